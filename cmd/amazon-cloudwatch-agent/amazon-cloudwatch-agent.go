@@ -9,6 +9,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	_ "net/http/pprof" // Comment this line to disable pprof endpoint.
@@ -29,8 +30,10 @@ import (
 	"github.com/influxdata/wlog"
 	"github.com/kardianos/service"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/otelcol"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
@@ -51,6 +54,10 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/downloader"
 	downloaderflags "github.com/aws/amazon-cloudwatch-agent/tool/downloader/flags"
 	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+	"github.com/aws/amazon-cloudwatch-agent/tool/schemaexport"
+	schemaexportflags "github.com/aws/amazon-cloudwatch-agent/tool/schemaexport/flags"
+	"github.com/aws/amazon-cloudwatch-agent/tool/telegrafimport"
+	telegrafimportflags "github.com/aws/amazon-cloudwatch-agent/tool/telegrafimport/flags"
 	"github.com/aws/amazon-cloudwatch-agent/tool/translator"
 	"github.com/aws/amazon-cloudwatch-agent/tool/wizard"
 	wizardflags "github.com/aws/amazon-cloudwatch-agent/tool/wizard/flags"
@@ -60,6 +67,9 @@ import (
 
 const (
 	defaultEnvCfgFileName = "env-config.json"
+	// componentTelemetryGate makes the collector add the component and
+	// pipeline IDs to the loggers of the components.
+	componentTelemetryGate = "telemetry.newPipelineTelemetry"
 )
 
 var fDebug = flag.Bool("debug", false,
@@ -171,7 +181,7 @@ func reloadLoop(
 							if err := wlog.SetLevelFromName(logLevel); err != nil {
 								log.Printf("E! Unable to set log level: %v\n", err)
 							}
-							cwaLogger.SetLevel(cwaLogger.ConvertToAtomicLevel(wlog.LogLevel()))
+							configureComponentLogLevels()
 							// Set AWS SDK logging
 							sdkLogLevel := os.Getenv(envconfig.AWS_SDK_LOG_LEVEL)
 							configaws.SetSDKLogLevel(sdkLogLevel)
@@ -223,6 +233,19 @@ func loadEnvironmentVariables(path string) error {
 	return nil
 }
 
+// configureComponentLogLevels applies the global log level along with the
+// component overrides from the env config.
+func configureComponentLogLevels() {
+	var levels map[string]zapcore.Level
+	if value := os.Getenv(envconfig.CWAgentComponentLogLevels); value != "" {
+		var err error
+		if levels, err = cwaLogger.ParseComponentLevels(value); err != nil {
+			log.Printf("E! Unable to set component log levels: %v\n", err)
+		}
+	}
+	cwaLogger.ConfigureLevels(wlog.LogLevel(), levels)
+}
+
 func getEnvConfigPath(configPath, envConfigPath string) (string, error) {
 	if configPath == "" {
 		return "", fmt.Errorf("no config file specified")
@@ -280,7 +303,13 @@ func runAgent(ctx context.Context,
 		LogWithTimezone:     "",
 	}
 
-	writer := logger.NewLogWriter(logConfig)
+	var writer io.Writer
+	if os.Getenv(envconfig.CWAgentLogFormat) == cwaLogger.LogFormatJSON {
+		writer = cwaLogger.NewJSONLogWriter(logConfig)
+	} else {
+		writer = cwaLogger.NewTextLogWriter(logConfig)
+	}
+	configureComponentLogLevels()
 
 	log.Printf("I! Starting AmazonCloudWatchAgent %s with log file %s with log target %s\n", version.Full(), ag.Config.Agent.Logfile, ag.Config.Agent.LogTarget)
 	// Need to set SDK log level before plugins get loaded.
@@ -343,6 +372,13 @@ func runAgent(ctx context.Context,
 		}
 	}
 	// Else start OTEL and rely on adapter package to start the logfile plugin.
+	if cwaLogger.Format() == cwaLogger.LogFormatJSON || cwaLogger.HasComponentLevels() {
+		// The collector only tells the loggers of the components which
+		// component and pipeline they belong to with this gate enabled.
+		if err = featuregate.GlobalRegistry().Set(componentTelemetryGate, true); err != nil {
+			log.Printf("W! Unable to enable %s, component log fields are not available: %v\n", componentTelemetryGate, err)
+		}
+	}
 	level := cwaLogger.ConvertToAtomicLevel(wlog.LogLevel())
 	logger, loggerOptions := cwaLogger.NewLogger(writer, level)
 
@@ -503,16 +539,20 @@ func main() {
 	// Check for subcommands first
 	if len(os.Args) > 1 {
 		subcommand := os.Args[1]
-		if subcommand == translatorflags.TranslatorCommand || subcommand == downloaderflags.Command || subcommand == wizardflags.Command {
+		if subcommand == translatorflags.TranslatorCommand || subcommand == downloaderflags.Command || subcommand == wizardflags.Command || subcommand == telegrafimportflags.Command || subcommand == schemaexportflags.Command {
 			subcommands := map[string]map[string]cmdwrapper.Flag{
 				translatorflags.TranslatorCommand: translatorflags.TranslatorFlags,
 				downloaderflags.Command:           downloaderflags.DownloaderFlags,
 				wizardflags.Command:               wizardflags.WizardFlags,
+				telegrafimportflags.Command:       telegrafimportflags.ImportFlags,
+				schemaexportflags.Command:         schemaexportflags.SchemaFlags,
 			}
 			handlers := map[string]func(map[string]*string) error{
 				translatorflags.TranslatorCommand: translator.RunTranslator,
 				downloaderflags.Command:           downloader.RunDownloaderFromFlags,
 				wizardflags.Command:               wizard.RunWizardFromFlags,
+				telegrafimportflags.Command:       telegrafimport.RunImporterFromFlags,
+				schemaexportflags.Command:         schemaexport.RunSchemaExportFromFlags,
 			}
 
 			if err := cmdwrapper.HandleSubcommand(subcommands, handlers); err != nil {
@@ -530,6 +570,8 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s\t\tTranslate configuration files\n", translatorflags.TranslatorCommand)
 		fmt.Fprintf(os.Stderr, "  %s\t\tDownload configuration from remote sources\n", downloaderflags.Command)
 		fmt.Fprintf(os.Stderr, "  %s\t\t\tInteractive configuration wizard\n", wizardflags.Command)
+		fmt.Fprintf(os.Stderr, "  %s\t\t\tImport the inputs of a telegraf.conf into an agent config\n", telegrafimportflags.Command)
+		fmt.Fprintf(os.Stderr, "  %s\t\t\tExport the documented JSON Schema of the agent config\n", schemaexportflags.Command)
 		fmt.Fprintf(os.Stderr, "\nUse '%s <subcommand> --help' for more information about a subcommand.\n", os.Args[0])
 	}
 
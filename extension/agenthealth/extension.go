@@ -10,12 +10,15 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/status"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/useragent"
 )
 
 type agentHealth struct {
 	logger *zap.Logger
 	cfg    *Config
+	// source identifies the extension in the agent status.
+	source string
 	component.StartFunc
 	component.ShutdownFunc
 }
@@ -23,8 +26,9 @@ type agentHealth struct {
 var _ awsmiddleware.Extension = (*agentHealth)(nil)
 
 func (ah *agentHealth) Handlers() ([]awsmiddleware.RequestHandler, []awsmiddleware.ResponseHandler) {
-	var responseHandlers []awsmiddleware.ResponseHandler
-	requestHandlers := []awsmiddleware.RequestHandler{useragent.NewHandler(ah.cfg.IsUsageDataEnabled)}
+	statusHandler := status.NewHandler(ah.source)
+	responseHandlers := []awsmiddleware.ResponseHandler{statusHandler}
+	requestHandlers := []awsmiddleware.RequestHandler{useragent.NewHandler(ah.cfg.IsUsageDataEnabled), statusHandler}
 
 	if !ah.cfg.IsUsageDataEnabled {
 		ah.logger.Debug("Usage data is disabled, skipping stats handlers")
@@ -55,5 +59,9 @@ func (ah *agentHealth) Handlers() ([]awsmiddleware.RequestHandler, []awsmiddlewa
 }
 
 func NewAgentHealth(logger *zap.Logger, cfg *Config) awsmiddleware.Extension {
-	return &agentHealth{logger: logger, cfg: cfg}
+	return newAgentHealth(logger, cfg, TypeStr.String())
+}
+
+func newAgentHealth(logger *zap.Logger, cfg *Config, source string) *agentHealth {
+	return &agentHealth{logger: logger, cfg: cfg, source: source}
 }
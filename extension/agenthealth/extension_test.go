@@ -21,15 +21,16 @@ func TestExtension(t *testing.T) {
 	assert.NotNil(t, extension)
 	assert.NoError(t, extension.Start(ctx, componenttest.NewNopHost()))
 	requestHandlers, responseHandlers := extension.Handlers()
-	// user agent, client stats, stats
-	assert.Len(t, requestHandlers, 3)
-	// client stats
-	assert.Len(t, responseHandlers, 2)
+	// user agent, status, client stats, stats
+	assert.Len(t, requestHandlers, 4)
+	// status, status code, client stats
+	assert.Len(t, responseHandlers, 3)
 	cfg.IsUsageDataEnabled = false
 	requestHandlers, responseHandlers = extension.Handlers()
-	// user agent
-	assert.Len(t, requestHandlers, 1)
-	assert.Len(t, responseHandlers, 0)
+	// user agent, status
+	assert.Len(t, requestHandlers, 2)
+	// status
+	assert.Len(t, responseHandlers, 1)
 	assert.NoError(t, extension.Shutdown(ctx))
 }
 
@@ -40,14 +41,15 @@ func TestExtensionStatusCodeOnly(t *testing.T) {
 	assert.NotNil(t, extension)
 	assert.NoError(t, extension.Start(ctx, componenttest.NewNopHost()))
 	requestHandlers, responseHandlers := extension.Handlers()
-	// user agent, client stats, stats
-	assert.Len(t, requestHandlers, 1)
-	// client stats
-	assert.Len(t, responseHandlers, 1)
+	// user agent, status
+	assert.Len(t, requestHandlers, 2)
+	// status, status code
+	assert.Len(t, responseHandlers, 2)
 	cfg.IsUsageDataEnabled = false
 	requestHandlers, responseHandlers = extension.Handlers()
-	// user agent
-	assert.Len(t, requestHandlers, 1)
-	assert.Len(t, responseHandlers, 0)
+	// user agent, status
+	assert.Len(t, requestHandlers, 2)
+	// status
+	assert.Len(t, responseHandlers, 1)
 	assert.NoError(t, extension.Shutdown(ctx))
 }
@@ -31,5 +31,5 @@ func createDefaultConfig() component.Config {
 }
 
 func createExtension(_ context.Context, settings extension.Settings, cfg component.Config) (extension.Extension, error) {
-	return NewAgentHealth(settings.Logger, cfg.(*Config)), nil
+	return newAgentHealth(settings.Logger, cfg.(*Config), settings.ID.String()), nil
 }
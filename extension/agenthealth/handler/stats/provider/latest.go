@@ -0,0 +1,17 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+)
+
+// LatestStats returns the most recent process and flag stats. Unlike Stats,
+// it does not count as a get, so it does not hold back the stats added to
+// the requests.
+func LatestStats() agent.Stats {
+	stats := GetProcessStats().(*processStats).getStats()
+	stats.Merge(GetFlagsStats().(*flagStats).getStats())
+	return stats
+}
@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package status
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/client"
+)
+
+const (
+	handlerID = "cloudwatchagent.Status"
+)
+
+// Handler records the requests and responses made through an agenthealth
+// extension in the registry.
+type Handler interface {
+	awsmiddleware.RequestHandler
+	awsmiddleware.ResponseHandler
+}
+
+type statusHandler struct {
+	source           string
+	registry         *Registry
+	getOperationName func(ctx context.Context) string
+
+	// mu keeps the client stats read after a response from being replaced
+	// by a concurrent response to the same operation.
+	mu          sync.Mutex
+	clientStats client.Stats
+}
+
+var _ Handler = (*statusHandler)(nil)
+
+// NewHandler returns a handler that records the responses of the requests
+// made through the extension identified by source, along with the latency,
+// payload size and entity rejection of each request.
+func NewHandler(source string) Handler {
+	return &statusHandler{
+		source:           source,
+		registry:         Get(),
+		getOperationName: awsmiddleware.GetOperationName,
+		clientStats:      client.NewHandler(agent.NewOperationsFilter(agent.AllowAllOperations)),
+	}
+}
+
+func (h *statusHandler) ID() string {
+	return handlerID
+}
+
+func (h *statusHandler) Position() awsmiddleware.HandlerPosition {
+	return awsmiddleware.After
+}
+
+func (h *statusHandler) HandleRequest(ctx context.Context, r *http.Request) {
+	h.clientStats.HandleRequest(ctx, r)
+}
+
+func (h *statusHandler) HandleResponse(ctx context.Context, r *http.Response) {
+	operation := h.getOperationName(ctx)
+	if operation == "" || r == nil {
+		return
+	}
+	h.mu.Lock()
+	h.clientStats.HandleResponse(ctx, r)
+	stats := h.clientStats.Stats(operation)
+	h.mu.Unlock()
+	h.registry.RecordResponse(h.source, operation, r.StatusCode, stats, time.Now())
+}
@@ -0,0 +1,185 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+const (
+	// PrometheusContentType is the content type of the text exposition format.
+	PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+	metricPrefix = "cwagent_"
+)
+
+type label struct {
+	name  string
+	value string
+}
+
+type promWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (pw *promWriter) family(name, metricType, help string) {
+	pw.printf("# HELP %s%s %s\n# TYPE %s%s %s\n", metricPrefix, name, help, metricPrefix, name, metricType)
+}
+
+func (pw *promWriter) sample(name string, value float64, labels ...label) {
+	var sb strings.Builder
+	sb.WriteString(metricPrefix)
+	sb.WriteString(name)
+	if len(labels) > 0 {
+		sb.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(l.name)
+			sb.WriteString(`="`)
+			sb.WriteString(escapeLabelValue(l.value))
+			sb.WriteByte('"')
+		}
+		sb.WriteByte('}')
+	}
+	pw.printf("%s %s\n", sb.String(), strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (pw *promWriter) printf(format string, args ...interface{}) {
+	if pw.err != nil {
+		return
+	}
+	_, pw.err = fmt.Fprintf(pw.w, format, args...)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// WritePrometheus writes the snapshot in the Prometheus text exposition
+// format.
+func WritePrometheus(w io.Writer, s Snapshot) error {
+	pw := &promWriter{w: w}
+
+	pw.family("up", "gauge", "Whether no component of the agent is in an error state.")
+	pw.sample("up", boolToFloat(s.Healthy()))
+	pw.family("info", "gauge", "Information about the agent.")
+	pw.sample("info", 1, label{"version", s.Version}, label{"status", s.Status})
+	pw.family("uptime_seconds", "gauge", "Seconds since the agent started.")
+	pw.sample("uptime_seconds", float64(s.UptimeSeconds))
+
+	pipelineIDs := make([]string, 0, len(s.Pipelines))
+	for id := range s.Pipelines {
+		pipelineIDs = append(pipelineIDs, id)
+	}
+	sort.Strings(pipelineIDs)
+	pw.family("component_healthy", "gauge", "Whether the component of a pipeline reports an OK status.")
+	for _, id := range pipelineIDs {
+		for _, c := range s.Pipelines[id].Components {
+			pw.sample("component_healthy", boolToFloat(c.Status == componentstatus.StatusOK.String()),
+				label{"pipeline", id}, label{"kind", c.Kind}, label{"component", c.ID})
+		}
+	}
+
+	pw.family("exporter_last_success_timestamp_seconds", "gauge", "Unix time of the last successful response to an operation.")
+	for _, e := range s.Exporters {
+		if e.LastSuccess != nil {
+			pw.sample("exporter_last_success_timestamp_seconds", float64(e.LastSuccess.Unix()),
+				label{"source", e.Source}, label{"operation", e.Operation})
+		}
+	}
+	pw.family("exporter_responses_total", "counter", "Responses to an operation by result.")
+	for _, e := range s.Exporters {
+		pw.sample("exporter_responses_total", float64(e.SuccessCount),
+			label{"source", e.Source}, label{"operation", e.Operation}, label{"result", "success"})
+		pw.sample("exporter_responses_total", float64(e.FailureCount),
+			label{"source", e.Source}, label{"operation", e.Operation}, label{"result", "failure"})
+	}
+	pw.family("exporter_status_codes_total", "counter", "Responses to an operation by status code.")
+	for _, e := range s.Exporters {
+		codes := make([]int, 0, len(e.StatusCodes))
+		for code := range e.StatusCodes {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			pw.sample("exporter_status_codes_total", float64(e.StatusCodes[code]),
+				label{"source", e.Source}, label{"operation", e.Operation}, label{"code", strconv.Itoa(code)})
+		}
+	}
+	pw.family("exporter_entity_rejected_total", "counter", "Responses to an operation that rejected the entity.")
+	for _, e := range s.Exporters {
+		pw.sample("exporter_entity_rejected_total", float64(e.EntityRejectedCount),
+			label{"source", e.Source}, label{"operation", e.Operation})
+	}
+	pw.family("exporter_last_latency_milliseconds", "gauge", "Latency of the last request of an operation.")
+	for _, e := range s.Exporters {
+		if e.LastLatencyMillis != nil {
+			pw.sample("exporter_last_latency_milliseconds", float64(*e.LastLatencyMillis),
+				label{"source", e.Source}, label{"operation", e.Operation})
+		}
+	}
+	pw.family("exporter_last_payload_bytes", "gauge", "Payload size of the last request of an operation.")
+	for _, e := range s.Exporters {
+		if e.LastPayloadBytes != nil {
+			pw.sample("exporter_last_payload_bytes", float64(*e.LastPayloadBytes),
+				label{"source", e.Source}, label{"operation", e.Operation})
+		}
+	}
+
+	queueNames := make([]string, 0, len(s.Queues))
+	for name := range s.Queues {
+		queueNames = append(queueNames, name)
+	}
+	sort.Strings(queueNames)
+	pw.family("queue_depth", "gauge", "Number of entries waiting in an agent queue.")
+	for _, name := range queueNames {
+		pw.sample("queue_depth", float64(s.Queues[name]), label{"queue", name})
+	}
+
+	counterNames := make([]string, 0, len(s.Counters))
+	for name := range s.Counters {
+		counterNames = append(counterNames, name)
+	}
+	sort.Strings(counterNames)
+	pw.family("events_total", "counter", "Number of events counted by an agent component.")
+	for _, name := range counterNames {
+		pw.sample("events_total", float64(s.Counters[name]), label{"counter", name})
+	}
+
+	if s.Stats.CPUPercent != nil {
+		pw.family("process_cpu_percent", "gauge", "CPU usage of the agent process.")
+		pw.sample("process_cpu_percent", *s.Stats.CPUPercent)
+	}
+	if s.Stats.MemoryBytes != nil {
+		pw.family("process_memory_bytes", "gauge", "Resident memory of the agent process.")
+		pw.sample("process_memory_bytes", float64(*s.Stats.MemoryBytes))
+	}
+	if s.Stats.FileDescriptorCount != nil {
+		pw.family("process_open_fds", "gauge", "Open file descriptors of the agent process.")
+		pw.sample("process_open_fds", float64(*s.Stats.FileDescriptorCount))
+	}
+	if s.Stats.ThreadCount != nil {
+		pw.family("process_threads", "gauge", "Threads of the agent process.")
+		pw.sample("process_threads", float64(*s.Stats.ThreadCount))
+	}
+	return pw.err
+}
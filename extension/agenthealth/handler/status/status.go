@@ -0,0 +1,327 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package status
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/provider"
+	"github.com/aws/amazon-cloudwatch-agent/internal/version"
+)
+
+const (
+	HealthOK       = "ok"
+	HealthStarting = "starting"
+	HealthStopping = "stopping"
+	HealthDegraded = "degraded"
+)
+
+var (
+	registrySingleton *Registry
+	registryOnce      sync.Once
+)
+
+// Registry keeps track of the health of the agent. The collector reports the
+// component statuses, the agenthealth handlers record the responses of the
+// AWS APIs, the outputs register their queues and the inputs register their
+// counters.
+type Registry struct {
+	mu         sync.RWMutex
+	start      time.Time
+	components map[string]*componentEntry
+	sends      map[sendKey]*SendStatus
+	queues     map[string]func() int
+	// counters are summed by name, since several instances of an input can
+	// register the same counter.
+	counters map[string][]*counterEntry
+
+	// latestStats is swapped out in tests.
+	latestStats func() agent.Stats
+}
+
+type componentEntry struct {
+	id        string
+	kind      string
+	pipelines []string
+	event     *componentstatus.Event
+}
+
+type sendKey struct {
+	source    string
+	operation string
+}
+
+// Snapshot is the state of the agent returned by the status endpoint.
+type Snapshot struct {
+	Status        string                    `json:"status"`
+	Version       string                    `json:"version"`
+	UptimeSeconds int64                     `json:"uptime_seconds"`
+	Pipelines     map[string]PipelineStatus `json:"pipelines"`
+	Exporters     []SendStatus              `json:"exporters"`
+	Queues        map[string]int            `json:"queues"`
+	Counters      map[string]int64          `json:"counters"`
+	Stats         agent.Stats               `json:"stats"`
+	Timestamp     time.Time                 `json:"timestamp"`
+}
+
+type PipelineStatus struct {
+	Healthy    bool              `json:"healthy"`
+	Components []ComponentStatus `json:"components"`
+}
+
+type ComponentStatus struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SendStatus summarizes the responses of one AWS API operation made through
+// an agenthealth extension.
+type SendStatus struct {
+	Source              string        `json:"source"`
+	Operation           string        `json:"operation"`
+	LastStatusCode      int           `json:"last_status_code"`
+	LastSuccess         *time.Time    `json:"last_success,omitempty"`
+	LastFailure         *time.Time    `json:"last_failure,omitempty"`
+	LastLatencyMillis   *int64        `json:"last_latency_ms,omitempty"`
+	LastPayloadBytes    *int          `json:"last_payload_bytes,omitempty"`
+	SuccessCount        int64         `json:"success_count"`
+	FailureCount        int64         `json:"failure_count"`
+	StatusCodes         map[int]int64 `json:"status_codes"`
+	EntityRejectedCount int64         `json:"entity_rejected_count"`
+}
+
+func newRegistry() *Registry {
+	return &Registry{
+		start:       time.Now(),
+		components:  map[string]*componentEntry{},
+		sends:       map[sendKey]*SendStatus{},
+		queues:      map[string]func() int{},
+		counters:    map[string][]*counterEntry{},
+		latestStats: provider.LatestStats,
+	}
+}
+
+// Get returns the registry shared by the agent.
+func Get() *Registry {
+	registryOnce.Do(func() {
+		registrySingleton = newRegistry()
+	})
+	return registrySingleton
+}
+
+// ComponentStatusChanged records the status of a collector component. The
+// processors of each pipeline are separate instances with the same component
+// ID, so the statuses are keyed by the whole instance ID.
+func (r *Registry) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	if source == nil || event == nil {
+		return
+	}
+	var pipelines []string
+	source.AllPipelineIDs(func(id pipeline.ID) bool {
+		pipelines = append(pipelines, id.String())
+		return true
+	})
+	sort.Strings(pipelines)
+	key := source.Kind().String() + "/" + source.ComponentID().String() + "/" + strings.Join(pipelines, ",")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.components[key] = &componentEntry{
+		id:        source.ComponentID().String(),
+		kind:      source.Kind().String(),
+		pipelines: pipelines,
+		event:     event,
+	}
+}
+
+// RecordResponse records the response to an operation sent by source. Any
+// 2xx status code counts as a successful send. The latency, payload size and
+// entity rejection are taken from the client stats of the request, if set.
+func (r *Registry) RecordResponse(source, operation string, statusCode int, stats agent.Stats, at time.Time) {
+	key := sendKey{source: source, operation: operation}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	send, ok := r.sends[key]
+	if !ok {
+		send = &SendStatus{Source: source, Operation: operation, StatusCodes: map[int]int64{}}
+		r.sends[key] = send
+	}
+	send.LastStatusCode = statusCode
+	send.StatusCodes[statusCode]++
+	if stats.LatencyMillis != nil {
+		send.LastLatencyMillis = stats.LatencyMillis
+	}
+	if stats.PayloadBytes != nil {
+		send.LastPayloadBytes = stats.PayloadBytes
+	}
+	if stats.EntityRejected != nil {
+		send.EntityRejectedCount += int64(*stats.EntityRejected)
+	}
+	if statusCode >= 200 && statusCode < 300 {
+		send.LastSuccess = &at
+		send.SuccessCount++
+	} else {
+		send.LastFailure = &at
+		send.FailureCount++
+	}
+}
+
+// RegisterQueue adds a queue whose depth is reported by the status endpoint.
+// The returned function removes it again.
+func (r *Registry) RegisterQueue(name string, depth func() int) func() {
+	r.mu.Lock()
+	r.queues[name] = depth
+	r.mu.Unlock()
+	return func() {
+		r.mu.Lock()
+		delete(r.queues, name)
+		r.mu.Unlock()
+	}
+}
+
+// counterEntry is a registered counter. The pointer identifies it when it is
+// removed.
+type counterEntry struct {
+	value func() int64
+}
+
+// RegisterCounter adds a counter whose value is reported by the status
+// endpoint. The values of the counters registered with the same name are
+// summed. The returned function removes it again.
+func (r *Registry) RegisterCounter(name string, value func() int64) func() {
+	entry := &counterEntry{value: value}
+	r.mu.Lock()
+	r.counters[name] = append(r.counters[name], entry)
+	r.mu.Unlock()
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		entries := slices.DeleteFunc(r.counters[name], func(e *counterEntry) bool {
+			return e == entry
+		})
+		if len(entries) == 0 {
+			delete(r.counters, name)
+		} else {
+			r.counters[name] = entries
+		}
+	}
+}
+
+// Snapshot returns the current state of the agent.
+func (r *Registry) Snapshot() Snapshot {
+	now := time.Now()
+	snapshot := Snapshot{
+		Status:        HealthOK,
+		Version:       version.Number(),
+		UptimeSeconds: int64(now.Sub(r.start).Seconds()),
+		Pipelines:     map[string]PipelineStatus{},
+		Exporters:     []SendStatus{},
+		Queues:        map[string]int{},
+		Counters:      map[string]int64{},
+		Stats:         r.latestStats(),
+		Timestamp:     now,
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, entry := range r.components {
+		component := ComponentStatus{
+			ID:        entry.id,
+			Kind:      entry.kind,
+			Status:    entry.event.Status().String(),
+			Timestamp: entry.event.Timestamp(),
+		}
+		if err := entry.event.Err(); err != nil {
+			component.Error = err.Error()
+		}
+		snapshot.Status = worseHealth(snapshot.Status, health(entry.event.Status()))
+		for _, id := range entry.pipelines {
+			p := snapshot.Pipelines[id]
+			p.Components = append(p.Components, component)
+			snapshot.Pipelines[id] = p
+		}
+	}
+	for id, p := range snapshot.Pipelines {
+		p.Healthy = true
+		for _, c := range p.Components {
+			if c.Status != componentstatus.StatusOK.String() {
+				p.Healthy = false
+			}
+		}
+		sort.Slice(p.Components, func(i, j int) bool {
+			if p.Components[i].Kind != p.Components[j].Kind {
+				return p.Components[i].Kind < p.Components[j].Kind
+			}
+			return p.Components[i].ID < p.Components[j].ID
+		})
+		snapshot.Pipelines[id] = p
+	}
+	for _, send := range r.sends {
+		exporter := *send
+		exporter.StatusCodes = make(map[int]int64, len(send.StatusCodes))
+		for code, count := range send.StatusCodes {
+			exporter.StatusCodes[code] = count
+		}
+		snapshot.Exporters = append(snapshot.Exporters, exporter)
+	}
+	sort.Slice(snapshot.Exporters, func(i, j int) bool {
+		if snapshot.Exporters[i].Source != snapshot.Exporters[j].Source {
+			return snapshot.Exporters[i].Source < snapshot.Exporters[j].Source
+		}
+		return snapshot.Exporters[i].Operation < snapshot.Exporters[j].Operation
+	})
+	for name, depth := range r.queues {
+		snapshot.Queues[name] = depth()
+	}
+	for name, entries := range r.counters {
+		var total int64
+		for _, entry := range entries {
+			total += entry.value()
+		}
+		snapshot.Counters[name] = total
+	}
+	return snapshot
+}
+
+// Healthy reports whether no component is in an error state.
+func (s Snapshot) Healthy() bool {
+	return s.Status != HealthDegraded
+}
+
+// health maps a component status to the overall health it implies.
+func health(status componentstatus.Status) string {
+	switch {
+	case componentstatus.StatusIsError(status):
+		return HealthDegraded
+	case status == componentstatus.StatusStarting || status == componentstatus.StatusNone:
+		return HealthStarting
+	case status == componentstatus.StatusStopping || status == componentstatus.StatusStopped:
+		return HealthStopping
+	}
+	return HealthOK
+}
+
+var healthSeverity = map[string]int{
+	HealthOK:       0,
+	HealthStarting: 1,
+	HealthStopping: 2,
+	HealthDegraded: 3,
+}
+
+func worseHealth(a, b string) string {
+	if healthSeverity[b] > healthSeverity[a] {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,254 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/client"
+)
+
+func newTestRegistry() *Registry {
+	r := newRegistry()
+	r.latestStats = func() agent.Stats {
+		return agent.Stats{CPUPercent: aws.Float64(1.5), MemoryBytes: aws.Uint64(1024)}
+	}
+	return r
+}
+
+func TestSnapshot(t *testing.T) {
+	r := newTestRegistry()
+	hostMetrics := pipeline.NewIDWithName(pipeline.SignalMetrics, "host")
+	hostLogs := pipeline.NewIDWithName(pipeline.SignalLogs, "host")
+	r.ComponentStatusChanged(
+		componentstatus.NewInstanceID(component.MustNewID("telegraf_cpu"), component.KindReceiver, hostMetrics),
+		componentstatus.NewEvent(componentstatus.StatusOK),
+	)
+	r.ComponentStatusChanged(
+		componentstatus.NewInstanceID(component.MustNewID("awscloudwatch"), component.KindExporter, hostMetrics),
+		componentstatus.NewEvent(componentstatus.StatusOK),
+	)
+	r.ComponentStatusChanged(
+		componentstatus.NewInstanceID(component.MustNewID("awscloudwatchlogs"), component.KindExporter, hostLogs),
+		componentstatus.NewEvent(componentstatus.StatusStarting),
+	)
+
+	snapshot := r.Snapshot()
+	assert.Equal(t, HealthStarting, snapshot.Status)
+	assert.True(t, snapshot.Healthy())
+	require.Len(t, snapshot.Pipelines, 2)
+	metrics := snapshot.Pipelines["metrics/host"]
+	assert.True(t, metrics.Healthy)
+	require.Len(t, metrics.Components, 2)
+	assert.Equal(t, "awscloudwatch", metrics.Components[0].ID)
+	assert.Equal(t, "Exporter", metrics.Components[0].Kind)
+	assert.False(t, snapshot.Pipelines["logs/host"].Healthy)
+	assert.Equal(t, 1.5, *snapshot.Stats.CPUPercent)
+
+	r.ComponentStatusChanged(
+		componentstatus.NewInstanceID(component.MustNewID("awscloudwatchlogs"), component.KindExporter, hostLogs),
+		componentstatus.NewPermanentErrorEvent(errors.New("access denied")),
+	)
+	snapshot = r.Snapshot()
+	assert.Equal(t, HealthDegraded, snapshot.Status)
+	assert.False(t, snapshot.Healthy())
+	assert.Equal(t, "access denied", snapshot.Pipelines["logs/host"].Components[0].Error)
+}
+
+func TestSnapshotProcessorInstances(t *testing.T) {
+	r := newTestRegistry()
+	hostMetrics := pipeline.NewIDWithName(pipeline.SignalMetrics, "host")
+	appSignals := pipeline.NewIDWithName(pipeline.SignalMetrics, "application_signals")
+	// the processor has one instance per pipeline
+	r.ComponentStatusChanged(
+		componentstatus.NewInstanceID(component.MustNewID("batch"), component.KindProcessor, hostMetrics),
+		componentstatus.NewPermanentErrorEvent(errors.New("failed")),
+	)
+	r.ComponentStatusChanged(
+		componentstatus.NewInstanceID(component.MustNewID("batch"), component.KindProcessor, appSignals),
+		componentstatus.NewEvent(componentstatus.StatusOK),
+	)
+
+	snapshot := r.Snapshot()
+	assert.Equal(t, HealthDegraded, snapshot.Status)
+	require.Len(t, snapshot.Pipelines["metrics/host"].Components, 1)
+	assert.Equal(t, "failed", snapshot.Pipelines["metrics/host"].Components[0].Error)
+	assert.False(t, snapshot.Pipelines["metrics/host"].Healthy)
+	assert.True(t, snapshot.Pipelines["metrics/application_signals"].Healthy)
+}
+
+func TestRecordResponse(t *testing.T) {
+	r := newTestRegistry()
+	first := time.Unix(1700000000, 0)
+	r.RecordResponse("agenthealth/logs", "PutLogEvents", http.StatusOK, agent.Stats{
+		LatencyMillis:  aws.Int64(120),
+		PayloadBytes:   aws.Int(2048),
+		EntityRejected: aws.Int(1),
+	}, first)
+	r.RecordResponse("agenthealth/logs", "PutLogEvents", http.StatusTooManyRequests, agent.Stats{
+		LatencyMillis: aws.Int64(30),
+	}, first.Add(time.Second))
+	r.RecordResponse("agenthealth/metrics", "PutMetricData", http.StatusOK, agent.Stats{}, first)
+
+	exporters := r.Snapshot().Exporters
+	require.Len(t, exporters, 2)
+	logs := exporters[0]
+	assert.Equal(t, "agenthealth/logs", logs.Source)
+	assert.Equal(t, http.StatusTooManyRequests, logs.LastStatusCode)
+	assert.Equal(t, first, *logs.LastSuccess)
+	assert.Equal(t, first.Add(time.Second), *logs.LastFailure)
+	assert.EqualValues(t, 1, logs.SuccessCount)
+	assert.EqualValues(t, 1, logs.FailureCount)
+	assert.Equal(t, map[int]int64{http.StatusOK: 1, http.StatusTooManyRequests: 1}, logs.StatusCodes)
+	assert.EqualValues(t, 30, *logs.LastLatencyMillis)
+	assert.Equal(t, 2048, *logs.LastPayloadBytes)
+	assert.EqualValues(t, 1, logs.EntityRejectedCount)
+	metrics := exporters[1]
+	assert.Nil(t, metrics.LastFailure)
+	assert.Nil(t, metrics.LastLatencyMillis)
+	assert.Nil(t, metrics.LastPayloadBytes)
+	assert.EqualValues(t, 0, metrics.EntityRejectedCount)
+}
+
+func TestRegisterQueue(t *testing.T) {
+	r := newTestRegistry()
+	depth := 5
+	unregister := r.RegisterQueue("cloudwatch/metrics", func() int { return depth })
+	assert.Equal(t, map[string]int{"cloudwatch/metrics": 5}, r.Snapshot().Queues)
+	depth = 2
+	assert.Equal(t, map[string]int{"cloudwatch/metrics": 2}, r.Snapshot().Queues)
+	unregister()
+	assert.Empty(t, r.Snapshot().Queues)
+}
+
+func TestRegisterCounter(t *testing.T) {
+	r := newTestRegistry()
+	var dropped int64 = 3
+	unregister := r.RegisterCounter("prometheus/first_observation_dropped", func() int64 { return dropped })
+	assert.Equal(t, map[string]int64{"prometheus/first_observation_dropped": 3}, r.Snapshot().Counters)
+	dropped = 4
+	assert.Equal(t, map[string]int64{"prometheus/first_observation_dropped": 4}, r.Snapshot().Counters)
+	unregister()
+	assert.Empty(t, r.Snapshot().Counters)
+}
+
+func TestRegisterCounterSumsInstances(t *testing.T) {
+	r := newTestRegistry()
+	unregisterFirst := r.RegisterCounter("prometheus/first_observation_dropped", func() int64 { return 3 })
+	unregisterSecond := r.RegisterCounter("prometheus/first_observation_dropped", func() int64 { return 4 })
+	assert.Equal(t, map[string]int64{"prometheus/first_observation_dropped": 7}, r.Snapshot().Counters)
+	// removing one instance keeps the counter of the other
+	unregisterFirst()
+	assert.Equal(t, map[string]int64{"prometheus/first_observation_dropped": 4}, r.Snapshot().Counters)
+	unregisterFirst()
+	assert.Equal(t, map[string]int64{"prometheus/first_observation_dropped": 4}, r.Snapshot().Counters)
+	unregisterSecond()
+	assert.Empty(t, r.Snapshot().Counters)
+}
+
+type mockClientStats struct {
+	requests int
+	stats    agent.Stats
+}
+
+var _ client.Stats = (*mockClientStats)(nil)
+
+func (m *mockClientStats) ID() string {
+	return "mock"
+}
+
+func (m *mockClientStats) Position() awsmiddleware.HandlerPosition {
+	return awsmiddleware.After
+}
+
+func (m *mockClientStats) HandleRequest(context.Context, *http.Request) {
+	m.requests++
+}
+
+func (m *mockClientStats) HandleResponse(context.Context, *http.Response) {
+}
+
+func (m *mockClientStats) Stats(string) agent.Stats {
+	return m.stats
+}
+
+func TestHandler(t *testing.T) {
+	r := newTestRegistry()
+	clientStats := &mockClientStats{
+		stats: agent.Stats{LatencyMillis: aws.Int64(15), PayloadBytes: aws.Int(512), EntityRejected: aws.Int(1)},
+	}
+	h := &statusHandler{
+		source:           "agenthealth/metrics",
+		registry:         r,
+		getOperationName: func(context.Context) string { return "PutMetricData" },
+		clientStats:      clientStats,
+	}
+	h.HandleRequest(context.Background(), &http.Request{})
+	assert.Equal(t, 1, clientStats.requests)
+	h.HandleResponse(context.Background(), &http.Response{StatusCode: http.StatusOK})
+	exporters := r.Snapshot().Exporters
+	require.Len(t, exporters, 1)
+	assert.Equal(t, "PutMetricData", exporters[0].Operation)
+	assert.NotNil(t, exporters[0].LastSuccess)
+	assert.EqualValues(t, 15, *exporters[0].LastLatencyMillis)
+	assert.Equal(t, 512, *exporters[0].LastPayloadBytes)
+	assert.EqualValues(t, 1, exporters[0].EntityRejectedCount)
+
+	h.getOperationName = func(context.Context) string { return "" }
+	h.HandleResponse(context.Background(), &http.Response{StatusCode: http.StatusOK})
+	assert.EqualValues(t, 1, r.Snapshot().Exporters[0].SuccessCount)
+}
+
+func TestWritePrometheus(t *testing.T) {
+	r := newTestRegistry()
+	r.ComponentStatusChanged(
+		componentstatus.NewInstanceID(component.MustNewID("awscloudwatch"), component.KindExporter,
+			pipeline.NewIDWithName(pipeline.SignalMetrics, "host")),
+		componentstatus.NewRecoverableErrorEvent(errors.New("throttled")),
+	)
+	r.RecordResponse("agenthealth/metrics", "PutMetricData", http.StatusOK, agent.Stats{
+		LatencyMillis:  aws.Int64(250),
+		PayloadBytes:   aws.Int(4096),
+		EntityRejected: aws.Int(1),
+	}, time.Unix(1700000000, 0))
+	r.RecordResponse("agenthealth/metrics", "PutMetricData", http.StatusRequestEntityTooLarge, agent.Stats{}, time.Unix(1700000001, 0))
+	r.RegisterQueue(`cloudwatchlogs/group/"stream"`, func() int { return 7 })
+	r.RegisterCounter("prometheus/first_observation_dropped", func() int64 { return 12 })
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePrometheus(&buf, r.Snapshot()))
+	got := buf.String()
+	for _, want := range []string{
+		"# TYPE cwagent_up gauge\ncwagent_up 0\n",
+		`cwagent_component_healthy{pipeline="metrics/host",kind="Exporter",component="awscloudwatch"} 0`,
+		`cwagent_exporter_last_success_timestamp_seconds{source="agenthealth/metrics",operation="PutMetricData"} 1.7e+09`,
+		`cwagent_exporter_responses_total{source="agenthealth/metrics",operation="PutMetricData",result="success"} 1`,
+		`cwagent_exporter_status_codes_total{source="agenthealth/metrics",operation="PutMetricData",code="200"} 1`,
+		`cwagent_exporter_status_codes_total{source="agenthealth/metrics",operation="PutMetricData",code="413"} 1`,
+		`cwagent_exporter_entity_rejected_total{source="agenthealth/metrics",operation="PutMetricData"} 1`,
+		`cwagent_exporter_last_latency_milliseconds{source="agenthealth/metrics",operation="PutMetricData"} 250`,
+		`cwagent_exporter_last_payload_bytes{source="agenthealth/metrics",operation="PutMetricData"} 4096`,
+		`cwagent_queue_depth{queue="cloudwatchlogs/group/\"stream\""} 7`,
+		`cwagent_events_total{counter="prometheus/first_observation_dropped"} 12`,
+		"cwagent_process_cpu_percent 1.5\n",
+		"cwagent_process_memory_bytes 1024\n",
+	} {
+		assert.Contains(t, got, want)
+	}
+	assert.NotContains(t, got, "cwagent_process_threads")
+}
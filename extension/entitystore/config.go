@@ -5,6 +5,9 @@ package entitystore
 
 import (
 	"go.opentelemetry.io/collector/component"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/entity"
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 )
 
 type Config struct {
@@ -14,6 +17,23 @@ type Config struct {
 	Profile        string `mapstructure:"profile,omitempty"`
 	RoleARN        string `mapstructure:"role_arn,omitempty"`
 	Filename       string `mapstructure:"shared_credential_file,omitempty"`
+	// EntityRules derive the service name and the environment of the log
+	// groups of the log files.
+	EntityRules []entity.Rule `mapstructure:"entity_rules,omitempty"`
+	// HostMetadata replaces IMDS with the metadata of a host that is not an
+	// EC2 instance, so that the entities have the account ID and the service
+	// name from the tags of the host.
+	HostMetadata *hostmetadata.Config `mapstructure:"host_metadata,omitempty"`
 }
 
 var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if _, err := entity.NewRules(cfg.EntityRules); err != nil {
+		return err
+	}
+	if cfg.HostMetadata != nil {
+		return cfg.HostMetadata.Validate()
+	}
+	return nil
+}
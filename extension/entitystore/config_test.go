@@ -8,6 +8,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/entity"
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 )
 
 func TestUnmarshalDefaultConfig(t *testing.T) {
@@ -16,3 +19,30 @@ func TestUnmarshalDefaultConfig(t *testing.T) {
 	assert.NoError(t, confmap.New().Unmarshal(cfg))
 	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
 }
+
+func TestValidateEntityRules(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	assert.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"entity_rules": []any{
+			map[string]any{
+				"source":       entity.RuleSourceLogGroup,
+				"pattern":      "/aws/(?P<app>.+)",
+				"service_name": "{{app}}",
+			},
+		},
+	}).Unmarshal(cfg))
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, []entity.Rule{{Source: entity.RuleSourceLogGroup, Pattern: "/aws/(?P<app>.+)", ServiceName: "{{app}}"}}, cfg.EntityRules)
+
+	cfg.EntityRules[0].Pattern = "("
+	assert.Error(t, cfg.Validate())
+}
+
+func TestValidateHostMetadata(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.HostMetadata = &hostmetadata.Config{File: "/etc/host-metadata.yaml"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.HostMetadata = &hostmetadata.Config{}
+	assert.Error(t, cfg.Validate())
+}
@@ -19,6 +19,8 @@ import (
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
 	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
+	"github.com/aws/amazon-cloudwatch-agent/internal/entity"
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/entityattributes"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
@@ -85,7 +87,11 @@ func (e *EntityStore) Start(ctx context.Context, host component.Host) error {
 	// These will be passed down to any object that requires access to IMDS or EC2
 	// API client so we have single source of truth for credential
 	e.done = make(chan struct{})
-	e.metadataprovider = getMetaDataProvider()
+	if e.config.HostMetadata != nil {
+		e.metadataprovider = hostmetadata.NewProvider(*e.config.HostMetadata)
+	} else {
+		e.metadataprovider = getMetaDataProvider()
+	}
 	e.mode = e.config.Mode
 	e.kubernetesMode = e.config.KubernetesMode
 	e.podTerminationCheckInterval = podTerminationCheckInterval
@@ -93,9 +99,11 @@ func (e *EntityStore) Start(ctx context.Context, host component.Host) error {
 		Profile:  e.config.Profile,
 		Filename: e.config.Filename,
 	}
-	e.serviceprovider = newServiceProvider(e.mode, e.config.Region, &e.ec2Info, e.metadataprovider, getEC2Provider, ec2CredentialConfig, e.done, e.logger)
-	switch e.mode {
-	case config.ModeEC2:
+	// the rules are validated with the config
+	entityRules, _ := entity.NewRules(e.config.EntityRules)
+	e.serviceprovider = newServiceProvider(e.mode, e.config.Region, &e.ec2Info, e.metadataprovider, getEC2Provider, ec2CredentialConfig, entityRules, e.done, e.logger)
+	// the host metadata provides the same information as IMDS on other hosts
+	if e.mode == config.ModeEC2 || e.config.HostMetadata != nil {
 		e.ec2Info = *newEC2Info(e.metadataprovider, e.done, e.config.Region, e.logger)
 		go e.ec2Info.initEc2Info()
 		// Instance metadata tags is not usable for EKS nodes
@@ -8,6 +8,8 @@ import (
 	"context"
 	"errors"
 	"log"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -23,6 +25,7 @@ import (
 	"golang.org/x/exp/maps"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/entityattributes"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
@@ -708,3 +711,36 @@ func assertIfNonEmpty(t *testing.T, message string, pattern string) {
 		assert.NotContains(t, message, pattern)
 	}
 }
+
+func TestEntityStore_HostMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "host-metadata.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("host_id: host-1\naccount_id: \"123456789012\"\ntags:\n  Application: billing\n"), 0600))
+	originalProvider := getMetaDataProvider
+	defer func() { getMetaDataProvider = originalProvider }()
+	getMetaDataProvider = func() ec2metadataprovider.MetadataProvider {
+		assert.Fail(t, "IMDS must not be used with host metadata")
+		return nil
+	}
+	e := EntityStore{
+		logger: zap.NewNop(),
+		config: &Config{Mode: config.ModeOnPremise, HostMetadata: &hostmetadata.Config{File: path}},
+	}
+	assert.NoError(t, e.Start(context.TODO(), nil))
+	defer e.Shutdown(context.TODO())
+
+	assert.Eventually(t, func() bool {
+		name, _ := e.serviceprovider.getServiceNameAndSource()
+		return e.ec2Info.GetAccountID() != "" && name != ServiceNameUnknown
+	}, 6*time.Second, 100*time.Millisecond)
+	assert.Equal(t, "host-1", e.ec2Info.GetInstanceID())
+	name, source := e.serviceprovider.getServiceNameAndSource()
+	assert.Equal(t, "billing", name)
+	assert.Equal(t, ServiceNameSourceResourceTags, source)
+	assert.Equal(t, map[string]*string{
+		entityattributes.EntityType:   aws.String(Service),
+		entityattributes.ServiceName:  aws.String("billing"),
+		entityattributes.AwsAccountId: aws.String("123456789012"),
+	}, e.createServiceKeyAttributes(ServiceAttribute{ServiceName: "billing"}))
+	// the host is not an EC2 instance
+	assert.Empty(t, e.createAttributeMap())
+}
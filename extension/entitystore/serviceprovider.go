@@ -12,6 +12,7 @@ import (
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
 	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
+	"github.com/aws/amazon-cloudwatch-agent/internal/entity"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 )
@@ -31,6 +32,7 @@ const (
 	ServiceNameSourceUnknown           = "Unknown"
 	ServiceNameSourceUserConfiguration = "UserConfiguration"
 	ServiceNameSourceK8sWorkload       = "K8sWorkload"
+	ServiceNameSourceEntityRule        = "EntityRule"
 
 	describeTagsJitterMax = 3600
 	describeTagsJitterMin = 3000
@@ -66,6 +68,7 @@ type serviceprovider struct {
 	imdsServiceName  string
 	autoScalingGroup autoscalinggroup
 	region           string
+	entityRules      *entity.Rules
 	done             chan struct{}
 	logger           *zap.Logger
 	mutex            sync.RWMutex
@@ -176,12 +179,17 @@ func mergeServiceAttributes(providers []serviceAttributeProvider) ServiceAttribu
 // service name is retrieved based on the following priority chain
 //  1. Incoming telemetry attributes
 //  2. CWA config
-//  3. instance tags - The tags attached to the EC2 instance. Only scrape for tag with the following key: service, application, app
-//  4. IAM Role - The IAM role name retrieved through IMDS(Instance Metadata Service)
+//  3. entity rules - The user-defined rules matching the log group name
+//  4. instance tags - The tags attached to the EC2 instance. Only scrape for tag with the following key: service, application, app
+//  5. IAM Role - The IAM role name retrieved through IMDS(Instance Metadata Service)
+//
+// The environment follows the same chain, with the Auto Scaling group below
+// the entity rules. The awsentity processor uses the same precedence.
 func (s *serviceprovider) logFileServiceAttribute(logFile LogFileGlob, logGroup LogGroupName) ServiceAttribute {
 	return mergeServiceAttributes([]serviceAttributeProvider{
 		func() ServiceAttribute { return s.serviceAttributeForLogGroup(logGroup) },
 		func() ServiceAttribute { return s.serviceAttributeForLogFile(logFile) },
+		func() ServiceAttribute { return s.serviceAttributeFromEntityRules(logGroup) },
 		s.serviceAttributeFromImdsTags,
 		s.serviceAttributeFromIamRole,
 		s.serviceAttributeFromAsg,
@@ -198,13 +206,21 @@ func (s *serviceprovider) getServiceNameAndSource() (string, string) {
 	return sa.ServiceName, sa.ServiceNameSource
 }
 
+// serviceAttributeForLogGroup ignores the unknown_service name the OTel SDKs
+// set by default, so it ranks below the entity rules like in the awsentity
+// processor.
 func (s *serviceprovider) serviceAttributeForLogGroup(logGroup LogGroupName) ServiceAttribute {
 	if logGroup == "" || s.logGroups == nil {
 		return ServiceAttribute{}
 	}
 	s.logMutex.RLock()
-	defer s.logMutex.RUnlock()
-	return s.logGroups[logGroup]
+	attr := s.logGroups[logGroup]
+	s.logMutex.RUnlock()
+	if strings.HasPrefix(attr.ServiceName, ServiceNameUnknown) {
+		attr.ServiceName = ""
+		attr.ServiceNameSource = ""
+	}
+	return attr
 }
 
 func (s *serviceprovider) serviceAttributeForLogFile(logFile LogFileGlob) ServiceAttribute {
@@ -216,6 +232,31 @@ func (s *serviceprovider) serviceAttributeForLogFile(logFile LogFileGlob) Servic
 	return s.logFiles[logFile]
 }
 
+// serviceAttributeFromEntityRules only matches the log group rules, the log
+// files have no other source the rules can match on.
+func (s *serviceprovider) serviceAttributeFromEntityRules(logGroup LogGroupName) ServiceAttribute {
+	if logGroup == "" {
+		return ServiceAttribute{}
+	}
+	match, ok := s.entityRules.Match(func(source, _ string) []string {
+		if source == entity.RuleSourceLogGroup {
+			return []string{string(logGroup)}
+		}
+		return nil
+	})
+	if !ok {
+		return ServiceAttribute{}
+	}
+	attr := ServiceAttribute{
+		ServiceName: match.ServiceName,
+		Environment: match.Environment,
+	}
+	if attr.ServiceName != "" {
+		attr.ServiceNameSource = ServiceNameSourceEntityRule
+	}
+	return attr
+}
+
 func (s *serviceprovider) serviceAttributeFromImdsTags() ServiceAttribute {
 	if s.GetIMDSServiceName() == "" {
 		return ServiceAttribute{}
@@ -317,10 +358,11 @@ func toLowerKeyMap(values []string) map[string]string {
 	return set
 }
 
-func newServiceProvider(mode string, region string, ec2Info *EC2Info, metadataProvider ec2metadataprovider.MetadataProvider, providerType ec2ProviderType, ec2Credential *configaws.CredentialConfig, done chan struct{}, logger *zap.Logger) serviceProviderInterface {
+func newServiceProvider(mode string, region string, ec2Info *EC2Info, metadataProvider ec2metadataprovider.MetadataProvider, providerType ec2ProviderType, ec2Credential *configaws.CredentialConfig, entityRules *entity.Rules, done chan struct{}, logger *zap.Logger) serviceProviderInterface {
 	return &serviceprovider{
 		mode:             mode,
 		region:           region,
+		entityRules:      entityRules,
 		ec2Info:          ec2Info,
 		metadataProvider: metadataProvider,
 		done:             done,
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
+	"github.com/aws/amazon-cloudwatch-agent/internal/entity"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 )
 
@@ -170,6 +171,8 @@ func Test_serviceprovider_serviceAttributeForLogGroup(t *testing.T) {
 	assert.Equal(t, ServiceAttribute{}, s.serviceAttributeForLogGroup(""))
 	assert.Equal(t, ServiceAttribute{}, s.serviceAttributeForLogGroup("othergroup"))
 	assert.Equal(t, ServiceAttribute{ServiceName: "test-service"}, s.serviceAttributeForLogGroup("group"))
+	s.logGroups["unknown"] = ServiceAttribute{ServiceName: "unknown_service:java", ServiceNameSource: ServiceNameSourceInstrumentation, Environment: "test-env"}
+	assert.Equal(t, ServiceAttribute{Environment: "test-env"}, s.serviceAttributeForLogGroup("unknown"))
 	s.logGroups = nil
 	assert.Equal(t, ServiceAttribute{}, s.serviceAttributeForLogGroup("group"))
 }
@@ -183,6 +186,21 @@ func Test_serviceprovider_serviceAttributeForLogFile(t *testing.T) {
 	assert.Equal(t, ServiceAttribute{}, s.serviceAttributeForLogFile("glob"))
 }
 
+func Test_serviceprovider_serviceAttributeFromEntityRules(t *testing.T) {
+	s := &serviceprovider{}
+	assert.Equal(t, ServiceAttribute{}, s.serviceAttributeFromEntityRules("/aws/billing/invoices"))
+
+	s.entityRules, _ = entity.NewRules([]entity.Rule{
+		{Source: entity.RuleSourceProcessName, Pattern: ".*", ServiceName: "test-process"},
+		{Source: entity.RuleSourceLogGroup, Pattern: "/aws/(?P<team>[^/]+)/(?P<app>[^/]+)", ServiceName: "{{app}}", Environment: "{{team}}"},
+		{Source: entity.RuleSourceLogGroup, Pattern: "/env/(?P<env>.+)", Environment: "{{env}}"},
+	})
+	assert.Equal(t, ServiceAttribute{}, s.serviceAttributeFromEntityRules(""))
+	assert.Equal(t, ServiceAttribute{}, s.serviceAttributeFromEntityRules("othergroup"))
+	assert.Equal(t, ServiceAttribute{ServiceName: "invoices", ServiceNameSource: ServiceNameSourceEntityRule, Environment: "billing"}, s.serviceAttributeFromEntityRules("/aws/billing/invoices"))
+	assert.Equal(t, ServiceAttribute{Environment: "prod"}, s.serviceAttributeFromEntityRules("/env/prod"))
+}
+
 func Test_serviceprovider_serviceAttributeFromEc2Tags(t *testing.T) {
 	s := &serviceprovider{}
 	assert.Equal(t, ServiceAttribute{}, s.serviceAttributeFromImdsTags())
@@ -240,11 +258,14 @@ func Test_serviceprovider_logFileServiceAttribute(t *testing.T) {
 	s.imdsServiceName = "test-service-from-tags"
 	assert.Equal(t, ServiceAttribute{ServiceName: "test-service-from-tags", ServiceNameSource: ServiceNameSourceResourceTags, Environment: "ec2:test-asg"}, s.logFileServiceAttribute("glob", "group"))
 
+	s.entityRules, _ = entity.NewRules([]entity.Rule{{Source: entity.RuleSourceLogGroup, Pattern: "gr(?P<suffix>.+)", ServiceName: "test-service-from-rule", Environment: "{{suffix}}"}})
+	assert.Equal(t, ServiceAttribute{ServiceName: "test-service-from-rule", ServiceNameSource: ServiceNameSourceEntityRule, Environment: "oup"}, s.logFileServiceAttribute("glob", "group"))
+
 	s.logFiles["glob"] = ServiceAttribute{ServiceName: "test-service-from-logfile", ServiceNameSource: ServiceNameSourceUserConfiguration}
-	assert.Equal(t, ServiceAttribute{ServiceName: "test-service-from-logfile", ServiceNameSource: ServiceNameSourceUserConfiguration, Environment: "ec2:test-asg"}, s.logFileServiceAttribute("glob", "group"))
+	assert.Equal(t, ServiceAttribute{ServiceName: "test-service-from-logfile", ServiceNameSource: ServiceNameSourceUserConfiguration, Environment: "oup"}, s.logFileServiceAttribute("glob", "group"))
 
 	s.logGroups["group"] = ServiceAttribute{ServiceName: "test-service-from-loggroup", ServiceNameSource: ServiceNameSourceInstrumentation}
-	assert.Equal(t, ServiceAttribute{ServiceName: "test-service-from-loggroup", ServiceNameSource: ServiceNameSourceInstrumentation, Environment: "ec2:test-asg"}, s.logFileServiceAttribute("glob", "group"))
+	assert.Equal(t, ServiceAttribute{ServiceName: "test-service-from-loggroup", ServiceNameSource: ServiceNameSourceInstrumentation, Environment: "oup"}, s.logFileServiceAttribute("glob", "group"))
 }
 
 func Test_serviceprovider_getServiceNameSource(t *testing.T) {
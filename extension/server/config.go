@@ -4,6 +4,9 @@
 package server
 
 import (
+	"fmt"
+	"net"
+
 	"go.opentelemetry.io/collector/component"
 )
 
@@ -12,6 +15,26 @@ type Config struct {
 	TLSCAPath     string `mapstructure:"tls_ca_path, omitempty"`
 	TLSCertPath   string `mapstructure:"tls_cert_path, omitempty"`
 	TLSKeyPath    string `mapstructure:"tls_key_path, omitempty"`
+	// StatusListenAddress is the address of the plain HTTP server serving the
+	// agent status. It is only allowed to listen on a loopback address.
+	StatusListenAddress string `mapstructure:"status_listen_addr,omitempty"`
 }
 
 var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c.StatusListenAddress == "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(c.StatusListenAddress)
+	if err != nil {
+		return fmt.Errorf("invalid status_listen_addr %q: %w", c.StatusListenAddress, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("status_listen_addr %q must use a loopback address", c.StatusListenAddress)
+	}
+	return nil
+}
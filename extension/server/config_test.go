@@ -16,3 +16,28 @@ func TestUnmarshalDefaultConfig(t *testing.T) {
 	assert.NoError(t, confmap.New().Unmarshal(cfg))
 	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
 }
+
+func TestValidate(t *testing.T) {
+	testCases := map[string]struct {
+		addr    string
+		wantErr bool
+	}{
+		"Empty":        {addr: ""},
+		"Localhost":    {addr: "localhost:4312"},
+		"IPv4Loopback": {addr: "127.0.0.1:4312"},
+		"IPv6Loopback": {addr: "[::1]:4312"},
+		"AllInterface": {addr: ":4312", wantErr: true},
+		"Remote":       {addr: "10.0.0.1:4312", wantErr: true},
+		"MissingPort":  {addr: "127.0.0.1", wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{StatusListenAddress: testCase.addr}
+			if testCase.wantErr {
+				assert.Error(t, cfg.Validate())
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"net/http"
 	"time"
 
@@ -13,9 +14,11 @@ import (
 	"github.com/jellydator/ttlcache/v3"
 	jsoniter "github.com/json-iterator/go"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/extension"
 	"go.uber.org/zap"
 
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/status"
 	"github.com/aws/amazon-cloudwatch-agent/extension/entitystore"
 	tlsInternal "github.com/aws/amazon-cloudwatch-agent/internal/tls"
 )
@@ -25,11 +28,13 @@ type Server struct {
 	config         *Config
 	jsonMarshaller jsoniter.API
 	httpsServer    *http.Server
+	statusServer   *http.Server
 	ctx            context.Context
 	watcher        *tlsInternal.CertWatcher
 }
 
 var _ extension.Extension = (*Server)(nil)
+var _ componentstatus.Watcher = (*Server)(nil)
 
 func (s *Server) setRouter(router *gin.Engine) {
 	router.Use(gin.Recovery())
@@ -39,6 +44,12 @@ func (s *Server) setRouter(router *gin.Engine) {
 	router.GET("/kubernetes/pod-to-service-env-map", s.k8sPodToServiceMapHandler)
 }
 
+func (s *Server) setStatusRouter(router *gin.Engine) {
+	router.Use(gin.Recovery())
+	router.GET("/status", s.statusHandler)
+	router.GET("/metrics", s.prometheusHandler)
+}
+
 func NewServer(logger *zap.Logger, config *Config) *Server {
 	s := &Server{
 		logger:         logger,
@@ -48,6 +59,12 @@ func NewServer(logger *zap.Logger, config *Config) *Server {
 	}
 	gin.SetMode(gin.ReleaseMode)
 
+	if config.StatusListenAddress != "" {
+		statusRouter := gin.New()
+		s.setStatusRouter(statusRouter)
+		s.statusServer = &http.Server{Addr: config.StatusListenAddress, Handler: statusRouter, ReadHeaderTimeout: 90 * time.Second}
+	}
+
 	// Initialize a new cert watcher with cert/key pair
 	watcher, err := tlsInternal.NewCertWatcher(config.TLSCertPath, config.TLSKeyPath, config.TLSCAPath, logger)
 	if err != nil {
@@ -81,6 +98,15 @@ func NewServer(logger *zap.Logger, config *Config) *Server {
 }
 
 func (s *Server) Start(context.Context, component.Host) error {
+	if s.statusServer != nil {
+		s.logger.Debug("Starting status server...")
+		go func() {
+			err := s.statusServer.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				s.logger.Error("failed to serve and listen for agent status", zap.Error(err))
+			}
+		}()
+	}
 	if s.httpsServer != nil {
 		s.logger.Debug("Starting HTTPS server...")
 		go func() {
@@ -95,11 +121,16 @@ func (s *Server) Start(context.Context, component.Host) error {
 
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.ctx.Done()
+	var errs []error
+	if s.statusServer != nil {
+		s.logger.Debug("Shutting down status server...")
+		errs = append(errs, s.statusServer.Shutdown(ctx))
+	}
 	if s.httpsServer != nil {
 		s.logger.Debug("Shutting down HTTPS server...")
-		return s.httpsServer.Shutdown(ctx)
+		errs = append(errs, s.httpsServer.Shutdown(ctx))
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 func (s *Server) reloadServer(config *tls.Config) error {
@@ -146,6 +177,40 @@ var getPodServiceEnvironmentMapping = func() *ttlcache.Cache[string, entitystore
 	)
 }
 
+// ComponentStatusChanged is called by the collector for every status change
+// of a component, which is what the status endpoint reports per pipeline.
+func (s *Server) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	status.Get().ComponentStatusChanged(source, event)
+}
+
+// statusHandler serves the agent status as JSON, or in the Prometheus text
+// format with ?format=prometheus. The response code is 503 while a
+// component is in an error state, so it can be used as a health check.
+func (s *Server) statusHandler(c *gin.Context) {
+	if c.Query("format") == "prometheus" {
+		s.prometheusHandler(c)
+		return
+	}
+	snapshot := getStatusSnapshot()
+	if !snapshot.Healthy() {
+		c.Writer.Header().Set("Content-Type", "application/json")
+		c.Writer.WriteHeader(http.StatusServiceUnavailable)
+	}
+	s.jsonHandler(c.Writer, snapshot)
+}
+
+func (s *Server) prometheusHandler(c *gin.Context) {
+	c.Writer.Header().Set("Content-Type", status.PrometheusContentType)
+	if err := status.WritePrometheus(c.Writer, getStatusSnapshot()); err != nil {
+		s.logger.Error("failed to write agent status", zap.Error(err))
+	}
+}
+
+// Added this for testing purpose
+var getStatusSnapshot = func() status.Snapshot {
+	return status.Get().Snapshot()
+}
+
 func (s *Server) jsonHandler(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	err := s.jsonMarshaller.NewEncoder(w).Encode(data)
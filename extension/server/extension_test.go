@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,9 +17,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jellydator/ttlcache/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/status"
 	"github.com/aws/amazon-cloudwatch-agent/extension/entitystore"
 )
 
@@ -277,6 +280,58 @@ func TestJSONHandler(t *testing.T) {
 	}
 }
 
+func TestStatusHandler(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	server := NewServer(logger, &Config{StatusListenAddress: "127.0.0.1:4312"})
+	assert.NotNil(t, server.statusServer)
+	assert.Nil(t, server.httpsServer)
+
+	tests := []struct {
+		name        string
+		snapshot    status.Snapshot
+		query       string
+		wantCode    int
+		wantType    string
+		wantContent string
+	}{
+		{
+			name:        "Healthy",
+			snapshot:    status.Snapshot{Status: status.HealthOK, Queues: map[string]int{"cloudwatch/metrics": 3}},
+			wantCode:    http.StatusOK,
+			wantType:    "application/json",
+			wantContent: `"queues":{"cloudwatch/metrics":3}`,
+		},
+		{
+			name:        "Degraded",
+			snapshot:    status.Snapshot{Status: status.HealthDegraded},
+			wantCode:    http.StatusServiceUnavailable,
+			wantType:    "application/json",
+			wantContent: `"status":"degraded"`,
+		},
+		{
+			name:        "Prometheus",
+			snapshot:    status.Snapshot{Status: status.HealthDegraded, Queues: map[string]int{"cloudwatch/metrics": 3}},
+			query:       "?format=prometheus",
+			wantCode:    http.StatusOK,
+			wantType:    status.PrometheusContentType,
+			wantContent: "cwagent_up 0\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getStatusSnapshot = func() status.Snapshot { return tt.snapshot }
+			defer func() { getStatusSnapshot = func() status.Snapshot { return status.Get().Snapshot() } }()
+			router := gin.New()
+			server.setStatusRouter(router)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/status"+tt.query, nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantType, w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Body.String(), tt.wantContent)
+		})
+	}
+}
+
 func TestServerStartAndShutdown(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
@@ -296,6 +351,12 @@ func TestServerStartAndShutdown(t *testing.T) {
 				ListenAddress: ":8080",
 			},
 		},
+		{
+			name: "StatusServer",
+			config: &Config{
+				StatusListenAddress: "127.0.0.1:0",
+			},
+		},
 		{
 			name: "EmptyHTTPSServer",
 			config: &Config{
@@ -322,6 +383,32 @@ func TestServerStartAndShutdown(t *testing.T) {
 	}
 }
 
+func TestShutdownBothServers(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	server := NewServer(logger, &Config{StatusListenAddress: "127.0.0.1:0"})
+	server.httpsServer = &http.Server{Addr: "127.0.0.1:0", ReadHeaderTimeout: time.Second}
+
+	// keep a request to the status server in flight, so that its shutdown
+	// fails with the cancelled context
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	inFlight := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	server.statusServer.Handler = http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		close(inFlight)
+		<-release
+	})
+	go server.statusServer.Serve(listener)
+	go http.Get("http://" + listener.Addr().String())
+	<-inFlight
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, server.Shutdown(ctx), context.Canceled)
+	assert.ErrorIs(t, server.httpsServer.ListenAndServe(), http.ErrServerClosed)
+}
+
 func TestConvertTtlCacheToMap(t *testing.T) {
 	podToServiceMap := map[string]entitystore.ServiceEnvironment{
 		"pod1": {
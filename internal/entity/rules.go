@@ -0,0 +1,134 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/capture"
+)
+
+// The sources a rule can match on.
+const (
+	// RuleSourceLogGroup matches the log group names of the telemetry.
+	RuleSourceLogGroup = "log_group"
+	// RuleSourceProcessName matches the executable name of the process.
+	RuleSourceProcessName = "process_name"
+	// RuleSourceContainerLabel matches the label of the container with the
+	// rule key.
+	RuleSourceContainerLabel = "container_label"
+	// RuleSourceResourceAttribute matches the resource attribute with the
+	// rule key.
+	RuleSourceResourceAttribute = "resource_attribute"
+)
+
+// Rule sets the service name and the environment of the entities with a
+// matching source. The service name and the environment can refer to the
+// named groups of the pattern as {{name}}.
+type Rule struct {
+	Name        string `mapstructure:"name,omitempty"`
+	Source      string `mapstructure:"source"`
+	Key         string `mapstructure:"key,omitempty"`
+	Pattern     string `mapstructure:"pattern"`
+	ServiceName string `mapstructure:"service_name,omitempty"`
+	Environment string `mapstructure:"environment,omitempty"`
+	// Priority orders the rules, the highest priority is matched first.
+	// Rules of the same priority are matched in their configured order.
+	Priority int `mapstructure:"priority,omitempty"`
+}
+
+func (r Rule) Validate() error {
+	switch r.Source {
+	case RuleSourceLogGroup, RuleSourceProcessName:
+	case RuleSourceContainerLabel, RuleSourceResourceAttribute:
+		if r.Key == "" {
+			return fmt.Errorf("key must be set for %s entity rule", r.Source)
+		}
+	default:
+		return fmt.Errorf("unknown entity rule source %q", r.Source)
+	}
+	if r.ServiceName == "" && r.Environment == "" {
+		return errors.New("service_name or environment must be set for entity rule")
+	}
+	if _, err := regexp.Compile(r.Pattern); err != nil {
+		return fmt.Errorf("invalid entity rule pattern %q: %w", r.Pattern, err)
+	}
+	return nil
+}
+
+// RuleMatch is the result of the first matching rule.
+type RuleMatch struct {
+	Rule        string
+	ServiceName string
+	Environment string
+}
+
+type compiledRule struct {
+	Rule
+	name  string
+	regex *regexp.Regexp
+}
+
+// Rules are the compiled entity rules in the order they are matched.
+type Rules struct {
+	rules []compiledRule
+}
+
+// NewRules validates and compiles the rules. Rules without a name are named
+// after their source and index.
+func NewRules(rules []Rule) (*Rules, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("%s#%d", rule.Source, i)
+		}
+		compiled = append(compiled, compiledRule{
+			Rule:  rule,
+			name:  name,
+			regex: regexp.MustCompile("^(?:" + rule.Pattern + ")$"),
+		})
+	}
+	sort.SliceStable(compiled, func(i, j int) bool {
+		return compiled[i].Priority > compiled[j].Priority
+	})
+	return &Rules{rules: compiled}, nil
+}
+
+// Len returns the number of rules, it is safe to call on nil rules.
+func (r *Rules) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.rules)
+}
+
+// Match returns the first rule with a matching value. values returns the
+// values of a source, e.g. every log group name of the telemetry.
+func (r *Rules) Match(values func(source, key string) []string) (RuleMatch, bool) {
+	if r == nil {
+		return RuleMatch{}, false
+	}
+	for _, rule := range r.rules {
+		for _, value := range values(rule.Source, rule.Key) {
+			submatches := rule.regex.FindStringSubmatch(value)
+			if submatches == nil {
+				continue
+			}
+			captures := capture.Named(rule.regex, submatches)
+			return RuleMatch{
+				Rule:        rule.name,
+				ServiceName: capture.Expand(rule.ServiceName, captures),
+				Environment: capture.Expand(rule.Environment, captures),
+			}, true
+		}
+	}
+	return RuleMatch{}, false
+}
@@ -0,0 +1,107 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleValidate(t *testing.T) {
+	testCases := map[string]struct {
+		rule    Rule
+		wantErr string
+	}{
+		"LogGroup": {
+			rule: Rule{Source: RuleSourceLogGroup, Pattern: "/aws/app/.*", ServiceName: "app"},
+		},
+		"EnvironmentOnly": {
+			rule: Rule{Source: RuleSourceProcessName, Pattern: "java", Environment: "prod"},
+		},
+		"UnknownSource": {
+			rule:    Rule{Source: "tag", Pattern: ".*", ServiceName: "app"},
+			wantErr: `unknown entity rule source "tag"`,
+		},
+		"MissingKey": {
+			rule:    Rule{Source: RuleSourceContainerLabel, Pattern: ".*", ServiceName: "app"},
+			wantErr: "key must be set for container_label entity rule",
+		},
+		"MissingTarget": {
+			rule:    Rule{Source: RuleSourceResourceAttribute, Key: "k8s.namespace.name", Pattern: ".*"},
+			wantErr: "service_name or environment must be set for entity rule",
+		},
+		"InvalidPattern": {
+			rule:    Rule{Source: RuleSourceLogGroup, Pattern: "(", ServiceName: "app"},
+			wantErr: `invalid entity rule pattern "("`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.rule.Validate()
+			if testCase.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestRulesMatch(t *testing.T) {
+	rules, err := NewRules([]Rule{
+		{Source: RuleSourceLogGroup, Pattern: "/aws/(?P<team>[^/]+)/(?P<app>[^/]+)", ServiceName: "{{team}}-{{app}}", Environment: "{{stage}}"},
+		{Name: "payments", Source: RuleSourceProcessName, Pattern: "payments-.*", ServiceName: "payments", Environment: "prod", Priority: 10},
+		{Source: RuleSourceResourceAttribute, Key: "k8s.namespace.name", Pattern: "(?P<env>.+)-ns", Environment: "{{env}}"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, rules.Len())
+
+	values := map[string][]string{
+		RuleSourceLogGroup:    {"other", "/aws/billing/invoices"},
+		RuleSourceProcessName: {"payments-worker"},
+		RuleSourceResourceAttribute + "/k8s.namespace.name": {"staging-ns"},
+	}
+	lookup := func(source, key string) []string {
+		if key != "" {
+			return values[source+"/"+key]
+		}
+		return values[source]
+	}
+
+	// the rule with the highest priority is matched first
+	got, ok := rules.Match(lookup)
+	assert.True(t, ok)
+	assert.Equal(t, RuleMatch{Rule: "payments", ServiceName: "payments", Environment: "prod"}, got)
+
+	// captures are expanded, unknown references are kept
+	delete(values, RuleSourceProcessName)
+	got, ok = rules.Match(lookup)
+	assert.True(t, ok)
+	assert.Equal(t, RuleMatch{Rule: "log_group#0", ServiceName: "billing-invoices", Environment: "{{stage}}"}, got)
+
+	// patterns must match the whole value
+	values[RuleSourceLogGroup] = []string{"prefix/aws/billing/invoices"}
+	got, ok = rules.Match(lookup)
+	assert.True(t, ok)
+	assert.Equal(t, RuleMatch{Rule: "resource_attribute#2", Environment: "staging"}, got)
+
+	delete(values, RuleSourceResourceAttribute+"/k8s.namespace.name")
+	_, ok = rules.Match(lookup)
+	assert.False(t, ok)
+}
+
+func TestNewRulesInvalid(t *testing.T) {
+	rules, err := NewRules([]Rule{{Source: RuleSourceLogGroup, Pattern: ".*"}})
+	assert.Error(t, err)
+	assert.Nil(t, rules)
+}
+
+func TestRulesNil(t *testing.T) {
+	var rules *Rules
+	assert.Equal(t, 0, rules.Len())
+	_, ok := rules.Match(func(string, string) []string { return []string{"value"} })
+	assert.False(t, ok)
+}
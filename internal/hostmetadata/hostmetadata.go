@@ -0,0 +1,103 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package hostmetadata reads the metadata of a host that has no instance
+// metadata service, e.g. an on-premise server, from a local YAML/JSON file or
+// from the output of a command. The metadata provides the same attributes as
+// EC2, so it can be used in place of IMDS.
+package hostmetadata
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultCommandTimeout is how long the command has to print the metadata.
+const defaultCommandTimeout = 10 * time.Second
+
+// Config is where the host metadata is read from. Exactly one of File and
+// Command must be set.
+type Config struct {
+	// File is the path of a YAML or JSON file.
+	File string `mapstructure:"file,omitempty"`
+	// Command is the program and its arguments that print the metadata as
+	// YAML or JSON to stdout.
+	Command []string `mapstructure:"command,omitempty"`
+}
+
+// Validate checks that the config has exactly one source.
+func (cfg *Config) Validate() error {
+	if (cfg.File == "") == (len(cfg.Command) == 0) {
+		return errors.New("host_metadata must set exactly one of file and command")
+	}
+	return nil
+}
+
+// Metadata is the metadata of the host. The availability zone can be any
+// location of the host, e.g. the name of the data center.
+type Metadata struct {
+	HostID           string            `yaml:"host_id"`
+	Hostname         string            `yaml:"hostname"`
+	Region           string            `yaml:"region"`
+	AvailabilityZone string            `yaml:"availability_zone"`
+	AccountID        string            `yaml:"account_id"`
+	InstanceType     string            `yaml:"instance_type"`
+	ImageID          string            `yaml:"image_id"`
+	Tags             map[string]string `yaml:"tags"`
+}
+
+// Parse parses the YAML or JSON metadata. Unknown keys are rejected so that
+// typos are not silently ignored.
+func Parse(data []byte) (*Metadata, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var metadata Metadata
+	if err := decoder.Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("invalid host metadata: %w", err)
+	}
+	if metadata.HostID == "" {
+		return nil, errors.New("invalid host metadata: host_id must be set")
+	}
+	return &metadata, nil
+}
+
+// Load reads and parses the metadata from the configured source.
+func Load(ctx context.Context, cfg Config) (*Metadata, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	var data []byte
+	var err error
+	if cfg.File != "" {
+		data, err = os.ReadFile(cfg.File)
+	} else {
+		data, err = runCommand(ctx, cfg.Command)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+func runCommand(ctx context.Context, command []string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultCommandTimeout)
+	defer cancel()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("host metadata command %q timed out: %w", command[0], ctx.Err())
+		}
+		return nil, fmt.Errorf("host metadata command %q failed: %w: %s", command[0], err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out, nil
+}
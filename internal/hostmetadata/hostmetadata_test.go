@@ -0,0 +1,126 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package hostmetadata
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testYAML = `
+host_id: mi-0123456789abcdef0
+hostname: rack1-node7
+region: us-west-2
+availability_zone: dc-portland-1
+account_id: "123456789012"
+tags:
+  Name: node7
+  team:owner: storage
+`
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, (&Config{File: "metadata.yaml"}).Validate())
+	assert.NoError(t, (&Config{Command: []string{"print-metadata"}}).Validate())
+	assert.Error(t, (&Config{}).Validate())
+	assert.Error(t, (&Config{File: "metadata.yaml", Command: []string{"print-metadata"}}).Validate())
+}
+
+func TestParse(t *testing.T) {
+	metadata, err := Parse([]byte(testYAML))
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{
+		HostID:           "mi-0123456789abcdef0",
+		Hostname:         "rack1-node7",
+		Region:           "us-west-2",
+		AvailabilityZone: "dc-portland-1",
+		AccountID:        "123456789012",
+		Tags:             map[string]string{"Name": "node7", "team:owner": "storage"},
+	}, metadata)
+
+	metadata, err = Parse([]byte(`{"host_id": "host-1", "tags": {"Name": "web"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{HostID: "host-1", Tags: map[string]string{"Name": "web"}}, metadata)
+
+	_, err = Parse([]byte(`{"hostname": "web"}`))
+	assert.ErrorContains(t, err, "host_id must be set")
+	_, err = Parse([]byte(`{"host_id": "host-1", "zone": "a"}`))
+	assert.ErrorContains(t, err, "field zone not found")
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metadata.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testYAML), 0600))
+	metadata, err := Load(context.Background(), Config{File: path})
+	require.NoError(t, err)
+	assert.Equal(t, "mi-0123456789abcdef0", metadata.HostID)
+
+	_, err = Load(context.Background(), Config{File: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.Error(t, err)
+}
+
+func TestLoadCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command needs a POSIX shell")
+	}
+	metadata, err := Load(context.Background(), Config{Command: []string{"sh", "-c", `echo '{"host_id": "host-1"}'`}})
+	require.NoError(t, err)
+	assert.Equal(t, "host-1", metadata.HostID)
+
+	_, err = Load(context.Background(), Config{Command: []string{"sh", "-c", "echo unavailable >&2; exit 1"}})
+	assert.ErrorContains(t, err, "unavailable")
+}
+
+func TestProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metadata.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testYAML), 0600))
+	ctx := context.Background()
+	p := NewProvider(Config{File: path})
+
+	doc, err := p.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ec2metadata.EC2InstanceIdentityDocument{
+		InstanceID:       "mi-0123456789abcdef0",
+		Region:           "us-west-2",
+		AvailabilityZone: "dc-portland-1",
+		AccountID:        "123456789012",
+	}, doc)
+	hostname, err := p.Hostname(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "rack1-node7", hostname)
+	tags, err := p.InstanceTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Name", "team:owner"}, tags)
+	value, err := p.InstanceTagValue(ctx, "team:owner")
+	require.NoError(t, err)
+	assert.Equal(t, "storage", value)
+	_, err = p.InstanceTagValue(ctx, "missing")
+	assert.Error(t, err)
+	role, err := p.ClientIAMRole(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, role)
+
+	// the metadata is kept until refreshed
+	require.NoError(t, os.WriteFile(path, []byte(`{"host_id": "host-2", "tags": {"Name": "node8"}}`), 0600))
+	id, err := p.InstanceID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "mi-0123456789abcdef0", id)
+	metadata, err := p.Refresh(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "host-2", metadata.HostID)
+
+	// a failed refresh keeps the previous metadata
+	require.NoError(t, os.Remove(path))
+	_, err = p.Refresh(ctx)
+	assert.Error(t, err)
+	id, err = p.InstanceID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "host-2", id)
+}
@@ -0,0 +1,123 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package hostmetadata
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
+)
+
+// Provider serves the host metadata in place of IMDS. The metadata is loaded
+// on first use and kept until Refresh is called.
+type Provider struct {
+	cfg Config
+
+	mu       sync.Mutex
+	metadata *Metadata
+}
+
+var _ ec2metadataprovider.MetadataProvider = (*Provider)(nil)
+
+func NewProvider(cfg Config) *Provider {
+	return &Provider{cfg: cfg}
+}
+
+// Metadata returns the loaded metadata, loading it if needed.
+func (p *Provider) Metadata(ctx context.Context) (*Metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+	return p.load(ctx)
+}
+
+// Refresh loads the metadata again. The previous metadata is kept if the
+// source cannot be read.
+func (p *Provider) Refresh(ctx context.Context) (*Metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.load(ctx)
+}
+
+func (p *Provider) load(ctx context.Context) (*Metadata, error) {
+	metadata, err := Load(ctx, p.cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.metadata = metadata
+	return metadata, nil
+}
+
+func (p *Provider) Get(ctx context.Context) (ec2metadata.EC2InstanceIdentityDocument, error) {
+	metadata, err := p.Metadata(ctx)
+	if err != nil {
+		return ec2metadata.EC2InstanceIdentityDocument{}, err
+	}
+	return ec2metadata.EC2InstanceIdentityDocument{
+		InstanceID:       metadata.HostID,
+		Region:           metadata.Region,
+		AvailabilityZone: metadata.AvailabilityZone,
+		AccountID:        metadata.AccountID,
+		InstanceType:     metadata.InstanceType,
+		ImageID:          metadata.ImageID,
+	}, nil
+}
+
+func (p *Provider) Hostname(ctx context.Context) (string, error) {
+	metadata, err := p.Metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	if metadata.Hostname != "" {
+		return metadata.Hostname, nil
+	}
+	return os.Hostname()
+}
+
+func (p *Provider) InstanceID(ctx context.Context) (string, error) {
+	metadata, err := p.Metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	return metadata.HostID, nil
+}
+
+// InstanceTags returns the sorted tag keys.
+func (p *Provider) InstanceTags(ctx context.Context) ([]string, error) {
+	metadata, err := p.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(metadata.Tags))
+	for key := range metadata.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (p *Provider) InstanceTagValue(ctx context.Context, tagKey string) (string, error) {
+	metadata, err := p.Metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	value, ok := metadata.Tags[tagKey]
+	if !ok {
+		return "", fmt.Errorf("host metadata has no tag %q", tagKey)
+	}
+	return value, nil
+}
+
+// ClientIAMRole returns no role, since the host has no instance profile.
+func (p *Provider) ClientIAMRole(context.Context) (string, error) {
+	return "", nil
+}
@@ -47,3 +47,12 @@ func (m *MapWithExpiry) Size() int {
 func (m *MapWithExpiry) Delete(key string) {
 	delete(m.entris, key)
 }
+
+// Range calls f for each entry until f returns false.
+func (m *MapWithExpiry) Range(f func(key string, content interface{}) bool) {
+	for k, v := range m.entris {
+		if !f(k, v.content) {
+			return
+		}
+	}
+}
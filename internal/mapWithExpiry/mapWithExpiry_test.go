@@ -52,3 +52,23 @@ func TestMapWithExpiry_cleanup(t *testing.T) {
 	assert.Equal(t, nil, val)
 	assert.Equal(t, 0, store.Size())
 }
+
+func TestMapWithExpiry_range(t *testing.T) {
+	store := NewMapWithExpiry(time.Second)
+	store.Set("key1", "value1")
+	store.Set("key2", "value2")
+
+	got := map[string]interface{}{}
+	store.Range(func(key string, content interface{}) bool {
+		got[key] = content
+		return true
+	})
+	assert.Equal(t, map[string]interface{}{"key1": "value1", "key2": "value2"}, got)
+
+	calls := 0
+	store.Range(func(string, interface{}) bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package capture expands the {{name}} references to the named groups of a
+// regular expression, which the entity and the Application Signals rules use
+// in the values they set.
+package capture
+
+import (
+	"regexp"
+	"strings"
+)
+
+var referencePattern = regexp.MustCompile(`\{\{(\w+)\}\}`)
+
+// Named returns the values of the named groups of the regex in the
+// submatches of a match. It returns nil if the regex has no named groups.
+func Named(regex *regexp.Regexp, submatches []string) map[string]string {
+	var captures map[string]string
+	for i, name := range regex.SubexpNames() {
+		if name == "" || i >= len(submatches) {
+			continue
+		}
+		if captures == nil {
+			captures = map[string]string{}
+		}
+		captures[name] = submatches[i]
+	}
+	return captures
+}
+
+// Expand replaces the {{name}} references of the template with the captures.
+// References to unknown captures are kept as written.
+func Expand(template string, captures map[string]string) string {
+	if len(captures) == 0 || !strings.Contains(template, "{{") {
+		return template
+	}
+	return referencePattern.ReplaceAllStringFunc(template, func(reference string) string {
+		if capture, ok := captures[reference[2:len(reference)-2]]; ok {
+			return capture
+		}
+		return reference
+	})
+}
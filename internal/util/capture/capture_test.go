@@ -0,0 +1,41 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package capture
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamed(t *testing.T) {
+	regex := regexp.MustCompile(`^/aws/(?P<service>[^/]+)/(\w+)(?:-(?P<env>\w+))?$`)
+	assert.Equal(t, map[string]string{"service": "cart", "env": "prod"},
+		Named(regex, regex.FindStringSubmatch("/aws/cart/app-prod")))
+	assert.Equal(t, map[string]string{"service": "cart", "env": ""},
+		Named(regex, regex.FindStringSubmatch("/aws/cart/app")))
+	unnamed := regexp.MustCompile(`^(\w+)$`)
+	assert.Nil(t, Named(unnamed, unnamed.FindStringSubmatch("cart")))
+}
+
+func TestExpand(t *testing.T) {
+	captures := map[string]string{"service": "cart", "env": "prod"}
+	testCases := map[string]struct {
+		template string
+		captures map[string]string
+		want     string
+	}{
+		"NoReference":      {template: "billing", captures: captures, want: "billing"},
+		"References":       {template: "{{service}}-{{env}}", captures: captures, want: "cart-prod"},
+		"UnknownReference": {template: "{{service}}-{{region}}", captures: captures, want: "cart-{{region}}"},
+		"NoCaptures":       {template: "{{service}}", want: "{{service}}"},
+		"DollarSyntax":     {template: "${service}", captures: captures, want: "${service}"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, Expand(testCase.template, testCase.captures))
+		})
+	}
+}
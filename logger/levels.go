@@ -0,0 +1,152 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/wlog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	componentLevelsMu sync.RWMutex
+	// componentLevels overrides the global level for single components, keyed
+	// by component ID (e.g. "inputs.cpu" or "awsemf").
+	componentLevels = map[string]zapcore.Level{}
+)
+
+// ParseComponentLevels parses overrides in the "id=level,id=level" form used
+// by the CWAGENT_COMPONENT_LOG_LEVELS environment variable.
+func ParseComponentLevels(value string) (map[string]zapcore.Level, error) {
+	levels := map[string]zapcore.Level{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, name, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(id) == "" {
+			return nil, fmt.Errorf("invalid component log level %q", entry)
+		}
+		level, err := zapcore.ParseLevel(strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level for component %s: %w", id, err)
+		}
+		levels[strings.TrimSpace(id)] = level
+	}
+	return levels, nil
+}
+
+// ConfigureLevels sets the global level along with the per-component
+// overrides. The telegraf writer level is lowered to the most verbose level
+// in use, since components with an override may log below the global level;
+// the filtering is then done per line by the writers of this package.
+func ConfigureLevels(global wlog.Level, overrides map[string]zapcore.Level) {
+	SetLevel(ConvertToAtomicLevel(global))
+	componentLevelsMu.Lock()
+	componentLevels = overrides
+	componentLevelsMu.Unlock()
+
+	lowest := loggerLevel.Level()
+	for _, level := range overrides {
+		if level < lowest {
+			lowest = level
+		}
+	}
+	wlog.SetLevel(convertToWlogLevel(lowest))
+}
+
+// levelFor returns the level a component logs at.
+func levelFor(componentID string) zapcore.Level {
+	if componentID != "" {
+		componentLevelsMu.RLock()
+		level, ok := componentLevels[componentID]
+		componentLevelsMu.RUnlock()
+		if ok {
+			return level
+		}
+	}
+	return loggerLevel.Level()
+}
+
+// HasComponentLevels reports whether any component has a level override.
+func HasComponentLevels() bool {
+	componentLevelsMu.RLock()
+	defer componentLevelsMu.RUnlock()
+	return len(componentLevels) > 0
+}
+
+func convertToWlogLevel(level zapcore.Level) wlog.Level {
+	switch {
+	case level <= zapcore.DebugLevel:
+		return wlog.DEBUG
+	case level == zapcore.InfoLevel:
+		return wlog.INFO
+	case level == zapcore.WarnLevel:
+		return wlog.WARN
+	}
+	return wlog.ERROR
+}
+
+// componentCore filters entries by the level of the component that logs
+// them. The collector identifies components by adding their ID as a field
+// through With, which is also where the fields are renamed to the keys of
+// the agent log.
+type componentCore struct {
+	zapcore.Core
+	componentID string
+}
+
+var _ zapcore.Core = (*componentCore)(nil)
+
+// componentFieldKeys renames the component attributes set by the collector.
+var componentFieldKeys = map[string]string{
+	"otelcol.component.id":   ComponentIDKey,
+	"otelcol.component.kind": "component_kind",
+	"otelcol.pipeline.id":    PipelineKey,
+	"otelcol.signal":         "signal",
+}
+
+const (
+	ComponentIDKey = "component_id"
+	PipelineKey    = "pipeline"
+)
+
+func newComponentCore(core zapcore.Core) zapcore.Core {
+	return &componentCore{Core: core}
+}
+
+func (c *componentCore) Enabled(level zapcore.Level) bool {
+	return level >= levelFor(c.componentID)
+}
+
+func (c *componentCore) With(fields []zapcore.Field) zapcore.Core {
+	componentID := c.componentID
+	renamed := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		if key, ok := componentFieldKeys[field.Key]; ok {
+			field.Key = key
+		}
+		if field.Key == ComponentIDKey && field.Type == zapcore.StringType {
+			componentID = field.String
+		}
+		renamed[i] = field
+	}
+	return &componentCore{Core: c.Core.With(renamed), componentID: componentID}
+}
+
+func (c *componentCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// allLevels lets the wrapped core write everything the componentCore lets
+// through.
+var allLevels = zap.LevelEnablerFunc(func(zapcore.Level) bool { return true })
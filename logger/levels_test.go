@@ -0,0 +1,86 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/wlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseComponentLevels(t *testing.T) {
+	levels, err := ParseComponentLevels("inputs.cpu=debug, awsemf=WARN,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]zapcore.Level{
+		"inputs.cpu": zapcore.DebugLevel,
+		"awsemf":     zapcore.WarnLevel,
+	}, levels)
+
+	_, err = ParseComponentLevels("inputs.cpu")
+	assert.Error(t, err)
+	_, err = ParseComponentLevels("inputs.cpu=verbose")
+	assert.Error(t, err)
+}
+
+func resetLevels(t *testing.T) {
+	t.Cleanup(func() {
+		ConfigureLevels(wlog.INFO, nil)
+		SetFormat(LogFormatText)
+	})
+}
+
+func TestConfigureLevels(t *testing.T) {
+	resetLevels(t)
+	ConfigureLevels(wlog.WARN, map[string]zapcore.Level{"inputs.cpu": zapcore.DebugLevel})
+	assert.True(t, HasComponentLevels())
+	assert.Equal(t, zapcore.WarnLevel, loggerLevel.Level())
+	assert.Equal(t, zapcore.DebugLevel, levelFor("inputs.cpu"))
+	assert.Equal(t, zapcore.WarnLevel, levelFor("inputs.mem"))
+	// The telegraf writer must let the debug lines of inputs.cpu through.
+	assert.Equal(t, wlog.DEBUG, wlog.LogLevel())
+
+	ConfigureLevels(wlog.ERROR, nil)
+	assert.False(t, HasComponentLevels())
+	assert.Equal(t, wlog.ERROR, wlog.LogLevel())
+}
+
+func TestComponentCore(t *testing.T) {
+	resetLevels(t)
+	ConfigureLevels(wlog.WARN, map[string]zapcore.Level{"awsemf": zapcore.DebugLevel})
+	SetFormat(LogFormatJSON)
+
+	var buf bytes.Buffer
+	logger, _ := NewLogger(&buf, loggerLevel)
+	logger.Info("dropped")
+	emf := logger.With(
+		zap.String("otelcol.component.id", "awsemf"),
+		zap.String("otelcol.component.kind", "exporter"),
+		zap.String("otelcol.pipeline.id", "metrics/host"),
+	)
+	emf.Debug("kept", zap.Error(errors.New("failed")))
+	logger.Warn("also kept")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "debug", entry["level"])
+	assert.Equal(t, "kept", entry["msg"])
+	assert.Equal(t, "awsemf", entry[ComponentIDKey])
+	assert.Equal(t, "exporter", entry["component_kind"])
+	assert.Equal(t, "metrics/host", entry[PipelineKey])
+	assert.Equal(t, "failed", entry["error"])
+	assert.NotEmpty(t, entry["ts"])
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "warn", entry["level"])
+}
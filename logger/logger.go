@@ -5,6 +5,7 @@ package logger
 
 import (
 	"io"
+	"time"
 
 	"github.com/influxdata/wlog"
 	"go.uber.org/zap"
@@ -13,7 +14,8 @@ import (
 )
 
 var (
-	loggerLevel zap.AtomicLevel
+	loggerLevel  zap.AtomicLevel
+	loggerFormat = LogFormatText
 )
 
 type TelegrafWrapperEncoder struct {
@@ -21,11 +23,7 @@ type TelegrafWrapperEncoder struct {
 }
 
 func NewLogger(writer io.Writer, level zap.AtomicLevel) (*zap.Logger, []zap.Option) {
-	core := zapcore.NewCore(
-		createTelegrafWrapperEncoder(),
-		zapcore.AddSync(writer),
-		loggerLevel,
-	)
+	core := newCore(writer)
 	option := zap.WrapCore(func(zapcore.Core) zapcore.Core {
 		return core
 	})
@@ -35,17 +33,24 @@ func NewLogger(writer io.Writer, level zap.AtomicLevel) (*zap.Logger, []zap.Opti
 	return logger, []zap.Option{option}
 }
 func getLoggingOptions(writer io.Writer) []zap.Option {
-	core := zapcore.NewCore(
-		createTelegrafWrapperEncoder(),
-		zapcore.AddSync(writer),
-		loggerLevel,
-	)
+	core := newCore(writer)
 	option := zap.WrapCore(func(zapcore.Core) zapcore.Core {
 		return core
 	})
 	return []zap.Option{option}
 }
 
+// newCore writes the entries in the configured format. Levels are checked by
+// the componentCore, so components with an override can log below the
+// global level.
+func newCore(writer io.Writer) zapcore.Core {
+	var encoder zapcore.Encoder = createTelegrafWrapperEncoder()
+	if loggerFormat == LogFormatJSON {
+		encoder = zapcore.NewJSONEncoder(newJSONEncoderConfig())
+	}
+	return newComponentCore(zapcore.NewCore(encoder, zapcore.AddSync(writer), allLevels))
+}
+
 func createTelegrafWrapperEncoder() TelegrafWrapperEncoder {
 	return TelegrafWrapperEncoder{
 		zapcore.NewJSONEncoder(newProductionEncoderConfig()),
@@ -56,6 +61,20 @@ func SetLevel(level zap.AtomicLevel) {
 	loggerLevel.SetLevel(level.Level())
 }
 
+// SetFormat selects between the telegraf style text lines and JSON objects
+// for the loggers created afterwards.
+func SetFormat(format string) {
+	if format == LogFormatJSON {
+		loggerFormat = LogFormatJSON
+	} else {
+		loggerFormat = LogFormatText
+	}
+}
+
+func Format() string {
+	return loggerFormat
+}
+
 func (t TelegrafWrapperEncoder) EncodeEntry(e zapcore.Entry, f []zapcore.Field) (*buffer.Buffer, error) {
 	entry, err := t.Encoder.EncodeEntry(e, f)
 	if err != nil {
@@ -86,6 +105,20 @@ func newProductionEncoderConfig() zapcore.EncoderConfig {
 	}
 }
 
+// newJSONEncoderConfig writes one JSON object per line with the same keys as
+// the lines of the telegraf plugins in JSON mode.
+func newJSONEncoderConfig() zapcore.EncoderConfig {
+	config := newProductionEncoderConfig()
+	config.TimeKey = "ts"
+	config.LevelKey = "level"
+	config.EncodeTime = func(t time.Time, encoder zapcore.PrimitiveArrayEncoder) {
+		encoder.AppendString(t.UTC().Format(time.RFC3339Nano))
+	}
+	config.EncodeLevel = zapcore.LowercaseLevelEncoder
+	config.EncodeDuration = zapcore.StringDurationEncoder
+	return config
+}
+
 func ConvertToAtomicLevel(level wlog.Level) zap.AtomicLevel {
 	if level == wlog.DEBUG {
 		return zap.NewAtomicLevelAt(zapcore.DebugLevel)
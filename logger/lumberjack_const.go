@@ -6,3 +6,12 @@ package logger
 const (
 	LogTargetLumberjack = "lumberjack"
 )
+
+// The rotation of the agent log matches the lumberjack writer of telegraf,
+// since the retention is published in the public docs.
+const (
+	LumberjackMaxSizeMB  = 100
+	LumberjackMaxBackups = 5
+	LumberjackMaxAgeDays = 7
+	LumberjackCompress   = true
+)
@@ -0,0 +1,240 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf/logger"
+	"github.com/influxdata/wlog"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// logLineRegex splits the lines written through the standard log package,
+// e.g. "E! [outputs.cloudwatch] failed to publish", into their level letter,
+// component ID and message. Both the level and the component are optional.
+var logLineRegex = regexp.MustCompile(`^(?:([DIWE])! )?(?:\[([^\]\s]+)\] )?(?s:(.*))$`)
+
+// lineWriter sits between the standard log package and the agent log. It
+// drops the lines below the level of the component that wrote them and, in
+// JSON mode, turns them into JSON objects.
+type lineWriter struct {
+	next io.Writer
+	json bool
+}
+
+// NewLineWriter wraps the writer the standard log package writes to, so
+// component level overrides also apply to the telegraf plugins.
+func NewLineWriter(next io.Writer) io.Writer {
+	return &lineWriter{next: next}
+}
+
+func (w *lineWriter) Write(b []byte) (int, error) {
+	match := logLineRegex.FindSubmatch(b)
+	if match == nil {
+		return w.next.Write(b)
+	}
+	level := letterToLevel(string(match[1]))
+	componentID := string(match[2])
+	if level < levelFor(componentID) {
+		return len(b), nil
+	}
+	if !w.json {
+		return w.next.Write(b)
+	}
+	line, err := json.Marshal(logLine{
+		Timestamp:   time.Now().UTC().Format(time.RFC3339Nano),
+		Level:       level.String(),
+		ComponentID: componentID,
+		Message:     strings.TrimRight(string(match[3]), "\n"),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if _, err = w.next.Write(append(line, '\n')); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// logLine uses the same keys as the JSON encoder of the zap logger, so both
+// halves of the agent log can be parsed the same way.
+type logLine struct {
+	Timestamp   string `json:"ts"`
+	Level       string `json:"level"`
+	ComponentID string `json:"component_id,omitempty"`
+	Message     string `json:"msg"`
+}
+
+func letterToLevel(letter string) zapcore.Level {
+	switch letter {
+	case "D":
+		return zapcore.DebugLevel
+	case "W":
+		return zapcore.WarnLevel
+	case "E":
+		return zapcore.ErrorLevel
+	}
+	return zapcore.InfoLevel
+}
+
+// logFile is the log file opened by the last NewJSONLogWriter, and
+// textLogWriter the writer of the last NewTextLogWriter. They are closed when
+// the agent reloads, so only one writer rotates the file.
+var (
+	logFile       io.Closer
+	textLogWriter io.Writer
+)
+
+// NewTextLogWriter sets up the telegraf text log, with the lines filtered by
+// the component levels. It closes the log file of a previous
+// NewJSONLogWriter, while telegraf closes its own previous writer.
+func NewTextLogWriter(cfg logger.LogConfig) io.Writer {
+	SetFormat(LogFormatText)
+	writer := logger.NewLogWriter(cfg)
+	log.SetOutput(NewLineWriter(writer))
+	closeLogFile()
+	textLogWriter = writer
+	return writer
+}
+
+func closeLogFile() {
+	if logFile != nil {
+		logFile.Close()
+		logFile = nil
+	}
+}
+
+// NewJSONLogWriter replaces telegraf's NewLogWriter when the agent log is in
+// JSON mode. The standard log package is redirected through a JSON
+// lineWriter, and the returned writer is the raw log file for the zap logger.
+// Like NewLogWriter, it closes the log file of the previous call, and the
+// writer of a previous NewTextLogWriter.
+func NewJSONLogWriter(cfg logger.LogConfig) io.Writer {
+	log.SetFlags(0)
+	switch {
+	case cfg.Debug:
+		wlog.SetLevel(wlog.DEBUG)
+	case cfg.Quiet:
+		wlog.SetLevel(wlog.ERROR)
+	default:
+		wlog.SetLevel(wlog.INFO)
+	}
+	SetLevel(ConvertToAtomicLevel(wlog.LogLevel()))
+	SetFormat(LogFormatJSON)
+
+	var writer io.Writer = os.Stderr
+	var file io.WriteCloser
+	if cfg.Logfile != "" && (cfg.LogTarget == LogTargetLumberjack || cfg.LogTarget == logger.LogTargetFile) {
+		if err := os.MkdirAll(filepath.Dir(cfg.Logfile), 0755); err != nil {
+			log.Printf("E! Unable to create the directory of %s (%s), using stderr", cfg.Logfile, err)
+		} else {
+			file = newLogFile(cfg)
+			writer = file
+		}
+	}
+	log.SetOutput(&lineWriter{next: writer, json: true})
+	closeLogFile()
+	if closer, ok := textLogWriter.(io.Closer); ok {
+		closer.Close()
+	}
+	textLogWriter = nil
+	logFile = file
+	return writer
+}
+
+// newLogFile opens the log file with the rotation of the log target. The
+// lumberjack target keeps the published retention, while the file target
+// follows the logfile_rotation settings.
+func newLogFile(cfg logger.LogConfig) io.WriteCloser {
+	if cfg.LogTarget == LogTargetLumberjack {
+		return &lumberjack.Logger{
+			Filename:   cfg.Logfile,
+			MaxSize:    LumberjackMaxSizeMB,
+			MaxBackups: LumberjackMaxBackups,
+			MaxAge:     LumberjackMaxAgeDays,
+			Compress:   LumberjackCompress,
+		}
+	}
+	return newRotatingFile(cfg)
+}
+
+const (
+	megabyte = 1024 * 1024
+	// noSizeRotationMB is the lumberjack size limit used when the file is not
+	// rotated on the size, since lumberjack treats 0 as its default size.
+	noSizeRotationMB = math.MaxInt32
+)
+
+// rotatingFile rotates the log file of the file log target. lumberjack only
+// rotates on the size, so the interval rotation is done by a ticker.
+type rotatingFile struct {
+	*lumberjack.Logger
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newRotatingFile(cfg logger.LogConfig) *rotatingFile {
+	maxSize := noSizeRotationMB
+	if size := int64(cfg.RotationMaxSize); size > 0 {
+		// lumberjack sizes are in megabytes, so smaller sizes round up to 1 MB
+		maxSize = int((size + megabyte - 1) / megabyte)
+	}
+	// -1 keeps every archive, which is 0 for lumberjack. lumberjack cannot
+	// keep no archives, so 0 keeps one.
+	maxBackups := cfg.RotationMaxArchives
+	switch {
+	case maxBackups < 0:
+		maxBackups = 0
+	case maxBackups == 0:
+		maxBackups = 1
+	}
+	f := &rotatingFile{
+		Logger: &lumberjack.Logger{
+			Filename:   cfg.Logfile,
+			MaxSize:    maxSize,
+			MaxBackups: maxBackups,
+		},
+		done: make(chan struct{}),
+	}
+	if interval := time.Duration(cfg.RotationInterval); interval > 0 {
+		go f.rotateEvery(interval)
+	}
+	return f
+}
+
+func (f *rotatingFile) rotateEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := f.Rotate(); err != nil {
+				log.Printf("E! Unable to rotate %s (%s)", f.Filename, err)
+			}
+		case <-f.done:
+			return
+		}
+	}
+}
+
+func (f *rotatingFile) Close() error {
+	f.closeOnce.Do(func() { close(f.done) })
+	return f.Logger.Close()
+}
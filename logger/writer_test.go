@@ -0,0 +1,162 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/logger"
+	"github.com/influxdata/wlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLineWriter(t *testing.T) {
+	resetLevels(t)
+	ConfigureLevels(wlog.INFO, map[string]zapcore.Level{
+		"inputs.cpu":         zapcore.DebugLevel,
+		"outputs.cloudwatch": zapcore.ErrorLevel,
+	})
+	var buf bytes.Buffer
+	w := NewLineWriter(&buf)
+	for _, line := range []string{
+		"D! [inputs.cpu] kept\n",
+		"D! [inputs.mem] dropped\n",
+		"W! [outputs.cloudwatch] dropped\n",
+		"E! [outputs.cloudwatch] kept\n",
+		"no level is info\n",
+	} {
+		n, err := w.Write([]byte(line))
+		require.NoError(t, err)
+		assert.Equal(t, len(line), n)
+	}
+	assert.Equal(t, "D! [inputs.cpu] kept\nE! [outputs.cloudwatch] kept\nno level is info\n", buf.String())
+}
+
+func TestLineWriterJSON(t *testing.T) {
+	resetLevels(t)
+	var buf bytes.Buffer
+	w := &lineWriter{next: &buf, json: true}
+	_, err := w.Write([]byte("E! [outputs.cloudwatch] failed to publish\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("I! Starting AmazonCloudWatchAgent\n"))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "error", entry["level"])
+	assert.Equal(t, "outputs.cloudwatch", entry[ComponentIDKey])
+	assert.Equal(t, "failed to publish", entry["msg"])
+	assert.NotEmpty(t, entry["ts"])
+
+	entry = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.NotContains(t, entry, ComponentIDKey)
+	assert.Equal(t, "Starting AmazonCloudWatchAgent", entry["msg"])
+}
+
+func TestNewJSONLogWriter(t *testing.T) {
+	resetLevels(t)
+	defer log.SetOutput(os.Stderr)
+	logfile := filepath.Join(t.TempDir(), "logs", "amazon-cloudwatch-agent.log")
+	writer := NewJSONLogWriter(logger.LogConfig{Logfile: logfile, LogTarget: LogTargetLumberjack})
+	assert.Equal(t, LogFormatJSON, Format())
+
+	log.Printf("D! [inputs.cpu] dropped")
+	log.Printf("I! [inputs.cpu] from telegraf")
+	zapLogger, _ := NewLogger(writer, loggerLevel)
+	zapLogger.Info("from zap")
+
+	content, err := os.ReadFile(logfile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		assert.Equal(t, "info", entry["level"])
+	}
+}
+
+func TestNewJSONLogWriterRotation(t *testing.T) {
+	resetLevels(t)
+	defer log.SetOutput(os.Stderr)
+	logfile := filepath.Join(t.TempDir(), "amazon-cloudwatch-agent.log")
+	cfg := logger.LogConfig{
+		Logfile:             logfile,
+		LogTarget:           logger.LogTargetFile,
+		RotationInterval:    config.Duration(time.Hour),
+		RotationMaxSize:     config.Size(1536 * 1024),
+		RotationMaxArchives: -1,
+	}
+	first, ok := NewJSONLogWriter(cfg).(*rotatingFile)
+	require.True(t, ok)
+	assert.Equal(t, 2, first.MaxSize)
+	assert.Equal(t, 0, first.MaxBackups)
+
+	// the reload closes the previous log file
+	cfg.RotationMaxSize = 0
+	cfg.RotationMaxArchives = 3
+	second, ok := NewJSONLogWriter(cfg).(*rotatingFile)
+	require.True(t, ok)
+	defer second.Close()
+	assert.Equal(t, noSizeRotationMB, second.MaxSize)
+	assert.Equal(t, 3, second.MaxBackups)
+	select {
+	case <-first.done:
+	default:
+		assert.Fail(t, "the previous log file was not closed")
+	}
+
+	log.Printf("I! after the reload")
+	content, err := os.ReadFile(logfile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "after the reload")
+}
+
+func TestLogWriterFormatSwitch(t *testing.T) {
+	resetLevels(t)
+	defer log.SetOutput(os.Stderr)
+	dir := t.TempDir()
+	cfg := logger.LogConfig{
+		Logfile:          filepath.Join(dir, "amazon-cloudwatch-agent.log"),
+		LogTarget:        logger.LogTargetFile,
+		RotationInterval: config.Duration(time.Hour),
+	}
+	jsonFile, ok := NewJSONLogWriter(cfg).(*rotatingFile)
+	require.True(t, ok)
+
+	// switching to text closes the JSON log file
+	NewTextLogWriter(cfg)
+	assert.Equal(t, LogFormatText, Format())
+	assert.Nil(t, logFile)
+	select {
+	case <-jsonFile.done:
+	default:
+		assert.Fail(t, "the JSON log file was not closed")
+	}
+
+	// switching back to JSON closes the text writer
+	require.NotNil(t, textLogWriter)
+	cfg.Logfile = filepath.Join(dir, "json.log")
+	defer NewJSONLogWriter(logger.LogConfig{})
+	NewJSONLogWriter(cfg)
+	assert.Equal(t, LogFormatJSON, Format())
+	assert.Nil(t, textLogWriter)
+	log.Printf("I! after the switch")
+	content, err := os.ReadFile(cfg.Logfile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "after the switch")
+}
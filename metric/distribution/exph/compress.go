@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exph
+
+import "math"
+
+// MinScale is the lowest scale of an exponential histogram. At this scale,
+// the buckets cover the whole float64 range in a few buckets.
+const MinScale = -10
+
+// Compression is how the buckets of an exponential histogram are merged
+// before being sent as values and counts. Adjacent buckets are merged by
+// lowering the scale, so every value stays in the bucket of its neighbours
+// and the error of the merged histogram is known from the scale alone.
+type Compression struct {
+	// MaxRelativeError merges adjacent buckets as long as the bucket
+	// midpoints are still within this relative error of the recorded values,
+	// e.g. 0.05 for 5%. The buckets are only merged to fit MaxBuckets if 0.
+	MaxRelativeError float64 `mapstructure:"max_relative_error,omitempty"`
+	// MaxBuckets is the most buckets, including the zero bucket, that the
+	// histogram can have after merging. Buckets are merged past the
+	// MaxRelativeError if needed to fit. There is no limit if 0.
+	MaxBuckets int `mapstructure:"max_buckets,omitempty"`
+}
+
+// RelativeError returns the largest relative error between a value and the
+// midpoint of its bucket at the scale. A value in the bucket (l, l*base] is
+// furthest from the midpoint at the lower boundary, where the error is
+// (base-1)/2.
+func RelativeError(scale int) float64 {
+	base := math.Exp2(math.Ldexp(1, -scale))
+	return (base - 1) / 2
+}
+
+// RelativeError returns the largest relative error of the values at the
+// current scale of the distribution.
+func (d *ExpHistogramDistribution) RelativeError() float64 {
+	return RelativeError(d.scale)
+}
+
+// Compress merges adjacent buckets to the lowest scale that is within the
+// MaxRelativeError, then keeps merging until the distribution fits in
+// MaxBuckets. The scale is never lowered past MinScale.
+func (d *ExpHistogramDistribution) Compress(c Compression) {
+	scale := d.scale
+	if c.MaxRelativeError > 0 {
+		for scale > MinScale && RelativeError(scale-1) <= c.MaxRelativeError {
+			scale--
+		}
+	}
+	d.downscale(d.scale - scale)
+	for c.MaxBuckets > 0 && d.Size() > c.MaxBuckets && d.scale > MinScale {
+		d.downscale(1)
+	}
+}
+
+// downscale lowers the scale by the change, merging each 2^change adjacent
+// buckets into one. The arithmetic shift floors the negative indexes, so the
+// buckets map into the lower scale as described by the OTel data model.
+func (d *ExpHistogramDistribution) downscale(change int) {
+	if change <= 0 {
+		return
+	}
+	d.positiveBuckets = downscaleBuckets(d.positiveBuckets, change)
+	d.negativeBuckets = downscaleBuckets(d.negativeBuckets, change)
+	d.scale -= change
+}
+
+func downscaleBuckets(buckets map[int]uint64, change int) map[int]uint64 {
+	merged := make(map[int]uint64, len(buckets))
+	for index, count := range buckets {
+		merged[index>>change] += count
+	}
+	return merged
+}
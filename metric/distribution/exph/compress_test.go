@@ -0,0 +1,158 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exph
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeError(t *testing.T) {
+	assert.InDelta(t, 0.5, RelativeError(0), 1e-12)
+	assert.InDelta(t, (math.Sqrt2-1)/2, RelativeError(1), 1e-12)
+	assert.InDelta(t, 1.5, RelativeError(-1), 1e-12)
+	for scale := MinScale; scale < 20; scale++ {
+		assert.Less(t, RelativeError(scale+1), RelativeError(scale))
+	}
+}
+
+func TestCompressMergesAdjacentBuckets(t *testing.T) {
+	d := newExpHistogramDistribution()
+	d.scale = 2
+	d.positiveBuckets = map[int]uint64{0: 1, 1: 2, 2: 3, 3: 4, 4: 5}
+	d.negativeBuckets = map[int]uint64{-3: 1, -2: 2, -1: 3}
+	d.zeroCount = 7
+
+	d.Compress(Compression{MaxBuckets: 6})
+	assert.Equal(t, 1, d.scale)
+	assert.Equal(t, map[int]uint64{0: 3, 1: 7, 2: 5}, d.positiveBuckets)
+	assert.Equal(t, map[int]uint64{-2: 1, -1: 5}, d.negativeBuckets)
+	assert.Equal(t, uint64(7), d.zeroCount)
+	assert.Equal(t, 6, d.Size())
+
+	// already fits
+	d.Compress(Compression{MaxBuckets: 6})
+	assert.Equal(t, 1, d.scale)
+
+	d.Compress(Compression{MaxBuckets: 1})
+	assert.Equal(t, MinScale, d.scale)
+	assert.Equal(t, 3, d.Size())
+}
+
+func TestCompressToRelativeError(t *testing.T) {
+	d := newExpHistogramDistribution()
+	d.scale = 8
+	d.positiveBuckets = map[int]uint64{0: 1, 255: 1, 256: 1, 1000: 1}
+
+	d.Compress(Compression{MaxRelativeError: 0.05})
+	// scale 3 is the lowest scale within 5%
+	assert.Equal(t, 3, d.scale)
+	assert.LessOrEqual(t, d.RelativeError(), 0.05)
+	assert.Greater(t, RelativeError(2), 0.05)
+	assert.Equal(t, map[int]uint64{0: 1, 7: 1, 8: 1, 31: 1}, d.positiveBuckets)
+
+	// a coarser scale is never made finer
+	d.Compress(Compression{MaxRelativeError: 0.001})
+	assert.Equal(t, 3, d.scale)
+}
+
+func TestCompressQuantileAccuracy(t *testing.T) {
+	samples := latencySamples(100000)
+	tests := []struct {
+		name        string
+		compression Compression
+		maxBuckets  int
+	}{
+		{
+			name:        "relative error",
+			compression: Compression{MaxRelativeError: 0.02},
+		},
+		{
+			name:        "max buckets",
+			compression: Compression{MaxBuckets: 150},
+			maxBuckets:  150,
+		},
+		{
+			name:        "max buckets past relative error",
+			compression: Compression{MaxRelativeError: 0.001, MaxBuckets: 40},
+			maxBuckets:  40,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newHistogram(samples, 10)
+			require.Greater(t, d.Size(), 150)
+			d.Compress(tt.compression)
+			if tt.maxBuckets > 0 {
+				assert.LessOrEqual(t, d.Size(), tt.maxBuckets)
+			}
+			if tt.compression.MaxRelativeError > 0 && tt.maxBuckets == 0 {
+				assert.LessOrEqual(t, d.RelativeError(), tt.compression.MaxRelativeError)
+			}
+			assert.Equal(t, float64(len(samples)), d.SampleCount())
+
+			values, counts := d.ValuesAndCounts()
+			assert.Equal(t, d.Size(), len(values))
+			for _, q := range []float64{0.5, 0.9, 0.99, 0.999} {
+				want := quantile(samples, q)
+				got := weightedQuantile(values, counts, q)
+				// small margin for the floating point error of the bucket boundaries
+				assert.InEpsilon(t, want, got, d.RelativeError()+1e-9, "quantile %v", q)
+			}
+		})
+	}
+}
+
+// latencySamples returns log-normal latencies around 20ms, sorted.
+func latencySamples(n int) []float64 {
+	r := rand.New(rand.NewSource(42)) //nolint:gosec
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = math.Exp(math.Log(20) + 1.5*r.NormFloat64())
+	}
+	slices.Sort(samples)
+	return samples
+}
+
+func newHistogram(samples []float64, scale int) *ExpHistogramDistribution {
+	d := newExpHistogramDistribution()
+	d.scale = scale
+	for _, sample := range samples {
+		d.positiveBuckets[MapToIndex(sample, scale)]++
+		d.sampleCount++
+		d.sum += sample
+		d.min = min(d.min, sample)
+		d.max = max(d.max, sample)
+	}
+	return d
+}
+
+// quantile returns the sample with the rank ceil(q*n) of the sorted samples.
+func quantile(samples []float64, q float64) float64 {
+	rank := int(math.Ceil(q * float64(len(samples))))
+	return samples[rank-1]
+}
+
+// weightedQuantile returns the value that reaches the rank ceil(q*n) of the
+// values in descending order and their counts.
+func weightedQuantile(values []float64, counts []float64, q float64) float64 {
+	var total float64
+	for _, count := range counts {
+		total += count
+	}
+	rank := math.Ceil(q * total)
+	var seen float64
+	for i := len(values) - 1; i >= 0; i-- {
+		seen += counts[i]
+		if seen >= rank {
+			return values[i]
+		}
+	}
+	return values[0]
+}
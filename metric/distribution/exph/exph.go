@@ -205,7 +205,8 @@ func (d *ExpHistogramDistribution) ConvertFromOtel(dp pmetric.ExponentialHistogr
 	}
 }
 
+// Resize does not split the distribution. Use Compress to merge the buckets
+// until they fit in one datum.
 func (d *ExpHistogramDistribution) Resize(_ int) []distribution.Distribution {
-	// TODO: split data points into separate PMD requests if the number of buckets exceeds the API limit
 	return []distribution.Distribution{d}
 }
@@ -0,0 +1,220 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package connections
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement       = "connections"
+	defaultProcfsRoot = "/proc"
+	portTag           = "port"
+	// maxPorts bounds the number of ports, and with it the cardinality of
+	// the port dimension.
+	maxPorts = 100
+)
+
+// tcpStates maps the hex states of /proc/net/tcp to the field names, as
+// defined in https://github.com/torvalds/linux/blob/master/include/net/tcp_states.h
+// The states of outgoing connections are left out since their local port is
+// not a listening port.
+var tcpStates = map[string]string{
+	"01": "established",
+	"03": "syn_recv",
+	"04": "fin_wait1",
+	"05": "fin_wait2",
+	"06": "time_wait",
+	"08": "close_wait",
+	"09": "last_ack",
+	"0A": "listen",
+	"0B": "closing",
+}
+
+// Connections gathers the TCP connection states of the listening ports in
+// the allow-list and the usage of the netfilter connection tracking table.
+type Connections struct {
+	// Ports are the local ports to report the connection states for. No
+	// connection states are reported if empty.
+	Ports      []int           `toml:"ports"`
+	ProcfsRoot string          `toml:"procfs_root"`
+	Log        telegraf.Logger `toml:"-"`
+
+	ports map[uint16]struct{}
+}
+
+// Description returns the description of the Connections plugin
+func (*Connections) Description() string {
+	return "Gathers the TCP connection states of listening ports and the conntrack table usage"
+}
+
+func (*Connections) SampleConfig() string {
+	return sampleConfig
+}
+
+func (c *Connections) Init() error {
+	if c.ProcfsRoot == "" {
+		c.ProcfsRoot = defaultProcfsRoot
+	}
+	if len(c.Ports) > maxPorts {
+		return fmt.Errorf("at most %d ports can be configured, got %d", maxPorts, len(c.Ports))
+	}
+	c.ports = make(map[uint16]struct{}, len(c.Ports))
+	for _, port := range c.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid port %d", port)
+		}
+		c.ports[uint16(port)] = struct{}{}
+	}
+	return nil
+}
+
+// Gather implements the telegraf interface
+func (c *Connections) Gather(acc telegraf.Accumulator) error {
+	if len(c.ports) > 0 {
+		counts := make(map[uint16]map[string]interface{}, len(c.ports))
+		for port := range c.ports {
+			fields := make(map[string]interface{}, len(tcpStates))
+			for _, state := range tcpStates {
+				fields[state] = 0
+			}
+			counts[port] = fields
+		}
+		for _, name := range []string{"tcp", "tcp6"} {
+			if err := c.countStates(filepath.Join(c.ProcfsRoot, "net", name), counts); err != nil {
+				acc.AddError(err)
+			}
+		}
+		for port, fields := range counts {
+			acc.AddFields(measurement, fields, map[string]string{portTag: strconv.Itoa(int(port))})
+		}
+	}
+
+	fields, err := c.conntrack()
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		acc.AddFields(measurement, fields, nil)
+	}
+	return nil
+}
+
+// countStates adds the states of the sockets in a /proc/net/tcp{,6} file to
+// the counts of their local port. Missing files are skipped since tcp6 is
+// absent when IPv6 is disabled.
+func (c *Connections) countStates(path string, counts map[uint16]map[string]interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	if err = parseSockets(f, func(port uint16, state string) {
+		fields, ok := counts[port]
+		if !ok {
+			return
+		}
+		if field, ok := tcpStates[state]; ok {
+			fields[field] = fields[field].(int) + 1
+		}
+	}); err != nil {
+		return fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// parseSockets calls fn with the local port and hex state of every socket in
+// lines such as
+//
+//	0: 0A00000A:0050 0B00000A:C350 01 00000000:00000000 00:00000000 00000000 ...
+//
+// The first line is a header.
+func parseSockets(r io.Reader, fn func(port uint16, state string)) error {
+	scanner := bufio.NewScanner(r)
+	header := true
+	for scanner.Scan() {
+		if header {
+			header = false
+			continue
+		}
+		columns := strings.Fields(scanner.Text())
+		if len(columns) < 4 {
+			continue
+		}
+		_, hexPort, ok := strings.Cut(columns[1], ":")
+		if !ok {
+			return fmt.Errorf("invalid local address %q", columns[1])
+		}
+		port, err := strconv.ParseUint(hexPort, 16, 16)
+		if err != nil {
+			return err
+		}
+		fn(uint16(port), strings.ToUpper(columns[3]))
+	}
+	return scanner.Err()
+}
+
+// conntrack returns the number of entries in the netfilter connection
+// tracking table and its size. Nothing is returned if the nf_conntrack module
+// is not loaded.
+func (c *Connections) conntrack() (map[string]interface{}, error) {
+	dir := filepath.Join(c.ProcfsRoot, "sys", "net", "netfilter")
+	count, err := readUint(filepath.Join(dir, "nf_conntrack_count"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	size, err := readUint(filepath.Join(dir, "nf_conntrack_max"))
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{
+		"conntrack_count": count,
+		"conntrack_max":   size,
+	}
+	if size > 0 {
+		fields["conntrack_used_percent"] = float64(count) / float64(size) * 100
+	}
+	return fields, nil
+}
+
+func readUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	return v, nil
+}
+
+func init() {
+	inputs.Add(measurement, func() telegraf.Input {
+		return &Connections{
+			ProcfsRoot: defaultProcfsRoot,
+		}
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package connections
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func states(values map[string]int) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, state := range tcpStates {
+		fields[state] = values[state]
+	}
+	return fields
+}
+
+func TestInit(t *testing.T) {
+	plugin := &Connections{Ports: []int{0}, Log: &testutil.Logger{}}
+	require.Error(t, plugin.Init())
+
+	plugin = &Connections{Ports: []int{65536}, Log: &testutil.Logger{}}
+	require.Error(t, plugin.Init())
+
+	plugin = &Connections{Ports: make([]int, maxPorts+1), Log: &testutil.Logger{}}
+	require.Error(t, plugin.Init())
+
+	plugin = &Connections{Ports: []int{80, 443}, Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, defaultProcfsRoot, plugin.ProcfsRoot)
+}
+
+func TestGather(t *testing.T) {
+	plugin := &Connections{
+		Ports:      []int{22, 80, 443},
+		ProcfsRoot: filepath.Join("testdata", "proc"),
+		Log:        &testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Empty(t, acc.Errors)
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(measurement, map[string]string{"port": "22"}, states(nil), time.Unix(0, 0)),
+		testutil.MustMetric(measurement, map[string]string{"port": "80"}, states(map[string]int{
+			"listen":      1,
+			"established": 2,
+			"time_wait":   2,
+			"close_wait":  1,
+		}), time.Unix(0, 0)),
+		testutil.MustMetric(measurement, map[string]string{"port": "443"}, states(map[string]int{
+			"listen":      1,
+			"established": 1,
+		}), time.Unix(0, 0)),
+		testutil.MustMetric(measurement, map[string]string{}, map[string]interface{}{
+			"conntrack_count":        uint64(3000),
+			"conntrack_max":          uint64(262144),
+			"conntrack_used_percent": float64(3000) / float64(262144) * 100,
+		}, time.Unix(0, 0)),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime(), testutil.SortMetrics())
+}
+
+func TestGatherWithoutPortsAndConntrack(t *testing.T) {
+	plugin := &Connections{
+		ProcfsRoot: filepath.Join("testdata", "missing"),
+		Log:        &testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Empty(t, acc.Errors)
+	assert.Empty(t, acc.GetTelegrafMetrics())
+}
+
+func TestGatherConntrackOnly(t *testing.T) {
+	plugin := &Connections{
+		ProcfsRoot: filepath.Join("testdata", "proc"),
+		Log:        &testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	metrics := acc.GetTelegrafMetrics()
+	require.Len(t, metrics, 1)
+	assert.Empty(t, metrics[0].Tags())
+	assert.Equal(t, map[string]interface{}{
+		"conntrack_count":        uint64(3000),
+		"conntrack_max":          uint64(262144),
+		"conntrack_used_percent": float64(3000) / float64(262144) * 100,
+	}, metrics[0].Fields())
+}
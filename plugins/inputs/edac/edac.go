@@ -0,0 +1,138 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package edac
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement      = "edac"
+	defaultSysfsRoot = "/sys"
+	controllerTag    = "controller"
+	dimmTag          = "dimm"
+	labelTag         = "label"
+)
+
+// controllerCounters are the counters of a memory controller. The noinfo
+// counters are the errors that could not be attributed to a DIMM.
+var controllerCounters = []string{"ce_count", "ue_count", "ce_noinfo_count", "ue_noinfo_count"}
+
+// EDAC gathers the corrected (ce) and uncorrected (ue) memory errors that the
+// EDAC drivers of the kernel count per memory controller and DIMM.
+type EDAC struct {
+	SysfsRoot string          `toml:"sysfs_root"`
+	Log       telegraf.Logger `toml:"-"`
+}
+
+// Description returns the description of the EDAC plugin
+func (*EDAC) Description() string {
+	return "Gathers the memory error counters of the EDAC drivers"
+}
+
+func (*EDAC) SampleConfig() string {
+	return sampleConfig
+}
+
+func (e *EDAC) Init() error {
+	if e.SysfsRoot == "" {
+		e.SysfsRoot = defaultSysfsRoot
+	}
+	return nil
+}
+
+// Gather implements the telegraf interface. Nothing is reported if no EDAC
+// driver is loaded, which is the case on most virtual machines.
+func (e *EDAC) Gather(acc telegraf.Accumulator) error {
+	controllers, err := filepath.Glob(filepath.Join(e.SysfsRoot, "devices", "system", "edac", "mc", "mc[0-9]*"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(controllers)
+	for _, controller := range controllers {
+		name := filepath.Base(controller)
+		fields := map[string]interface{}{}
+		for _, counter := range controllerCounters {
+			value, err := readCounter(filepath.Join(controller, counter))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				acc.AddError(err)
+				continue
+			}
+			fields[counter] = value
+		}
+		if len(fields) > 0 {
+			acc.AddGauge(measurement, fields, map[string]string{controllerTag: name})
+		}
+		e.gatherDIMMs(controller, name, acc)
+	}
+	return nil
+}
+
+// gatherDIMMs gathers the counters of the DIMMs of a memory controller. The
+// label of a DIMM is the name of its slot on the board, if the driver or
+// the firmware knows it.
+func (e *EDAC) gatherDIMMs(controller, name string, acc telegraf.Accumulator) {
+	dimms, err := filepath.Glob(filepath.Join(controller, "dimm[0-9]*"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	sort.Strings(dimms)
+	for _, dimm := range dimms {
+		fields := map[string]interface{}{}
+		for _, counter := range []string{"ce_count", "ue_count"} {
+			value, err := readCounter(filepath.Join(dimm, "dimm_"+counter))
+			if err != nil {
+				if !os.IsNotExist(err) {
+					acc.AddError(err)
+				}
+				continue
+			}
+			fields[counter] = value
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		tags := map[string]string{controllerTag: name, dimmTag: filepath.Base(dimm)}
+		if label, err := os.ReadFile(filepath.Join(dimm, "dimm_label")); err == nil && strings.TrimSpace(string(label)) != "" {
+			tags[labelTag] = strings.TrimSpace(string(label))
+		}
+		acc.AddGauge(measurement, fields, tags)
+	}
+}
+
+func readCounter(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	return value, nil
+}
+
+func init() {
+	inputs.Add(measurement, func() telegraf.Input {
+		return &EDAC{
+			SysfsRoot: defaultSysfsRoot,
+		}
+	})
+}
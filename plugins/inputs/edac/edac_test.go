@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package edac
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGather(t *testing.T) {
+	plugin := &EDAC{SysfsRoot: filepath.Join("testdata", "sys"), Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Empty(t, acc.Errors)
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(measurement, map[string]string{"controller": "mc0"}, map[string]interface{}{
+			"ce_count":        uint64(14),
+			"ue_count":        uint64(0),
+			"ce_noinfo_count": uint64(1),
+			"ue_noinfo_count": uint64(0),
+		}, time.Unix(0, 0), telegraf.Gauge),
+		testutil.MustMetric(measurement, map[string]string{"controller": "mc0", "dimm": "dimm0", "label": "CPU_SrcID#0_MC#0_Chan#0_DIMM#0"}, map[string]interface{}{
+			"ce_count": uint64(13),
+			"ue_count": uint64(0),
+		}, time.Unix(0, 0), telegraf.Gauge),
+		testutil.MustMetric(measurement, map[string]string{"controller": "mc0", "dimm": "dimm1"}, map[string]interface{}{
+			"ce_count": uint64(0),
+			"ue_count": uint64(0),
+		}, time.Unix(0, 0), telegraf.Gauge),
+		testutil.MustMetric(measurement, map[string]string{"controller": "mc1"}, map[string]interface{}{
+			"ce_count":        uint64(0),
+			"ue_count":        uint64(2),
+			"ce_noinfo_count": uint64(0),
+			"ue_noinfo_count": uint64(0),
+		}, time.Unix(0, 0), telegraf.Gauge),
+		testutil.MustMetric(measurement, map[string]string{"controller": "mc1", "dimm": "dimm0", "label": "CPU_SrcID#1_MC#0_Chan#0_DIMM#0"}, map[string]interface{}{
+			"ce_count": uint64(0),
+			"ue_count": uint64(2),
+		}, time.Unix(0, 0), telegraf.Gauge),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestGatherWithoutDriver(t *testing.T) {
+	plugin := &EDAC{SysfsRoot: t.TempDir(), Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Empty(t, acc.Errors)
+	assert.Empty(t, acc.GetTelegrafMetrics())
+}
+
+func TestGatherInvalidCounter(t *testing.T) {
+	root := t.TempDir()
+	controller := filepath.Join(root, "devices", "system", "edac", "mc", "mc0")
+	require.NoError(t, os.MkdirAll(controller, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(controller, "ce_count"), []byte("3\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(controller, "ue_count"), []byte("invalid\n"), 0600))
+	plugin := &EDAC{SysfsRoot: root, Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Len(t, acc.Errors, 1)
+	assert.ErrorContains(t, acc.Errors[0], "ue_count")
+	require.Len(t, acc.GetTelegrafMetrics(), 1)
+	assert.Equal(t, map[string]interface{}{"ce_count": uint64(3)}, acc.GetTelegrafMetrics()[0].Fields())
+}
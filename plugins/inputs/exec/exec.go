@@ -0,0 +1,130 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	osexec "os/exec"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement    = "exec"
+	defaultTimeout = 30 * time.Second
+	commandTag     = "command"
+)
+
+// runner runs a command and returns its standard output.
+type runner func(cmd *osexec.Cmd, timeout time.Duration) ([]byte, error)
+
+// Exec runs a command on every collection and parses its output with the
+// configured data format. The outcome of every run is reported as well, so
+// that failing commands can be alarmed on rather than only logged.
+type Exec struct {
+	Command   []string        `toml:"command"`
+	Timeout   config.Duration `toml:"timeout"`
+	RunAsUser string          `toml:"run_as_user"`
+	Log       telegraf.Logger `toml:"-"`
+
+	parser telegraf.Parser
+	run    runner
+}
+
+// Description returns the description of the Exec plugin
+func (*Exec) Description() string {
+	return "Runs a command and parses its output as metrics"
+}
+
+func (*Exec) SampleConfig() string {
+	return sampleConfig
+}
+
+// SetParser implements telegraf.ParserInput. The parser is built from the
+// data_format of the plugin configuration.
+func (e *Exec) SetParser(parser telegraf.Parser) {
+	e.parser = parser
+}
+
+func (e *Exec) Init() error {
+	if len(e.Command) == 0 || e.Command[0] == "" {
+		return errors.New("command must be set")
+	}
+	if e.Timeout <= 0 {
+		e.Timeout = config.Duration(defaultTimeout)
+	}
+	if e.run == nil {
+		e.run = internal.StdOutputTimeout
+	}
+	return nil
+}
+
+// Gather implements the telegraf interface
+func (e *Exec) Gather(acc telegraf.Accumulator) error {
+	command := strings.Join(e.Command, " ")
+	fields := map[string]interface{}{
+		"success":     0,
+		"timed_out":   0,
+		"parse_error": 0,
+	}
+	defer func() {
+		acc.AddGauge(measurement, fields, map[string]string{commandTag: command})
+	}()
+
+	cmd := osexec.Command(e.Command[0], e.Command[1:]...)
+	if err := setSysProcAttr(cmd, e.RunAsUser); err != nil {
+		return fmt.Errorf("unable to run %q: %w", command, err)
+	}
+	start := time.Now()
+	out, err := e.run(cmd, time.Duration(e.Timeout))
+	fields["execution_time"] = float64(time.Since(start)) / float64(time.Millisecond)
+	var exitErr *osexec.ExitError
+	switch {
+	case err == nil:
+		fields["exit_code"] = 0
+	case errors.Is(err, internal.ErrTimeout):
+		fields["timed_out"] = 1
+		return fmt.Errorf("%q timed out after %s", command, time.Duration(e.Timeout))
+	case errors.As(err, &exitErr):
+		fields["exit_code"] = exitErr.ExitCode()
+		return fmt.Errorf("%q failed: %w", command, err)
+	default:
+		return fmt.Errorf("unable to run %q: %w", command, err)
+	}
+
+	if e.parser == nil {
+		fields["success"] = 1
+		return nil
+	}
+	metrics, err := e.parser.Parse(out)
+	if err != nil {
+		fields["parse_error"] = 1
+		return fmt.Errorf("unable to parse the output of %q: %w", command, err)
+	}
+	for _, m := range metrics {
+		acc.AddMetric(m)
+	}
+	fields["success"] = 1
+	return nil
+}
+
+func init() {
+	inputs.Add(measurement, func() telegraf.Input {
+		return &Exec{
+			Timeout: config.Duration(defaultTimeout),
+			run:     internal.StdOutputTimeout,
+		}
+	})
+}
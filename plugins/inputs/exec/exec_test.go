@@ -0,0 +1,177 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package exec
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/parsers"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInfluxParser(t *testing.T) telegraf.Parser {
+	parser, err := parsers.NewInfluxParser()
+	require.NoError(t, err)
+	return parser
+}
+
+// statusFields returns the fields of the exec measurement without the
+// execution time, which varies between runs.
+func statusFields(t *testing.T, acc *testutil.Accumulator, command string) map[string]interface{} {
+	t.Helper()
+	for _, m := range acc.GetTelegrafMetrics() {
+		if tag, ok := m.GetTag(commandTag); ok && m.Name() == measurement && tag == command {
+			fields := m.Fields()
+			assert.IsType(t, float64(0), fields["execution_time"])
+			delete(fields, "execution_time")
+			return fields
+		}
+	}
+	require.Failf(t, "missing status", "no status for %q", command)
+	return nil
+}
+
+func TestInit(t *testing.T) {
+	plugin := &Exec{Log: &testutil.Logger{}}
+	require.Error(t, plugin.Init())
+
+	plugin = &Exec{Command: []string{"true"}, Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, config.Duration(defaultTimeout), plugin.Timeout)
+}
+
+func TestGather(t *testing.T) {
+	plugin := &Exec{
+		Command: []string{"sh", "-c", `echo "queue,name=orders depth=12i"; echo "queue,name=refunds depth=3i"`},
+		Log:     &testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+	plugin.SetParser(newInfluxParser(t))
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric("queue", map[string]string{"name": "orders"}, map[string]interface{}{"depth": int64(12)}, time.Unix(0, 0)),
+		testutil.MustMetric("queue", map[string]string{"name": "refunds"}, map[string]interface{}{"depth": int64(3)}, time.Unix(0, 0)),
+	}
+	var queues []telegraf.Metric
+	for _, m := range acc.GetTelegrafMetrics() {
+		if m.Name() == "queue" {
+			queues = append(queues, m)
+		}
+	}
+	testutil.RequireMetricsEqual(t, expected, queues, testutil.IgnoreTime())
+	assert.Equal(t, map[string]interface{}{
+		"success":     int64(1),
+		"exit_code":   int64(0),
+		"timed_out":   int64(0),
+		"parse_error": int64(0),
+	}, statusFields(t, &acc, strings.Join(plugin.Command, " ")))
+}
+
+func TestGatherFailures(t *testing.T) {
+	testCases := map[string]struct {
+		command  []string
+		timeout  time.Duration
+		expected map[string]interface{}
+	}{
+		"ExitCode": {
+			command: []string{"sh", "-c", "echo 'queue depth=1i'; exit 3"},
+			expected: map[string]interface{}{
+				"success":     int64(0),
+				"exit_code":   int64(3),
+				"timed_out":   int64(0),
+				"parse_error": int64(0),
+			},
+		},
+		"Timeout": {
+			command: []string{"sleep", "10"},
+			timeout: 100 * time.Millisecond,
+			expected: map[string]interface{}{
+				"success":     int64(0),
+				"timed_out":   int64(1),
+				"parse_error": int64(0),
+			},
+		},
+		"ParseError": {
+			command: []string{"echo", "not influx"},
+			expected: map[string]interface{}{
+				"success":     int64(0),
+				"exit_code":   int64(0),
+				"timed_out":   int64(0),
+				"parse_error": int64(1),
+			},
+		},
+		"NotFound": {
+			command: []string{"/nonexistent/command"},
+			expected: map[string]interface{}{
+				"success":     int64(0),
+				"timed_out":   int64(0),
+				"parse_error": int64(0),
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			plugin := &Exec{
+				Command: testCase.command,
+				Timeout: config.Duration(testCase.timeout),
+				Log:     &testutil.Logger{},
+			}
+			require.NoError(t, plugin.Init())
+			plugin.SetParser(newInfluxParser(t))
+
+			var acc testutil.Accumulator
+			require.Error(t, plugin.Gather(&acc))
+			require.Len(t, acc.GetTelegrafMetrics(), 1)
+			assert.Equal(t, testCase.expected, statusFields(t, &acc, strings.Join(plugin.Command, " ")))
+		})
+	}
+}
+
+func TestGatherUnknownUser(t *testing.T) {
+	plugin := &Exec{
+		Command:   []string{"true"},
+		RunAsUser: "no-such-user-for-exec-test",
+		Log:       &testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	assert.ErrorContains(t, plugin.Gather(&acc), "no-such-user-for-exec-test")
+	assert.Equal(t, int64(0), acc.GetTelegrafMetrics()[0].Fields()["success"])
+}
+
+func TestLoadConfig(t *testing.T) {
+	c := config.NewConfig()
+	require.NoError(t, c.LoadConfigData([]byte(`
+[[inputs.exec]]
+  command = ["/usr/local/bin/check.sh", "--json"]
+  timeout = "10s"
+  run_as_user = "nobody"
+  data_format = "json"
+`)))
+	require.Len(t, c.Inputs, 1)
+	plugin, ok := c.Inputs[0].Input.(*Exec)
+	require.True(t, ok)
+	assert.Equal(t, []string{"/usr/local/bin/check.sh", "--json"}, plugin.Command)
+	assert.Equal(t, config.Duration(10*time.Second), plugin.Timeout)
+	assert.Equal(t, "nobody", plugin.RunAsUser)
+	require.NotNil(t, plugin.parser)
+
+	metrics, err := plugin.parser.Parse([]byte(`{"depth": 12}`))
+	require.NoError(t, err)
+	require.Len(t, metrics, 1)
+	assert.Equal(t, measurement, metrics[0].Name())
+	assert.Equal(t, map[string]interface{}{"depth": float64(12)}, metrics[0].Fields())
+}
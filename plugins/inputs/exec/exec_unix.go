@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package exec
+
+import (
+	"fmt"
+	osexec "os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// setSysProcAttr runs the command in its own process group, so that the children
+// are terminated with it on timeout, and as the user if one is set.
+func setSysProcAttr(cmd *osexec.Cmd, username string) error {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if username == "" {
+		return nil
+	}
+	u, err := user.Lookup(username)
+	if err != nil {
+		return err
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid uid of %s: %w", username, err)
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid gid of %s: %w", username, err)
+	}
+	cmd.SysProcAttr.Credential = &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}
+	cmd.Dir = u.HomeDir
+	return nil
+}
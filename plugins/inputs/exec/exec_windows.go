@@ -0,0 +1,18 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package exec
+
+import (
+	"errors"
+	osexec "os/exec"
+)
+
+func setSysProcAttr(_ *osexec.Cmd, username string) error {
+	if username != "" {
+		return errors.New("run_as_user is not supported on Windows")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package file_handles
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement       = "file_handles"
+	defaultProcfsRoot = "/proc"
+)
+
+// FileHandles gathers the number of allocated, unused and maximum file
+// handles of the kernel.
+type FileHandles struct {
+	ProcfsRoot string          `toml:"procfs_root"`
+	Log        telegraf.Logger `toml:"-"`
+}
+
+// Description returns the description of the FileHandles plugin
+func (*FileHandles) Description() string {
+	return "Gathers the usage of the kernel file handles"
+}
+
+func (*FileHandles) SampleConfig() string {
+	return sampleConfig
+}
+
+func (f *FileHandles) Init() error {
+	if f.ProcfsRoot == "" {
+		f.ProcfsRoot = defaultProcfsRoot
+	}
+	return nil
+}
+
+// Gather implements the telegraf interface
+func (f *FileHandles) Gather(acc telegraf.Accumulator) error {
+	path := filepath.Join(f.ProcfsRoot, "sys", "fs", "file-nr")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	// file-nr contains the number of allocated file handles, the number of
+	// allocated but unused file handles and the maximum number of file handles.
+	columns := strings.Fields(string(data))
+	if len(columns) != 3 {
+		return fmt.Errorf("unexpected format of %s: %q", path, data)
+	}
+	values := make([]uint64, len(columns))
+	for i, column := range columns {
+		if values[i], err = strconv.ParseUint(column, 10, 64); err != nil {
+			return fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+	}
+	allocated, unused, maximum := values[0], values[1], values[2]
+	used := allocated - min(unused, allocated)
+	fields := map[string]interface{}{
+		"allocated": allocated,
+		"unused":    unused,
+		"used":      used,
+		"max":       maximum,
+	}
+	if maximum > 0 {
+		fields["used_percent"] = float64(used) / float64(maximum) * 100
+	}
+	acc.AddGauge(measurement, fields, nil)
+	return nil
+}
+
+func init() {
+	inputs.Add(measurement, func() telegraf.Input {
+		return &FileHandles{
+			ProcfsRoot: defaultProcfsRoot,
+		}
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package file_handles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGather(t *testing.T) {
+	plugin := &FileHandles{ProcfsRoot: filepath.Join("testdata", "proc"), Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(measurement, map[string]string{}, map[string]interface{}{
+			"allocated":    uint64(9248),
+			"unused":       uint64(0),
+			"used":         uint64(9248),
+			"max":          uint64(9223372036854775807),
+			"used_percent": float64(9248) / float64(9223372036854775807) * 100,
+		}, time.Unix(0, 0), telegraf.Gauge),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestGatherInvalidFile(t *testing.T) {
+	procfs := t.TempDir()
+	plugin := &FileHandles{ProcfsRoot: procfs, Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	assert.Error(t, plugin.Gather(&acc))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(procfs, "sys", "fs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(procfs, "sys", "fs", "file-nr"), []byte("1 2\n"), 0600))
+	assert.ErrorContains(t, plugin.Gather(&acc), "unexpected format")
+	assert.Empty(t, acc.GetTelegrafMetrics())
+}
@@ -0,0 +1,231 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_event_log
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/kernel_events/kmsg"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+// KernelEventLog sends the oom-kills, hung tasks and machine check exceptions
+// that the kernel reports in its ring buffer to a log group. Only the events
+// that are reported after the plugin is started are sent.
+type KernelEventLog struct {
+	KmsgPath      string          `toml:"kmsg_path"`
+	LogGroupName  string          `toml:"log_group_name"`
+	LogStreamName string          `toml:"log_stream_name"`
+	LogGroupClass string          `toml:"log_group_class"`
+	Destination   string          `toml:"destination"`
+	Retention     int             `toml:"retention_in_days"`
+	Log           telegraf.Logger `toml:"-"`
+
+	startOnce sync.Once
+	newSrcs   []logs.LogSrc
+}
+
+var _ logs.LogCollection = (*KernelEventLog)(nil)
+
+// Description returns the description of the KernelEventLog plugin
+func (*KernelEventLog) Description() string {
+	return "Sends the oom-kills, hung tasks and machine check exceptions of the kernel to CloudWatch Logs"
+}
+
+func (*KernelEventLog) SampleConfig() string {
+	return sampleConfig
+}
+
+// Gather does nothing, the events are sent by the log agent.
+func (*KernelEventLog) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+// Start creates the log source. The log agent starts the log collections
+// without initializing them, so the defaults are set here.
+func (k *KernelEventLog) Start(telegraf.Accumulator) error {
+	if k.LogGroupName == "" {
+		return errors.New("log_group_name must be set")
+	}
+	if k.KmsgPath == "" {
+		k.KmsgPath = kmsg.DefaultPath
+	}
+	k.startOnce.Do(func() {
+		k.newSrcs = append(k.newSrcs, &eventSrc{
+			path:          k.KmsgPath,
+			logGroupName:  k.LogGroupName,
+			logStreamName: k.LogStreamName,
+			logGroupClass: k.LogGroupClass,
+			destination:   k.Destination,
+			retention:     k.Retention,
+			log:           k.Log,
+			done:          make(chan struct{}),
+		})
+	})
+	return nil
+}
+
+func (k *KernelEventLog) FindLogSrc() []logs.LogSrc {
+	srcs := k.newSrcs
+	k.newSrcs = nil
+	return srcs
+}
+
+func (*KernelEventLog) Stop() {
+}
+
+// eventSrc is the log source of the kernel events. The kernel ring buffer is
+// opened when the output is set, which is when the log agent starts to
+// publish the events.
+type eventSrc struct {
+	path          string
+	logGroupName  string
+	logStreamName string
+	logGroupClass string
+	destination   string
+	retention     int
+	log           telegraf.Logger
+
+	outputFn  func(logs.LogEvent)
+	startOnce sync.Once
+	stopOnce  sync.Once
+	done      chan struct{}
+	mu        sync.Mutex
+	file      *os.File
+}
+
+var _ logs.LogSrc = (*eventSrc)(nil)
+
+func (s *eventSrc) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	s.outputFn = fn
+	s.startOnce.Do(func() { go s.run() })
+}
+
+func (s *eventSrc) Group() string {
+	return s.logGroupName
+}
+
+func (s *eventSrc) Stream() string {
+	return s.logStreamName
+}
+
+func (s *eventSrc) Description() string {
+	return fmt.Sprintf("kernel events of %s", s.path)
+}
+
+func (s *eventSrc) Destination() string {
+	return s.destination
+}
+
+func (s *eventSrc) Retention() int {
+	return s.retention
+}
+
+func (s *eventSrc) Class() string {
+	return s.logGroupClass
+}
+
+func (s *eventSrc) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+func (s *eventSrc) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.file != nil {
+			s.file.Close()
+		}
+	})
+}
+
+func (s *eventSrc) stopped() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// run sends the events of the kernel ring buffer until the source is stopped
+// or the ring buffer cannot be read.
+func (s *eventSrc) run() {
+	defer s.outputFn(nil)
+	f, err := kmsg.Open(s.path)
+	if err != nil {
+		s.log.Errorf("Unable to open the kernel ring buffer: %v", err)
+		return
+	}
+	s.mu.Lock()
+	s.file = f
+	s.mu.Unlock()
+	if s.stopped() {
+		f.Close()
+		return
+	}
+	if err = s.send(kmsg.NewReader(f, s.log)); err != nil && !s.stopped() {
+		s.log.Errorf("Unable to read the kernel ring buffer: %v", err)
+	}
+}
+
+// send sends the kernel events of the reader to the output until the reader
+// fails or reaches its end.
+func (s *eventSrc) send(reader *kmsg.Reader) error {
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := record.Classify(); ok {
+			s.outputFn(&logEvent{message: record.String(), time: time.Now()})
+		}
+	}
+}
+
+// logEvent is a kernel event. The time of the event is the time it was read,
+// the timestamp of the record is relative to the boot of the host.
+type logEvent struct {
+	message string
+	time    time.Time
+}
+
+var _ logs.LogEvent = (*logEvent)(nil)
+
+func (e *logEvent) Message() string {
+	return e.message
+}
+
+func (e *logEvent) Time() time.Time {
+	return e.time
+}
+
+func (e *logEvent) Done() {
+}
+
+func init() {
+	inputs.Add("kernel_event_log", func() telegraf.Input {
+		return &KernelEventLog{}
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_event_log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/kernel_events/kmsg"
+)
+
+func TestFindLogSrc(t *testing.T) {
+	plugin := &KernelEventLog{Log: &testutil.Logger{}}
+	assert.Error(t, plugin.Start(nil))
+
+	plugin = &KernelEventLog{
+		LogGroupName:  "kernel-events",
+		LogStreamName: "i-1234567890abcdef0",
+		LogGroupClass: "STANDARD",
+		Destination:   "cloudwatchlogs",
+		Retention:     7,
+		Log:           &testutil.Logger{},
+	}
+	require.NoError(t, plugin.Start(nil))
+	require.NoError(t, plugin.Start(nil))
+	srcs := plugin.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Empty(t, plugin.FindLogSrc())
+
+	src := srcs[0]
+	assert.Equal(t, "kernel-events", src.Group())
+	assert.Equal(t, "i-1234567890abcdef0", src.Stream())
+	assert.Equal(t, "STANDARD", src.Class())
+	assert.Equal(t, "cloudwatchlogs", src.Destination())
+	assert.Equal(t, 7, src.Retention())
+	assert.Equal(t, "kernel events of "+kmsg.DefaultPath, src.Description())
+	assert.Nil(t, src.Entity())
+}
+
+func TestSend(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "kernel_events", "kmsg", "testdata", "kmsg.txt"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var messages []string
+	src := &eventSrc{outputFn: func(e logs.LogEvent) {
+		messages = append(messages, e.Message())
+		assert.WithinDuration(t, time.Now(), e.Time(), time.Minute)
+	}}
+	require.NoError(t, src.send(kmsg.NewReader(f, &testutil.Logger{})))
+	assert.Equal(t, []string{
+		"[  103.515622] Out of memory: Killed process 4711 (java) total-vm:8123456kB, anon-rss:3923456kB, file-rss:0kB, shmem-rss:0kB, UID:1000 pgtables:8192kB oom_score_adj:0",
+		"[  250.100000] Memory cgroup out of memory: Killed process 5120 (python3) total-vm:1023456kB, anon-rss:511234kB, file-rss:1024kB, shmem-rss:0kB, UID:0 pgtables:1100kB oom_score_adj:0",
+		"[  362.880112] INFO: task kworker/u4:2:87 blocked for more than 122 seconds.",
+		"[  410.021337] mce: [Hardware Error]: Machine check events logged",
+		"[  410.022001] mce: [Hardware Error]: CPU 3: Machine Check: 0 Bank 7: be00000000800400",
+	}, messages)
+}
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kmsg")
+	require.NoError(t, os.WriteFile(path, []byte("3,1,1,-;Out of memory: Killed process 1 (init)\n"), 0600))
+	for name, path := range map[string]string{"Existing": path, "Missing": filepath.Join(t.TempDir(), "missing")} {
+		t.Run(name, func(t *testing.T) {
+			src := &eventSrc{path: path, log: &testutil.Logger{}, done: make(chan struct{})}
+			events := make(chan logs.LogEvent, 1)
+			src.SetOutput(func(e logs.LogEvent) { events <- e })
+
+			// The events from before the start are not sent, and the source
+			// stops at the end of a file.
+			select {
+			case e := <-events:
+				assert.Nil(t, e)
+			case <-time.After(10 * time.Second):
+				require.Fail(t, "source did not stop")
+			}
+			src.Stop()
+			src.Stop()
+		})
+	}
+}
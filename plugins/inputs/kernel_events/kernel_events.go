@@ -0,0 +1,114 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	_ "embed"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/kernel_events/kmsg"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const measurement = "kernel_events"
+
+// KernelEvents counts the oom-kills, hung tasks and machine check exceptions
+// that the kernel reports in its ring buffer. Only the events that are
+// reported after the plugin is started are counted.
+type KernelEvents struct {
+	KmsgPath string          `toml:"kmsg_path"`
+	Log      telegraf.Logger `toml:"-"`
+
+	mu     sync.Mutex
+	counts map[kmsg.Event]int
+	file   *os.File
+	wg     sync.WaitGroup
+}
+
+var _ telegraf.ServiceInput = (*KernelEvents)(nil)
+
+// Description returns the description of the KernelEvents plugin
+func (*KernelEvents) Description() string {
+	return "Counts the oom-kills, hung tasks and machine check exceptions of the kernel"
+}
+
+func (*KernelEvents) SampleConfig() string {
+	return sampleConfig
+}
+
+func (k *KernelEvents) Init() error {
+	if k.KmsgPath == "" {
+		k.KmsgPath = kmsg.DefaultPath
+	}
+	k.counts = map[kmsg.Event]int{}
+	return nil
+}
+
+func (k *KernelEvents) Start(telegraf.Accumulator) error {
+	f, err := kmsg.Open(k.KmsgPath)
+	if err != nil {
+		return err
+	}
+	k.file = f
+	k.wg.Add(1)
+	go func() {
+		defer k.wg.Done()
+		k.read(kmsg.NewReader(f, k.Log))
+	}()
+	return nil
+}
+
+func (k *KernelEvents) Stop() {
+	if k.file != nil {
+		k.file.Close()
+	}
+	k.wg.Wait()
+}
+
+// read counts the events of the reader until it fails.
+func (k *KernelEvents) read(reader *kmsg.Reader) {
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) || errors.Is(err, os.ErrClosed) {
+			return
+		}
+		if err != nil {
+			k.Log.Errorf("Unable to read the kernel ring buffer: %v", err)
+			return
+		}
+		if event, ok := record.Classify(); ok {
+			k.mu.Lock()
+			k.counts[event]++
+			k.mu.Unlock()
+		}
+	}
+}
+
+// Gather implements the telegraf interface. The events of the collection
+// interval are reported.
+func (k *KernelEvents) Gather(acc telegraf.Accumulator) error {
+	k.mu.Lock()
+	fields := make(map[string]interface{}, len(kmsg.Events))
+	for _, event := range kmsg.Events {
+		fields[string(event)] = k.counts[event]
+	}
+	k.counts = map[kmsg.Event]int{}
+	k.mu.Unlock()
+	acc.AddGauge(measurement, fields, nil)
+	return nil
+}
+
+func init() {
+	inputs.Add(measurement, func() telegraf.Input {
+		return &KernelEvents{}
+	})
+}
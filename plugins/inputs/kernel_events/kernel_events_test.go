@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/kernel_events/kmsg"
+)
+
+func TestGather(t *testing.T) {
+	plugin := &KernelEvents{Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, kmsg.DefaultPath, plugin.KmsgPath)
+
+	f, err := os.Open(filepath.Join("kmsg", "testdata", "kmsg.txt"))
+	require.NoError(t, err)
+	defer f.Close()
+	plugin.read(kmsg.NewReader(f, plugin.Log))
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.NoError(t, plugin.Gather(&acc))
+
+	// The events are only reported in the interval they happened in.
+	expected := []telegraf.Metric{
+		testutil.MustMetric(measurement, map[string]string{}, map[string]interface{}{
+			"oom_kill":  2,
+			"hung_task": 1,
+			"mce":       2,
+		}, time.Unix(0, 0), telegraf.Gauge),
+		testutil.MustMetric(measurement, map[string]string{}, map[string]interface{}{
+			"oom_kill":  0,
+			"hung_task": 0,
+			"mce":       0,
+		}, time.Unix(0, 0), telegraf.Gauge),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kmsg")
+	require.NoError(t, os.WriteFile(path, []byte("3,1,1,-;Out of memory: Killed process 1 (init)\n"), 0600))
+	plugin := &KernelEvents{KmsgPath: path, Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Start(&acc))
+	plugin.Stop()
+
+	// The events from before the start are not counted.
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Equal(t, int64(0), acc.GetTelegrafMetrics()[0].Fields()["oom_kill"])
+
+	plugin.KmsgPath = filepath.Join(t.TempDir(), "missing")
+	assert.Error(t, plugin.Start(&acc))
+}
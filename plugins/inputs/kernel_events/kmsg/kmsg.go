@@ -0,0 +1,172 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package kmsg reads the records of the kernel ring buffer from /dev/kmsg and
+// classifies the records that report kernel and hardware errors.
+package kmsg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+const (
+	DefaultPath = "/dev/kmsg"
+	// readSize is larger than the largest record of /dev/kmsg. A read of
+	// /dev/kmsg returns a single record and fails if the buffer is smaller
+	// than the record.
+	readSize = 8192
+)
+
+// Event is a kind of kernel event.
+type Event string
+
+const (
+	OOMKill  Event = "oom_kill"
+	HungTask Event = "hung_task"
+	MCE      Event = "mce"
+)
+
+// Events are all the kinds of kernel events in a stable order.
+var Events = []Event{OOMKill, HungTask, MCE}
+
+var (
+	// The victim of the global and the memory cgroup oom-killer, e.g.
+	// "Out of memory: Killed process 1234 (java) total-vm:..." or
+	// "Memory cgroup out of memory: Killed process 1234 (java) ...".
+	oomKillPattern = regexp.MustCompile(`(?i)out of memory: killed process \d+`)
+	// e.g. "INFO: task kworker/0:1:123 blocked for more than 120 seconds."
+	hungTaskPattern = regexp.MustCompile(`^INFO: task .+ blocked for more than \d+ seconds`)
+	// The header of a machine check record or the notification of logged
+	// corrected errors, e.g. "mce: [Hardware Error]: CPU 0: Machine Check: 0
+	// Bank 5: ..." or "mce: [Hardware Error]: Machine check events logged".
+	mcePattern = regexp.MustCompile(`\[Hardware Error\]: (CPU \d+: Machine Check|Machine check events logged)`)
+)
+
+// Record is a record of the kernel ring buffer.
+type Record struct {
+	Priority int
+	Facility int
+	Sequence uint64
+	// Timestamp is the time since boot.
+	Timestamp time.Duration
+	Message   string
+}
+
+// Classify returns the kind of kernel event a record reports.
+func (r Record) Classify() (Event, bool) {
+	switch {
+	case oomKillPattern.MatchString(r.Message):
+		return OOMKill, true
+	case hungTaskPattern.MatchString(r.Message):
+		return HungTask, true
+	case mcePattern.MatchString(r.Message):
+		return MCE, true
+	}
+	return "", false
+}
+
+// String formats the record like dmesg does.
+func (r Record) String() string {
+	return fmt.Sprintf("[%12.6f] %s", r.Timestamp.Seconds(), r.Message)
+}
+
+// ParseRecord parses a record in the format of /dev/kmsg, e.g.
+// "6,339,5140900,-;NET: Registered protocol family 10". The key value
+// continuation lines that follow a record are not records.
+func ParseRecord(line string) (Record, error) {
+	prefix, message, ok := strings.Cut(line, ";")
+	if !ok {
+		return Record{}, fmt.Errorf("invalid record %q", line)
+	}
+	fields := strings.Split(prefix, ",")
+	if len(fields) < 4 {
+		return Record{}, fmt.Errorf("invalid record prefix %q", prefix)
+	}
+	value, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return Record{}, fmt.Errorf("invalid record priority %q", fields[0])
+	}
+	sequence, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return Record{}, fmt.Errorf("invalid record sequence %q", fields[1])
+	}
+	timestamp, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return Record{}, fmt.Errorf("invalid record timestamp %q", fields[2])
+	}
+	return Record{
+		Priority:  value & 7,
+		Facility:  value >> 3,
+		Sequence:  sequence,
+		Timestamp: time.Duration(timestamp) * time.Microsecond,
+		Message:   message,
+	}, nil
+}
+
+// Reader reads the records of the kernel ring buffer.
+type Reader struct {
+	r       io.Reader
+	log     telegraf.Logger
+	buf     []byte
+	pending []byte
+}
+
+// NewReader creates a reader of r. The records that cannot be parsed are
+// logged to log and skipped.
+func NewReader(r io.Reader, log telegraf.Logger) *Reader {
+	return &Reader{r: r, log: log, buf: make([]byte, readSize)}
+}
+
+// Open opens the kernel ring buffer at path. Only the records that are added
+// after it is opened are read.
+func Open(path string) (*os.File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = f.Seek(0, io.SeekEnd); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// Next returns the next record. Records that were overwritten in the ring
+// buffer before they were read, or that cannot be parsed, are skipped, so
+// the error is always from reading the ring buffer.
+func (r *Reader) Next() (Record, error) {
+	for {
+		if i := bytes.IndexByte(r.pending, '\n'); i >= 0 {
+			line := string(r.pending[:i])
+			r.pending = r.pending[i+1:]
+			if line == "" || line[0] == ' ' {
+				continue
+			}
+			record, err := ParseRecord(line)
+			if err != nil {
+				r.log.Warnf("Skipping the kernel record %q: %v", line, err)
+				continue
+			}
+			return record, nil
+		}
+		n, err := r.r.Read(r.buf)
+		r.pending = append(r.pending, r.buf[:n]...)
+		if errors.Is(err, syscall.EPIPE) {
+			continue
+		}
+		if err != nil {
+			return Record{}, err
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kmsg
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecord(t *testing.T) {
+	record, err := ParseRecord("3,1025,103515622,-;Out of memory: Killed process 4711 (java)")
+	require.NoError(t, err)
+	assert.Equal(t, Record{
+		Priority:  3,
+		Sequence:  1025,
+		Timestamp: 103515622 * time.Microsecond,
+		Message:   "Out of memory: Killed process 4711 (java)",
+	}, record)
+	assert.Equal(t, "[  103.515622] Out of memory: Killed process 4711 (java)", record.String())
+
+	// The facility is in the upper bits of the syslog prefix and the
+	// fields after the flags are reserved for extensions.
+	record, err = ParseRecord("30,7,1,-,caller=T1;systemd[1]: Started")
+	require.NoError(t, err)
+	assert.Equal(t, 6, record.Priority)
+	assert.Equal(t, 3, record.Facility)
+	assert.Equal(t, "systemd[1]: Started", record.Message)
+
+	for _, line := range []string{"no prefix", "3,1;short prefix", "x,1,1,-;priority", "3,x,1,-;sequence", "3,1,x,-;timestamp"} {
+		_, err = ParseRecord(line)
+		assert.Error(t, err, line)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	testCases := map[string]struct {
+		message string
+		want    Event
+	}{
+		"OOMKill":         {message: "Out of memory: Killed process 4711 (java) total-vm:8123456kB", want: OOMKill},
+		"CgroupOOMKill":   {message: "Memory cgroup out of memory: Killed process 5120 (python3)", want: OOMKill},
+		"OOMInvoked":      {message: "java invoked oom-killer: gfp_mask=0x140cca, order=0"},
+		"HungTask":        {message: "INFO: task kworker/u4:2:87 blocked for more than 122 seconds.", want: HungTask},
+		"MCENotification": {message: "mce: [Hardware Error]: Machine check events logged", want: MCE},
+		"MCEHeader":       {message: "mce: [Hardware Error]: CPU 3: Machine Check: 0 Bank 7: be00000000800400", want: MCE},
+		"MCEDetail":       {message: "mce: [Hardware Error]: TSC 0 ADDR 1fff8f4c0 MISC 84000000000000"},
+		"Other":           {message: "systemd[1]: Started Session 12 of User ec2-user."},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			event, ok := Record{Message: testCase.message}.Classify()
+			assert.Equal(t, testCase.want != "", ok)
+			assert.Equal(t, testCase.want, event)
+		})
+	}
+}
+
+func TestReader(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "kmsg.txt"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	reader := NewReader(f, &testutil.Logger{})
+	var sequences []uint64
+	events := map[Event]int{}
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		sequences = append(sequences, record.Sequence)
+		if event, ok := record.Classify(); ok {
+			events[event]++
+		}
+	}
+	// The continuation line of record 1025 is skipped.
+	assert.Len(t, sequences, 13)
+	assert.Equal(t, uint64(1021), sequences[0])
+	assert.Equal(t, uint64(1033), sequences[12])
+	assert.Equal(t, map[Event]int{OOMKill: 2, HungTask: 1, MCE: 2}, events)
+}
+
+// overrunReader fails a read with EPIPE, like /dev/kmsg does if records were
+// overwritten before they were read.
+type overrunReader struct {
+	reads []string
+}
+
+func (r *overrunReader) Read(p []byte) (int, error) {
+	if len(r.reads) == 0 {
+		return 0, io.EOF
+	}
+	read := r.reads[0]
+	r.reads = r.reads[1:]
+	if read == "" {
+		return 0, syscall.EPIPE
+	}
+	return copy(p, read), nil
+}
+
+func TestReaderOverrun(t *testing.T) {
+	reader := NewReader(&overrunReader{reads: []string{"6,1,1,-;first\n", "", "6,5,2,-;fifth\n"}}, &testutil.Logger{})
+	record, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "first", record.Message)
+	record, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "fifth", record.Message)
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReaderSkipsInvalidRecords(t *testing.T) {
+	reader := NewReader(&overrunReader{reads: []string{"6,1,1,-;first\n", "6,x,2,-;invalid\n", "6,3,3,-;third\n"}}, &testutil.Logger{})
+	record, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "first", record.Message)
+	record, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "third", record.Message)
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kmsg")
+	require.NoError(t, os.WriteFile(path, []byte("6,1,1,-;before\n"), 0600))
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	// The records that were in the ring buffer before are not read.
+	_, err = NewReader(f, &testutil.Logger{}).Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
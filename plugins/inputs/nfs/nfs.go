@@ -0,0 +1,274 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nfs
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement       = "nfs"
+	defaultProcfsRoot = "/proc"
+)
+
+// opStats are the cumulative per-op statistics of a mount. The columns of
+// the per-op lines in mountstats are the number of operations, transmissions,
+// major timeouts, bytes sent, bytes received and the cumulative queue, round
+// trip and execution times in milliseconds.
+type opStats struct {
+	ops, trans, timeouts, bytesSent, bytesRecv, queue, rtt, execute uint64
+}
+
+func (s opStats) add(o opStats) opStats {
+	return opStats{
+		ops:       s.ops + o.ops,
+		trans:     s.trans + o.trans,
+		timeouts:  s.timeouts + o.timeouts,
+		bytesSent: s.bytesSent + o.bytesSent,
+		bytesRecv: s.bytesRecv + o.bytesRecv,
+		queue:     s.queue + o.queue,
+		rtt:       s.rtt + o.rtt,
+		execute:   s.execute + o.execute,
+	}
+}
+
+// sub returns the difference to the previous statistics. The bool is false if
+// any counter decreased, which happens when the file system is remounted.
+func (s opStats) sub(previous opStats) (opStats, bool) {
+	if s.ops < previous.ops || s.trans < previous.trans || s.timeouts < previous.timeouts ||
+		s.bytesSent < previous.bytesSent || s.bytesRecv < previous.bytesRecv ||
+		s.queue < previous.queue || s.rtt < previous.rtt || s.execute < previous.execute {
+		return opStats{}, false
+	}
+	return opStats{
+		ops:       s.ops - previous.ops,
+		trans:     s.trans - previous.trans,
+		timeouts:  s.timeouts - previous.timeouts,
+		bytesSent: s.bytesSent - previous.bytesSent,
+		bytesRecv: s.bytesRecv - previous.bytesRecv,
+		queue:     s.queue - previous.queue,
+		rtt:       s.rtt - previous.rtt,
+		execute:   s.execute - previous.execute,
+	}, true
+}
+
+type mountStats struct {
+	export string
+	total  opStats
+	read   opStats
+	write  opStats
+}
+
+// NFS gathers the operations, round trip times and retransmissions of the
+// NFS mounts from mountstats. The values are reported per collection
+// interval, so nothing is reported for a mount on the first collection.
+type NFS struct {
+	MountPoints []string        `toml:"mount_points"`
+	ProcfsRoot  string          `toml:"procfs_root"`
+	Log         telegraf.Logger `toml:"-"`
+
+	previous map[string]mountStats
+}
+
+// Description returns the description of the NFS plugin
+func (*NFS) Description() string {
+	return "Gathers the operations, round trip times and retransmissions of NFS mounts"
+}
+
+func (*NFS) SampleConfig() string {
+	return sampleConfig
+}
+
+func (n *NFS) Init() error {
+	if n.ProcfsRoot == "" {
+		n.ProcfsRoot = defaultProcfsRoot
+	}
+	n.previous = map[string]mountStats{}
+	return nil
+}
+
+// Gather implements the telegraf interface
+func (n *NFS) Gather(acc telegraf.Accumulator) error {
+	f, err := os.Open(filepath.Join(n.ProcfsRoot, "self", "mountstats"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	mounts, err := parseMountStats(f)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]mountStats, len(mounts))
+	for path, stats := range mounts {
+		if !n.included(path) {
+			continue
+		}
+		current[path] = stats
+		previous, ok := n.previous[path]
+		if !ok {
+			continue
+		}
+		total, ok := stats.total.sub(previous.total)
+		if !ok {
+			n.Log.Debugf("Statistics of %s were reset", path)
+			continue
+		}
+		read, _ := stats.read.sub(previous.read)
+		write, _ := stats.write.sub(previous.write)
+		fields := map[string]interface{}{
+			"ops":         total.ops,
+			"retransmits": total.trans - min(total.ops, total.trans),
+			"timeouts":    total.timeouts,
+			"bytes_sent":  total.bytesSent,
+			"bytes_recv":  total.bytesRecv,
+			"read_ops":    read.ops,
+			"write_ops":   write.ops,
+		}
+		addAverage(fields, "rtt", total.rtt, total.ops)
+		addAverage(fields, "execute_time", total.execute, total.ops)
+		addAverage(fields, "queue_time", total.queue, total.ops)
+		addAverage(fields, "read_rtt", read.rtt, read.ops)
+		addAverage(fields, "write_rtt", write.rtt, write.ops)
+		acc.AddGauge(measurement, fields, map[string]string{"path": path, "export": stats.export})
+	}
+	n.previous = current
+	return nil
+}
+
+func (n *NFS) included(path string) bool {
+	if len(n.MountPoints) == 0 {
+		return true
+	}
+	for _, mountPoint := range n.MountPoints {
+		if mountPoint == path {
+			return true
+		}
+	}
+	return false
+}
+
+// addAverage adds the average time per operation in milliseconds if there
+// were operations in the interval.
+func addAverage(fields map[string]interface{}, field string, total, ops uint64) {
+	if ops > 0 {
+		fields[field] = float64(total) / float64(ops)
+	}
+}
+
+// parseMountStats returns the statistics of the NFS mounts by mount point.
+// The format is described in
+// https://git.linux-nfs.org/?p=steved/nfs-utils.git;a=blob;f=tools/mountstats/mountstats.py
+func parseMountStats(r io.Reader) (map[string]mountStats, error) {
+	mounts := map[string]mountStats{}
+	var path string
+	var stats *mountStats
+	inOps := false
+	flush := func() {
+		if stats != nil {
+			mounts[path] = *stats
+		}
+		stats = nil
+		inOps = false
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		columns := strings.Fields(line)
+		if len(columns) == 0 {
+			continue
+		}
+		// device <export> mounted on <path> with fstype <type> [statvers=<version>]
+		if columns[0] == "device" {
+			flush()
+			if len(columns) >= 8 && columns[2] == "mounted" && columns[3] == "on" && strings.HasPrefix(columns[7], "nfs") {
+				path = unescape(columns[4])
+				stats = &mountStats{export: unescape(columns[1])}
+			}
+			continue
+		}
+		if stats == nil {
+			continue
+		}
+		if line = strings.TrimSpace(line); line == "per-op statistics" {
+			inOps = true
+			continue
+		}
+		if !inOps || !strings.HasSuffix(columns[0], ":") {
+			continue
+		}
+		op := strings.TrimSuffix(columns[0], ":")
+		values := make([]uint64, 8)
+		if len(columns) < len(values)+1 {
+			return nil, fmt.Errorf("unexpected per-op statistics for %s: %q", path, line)
+		}
+		for i := range values {
+			v, err := strconv.ParseUint(columns[i+1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse per-op statistics for %s: %w", path, err)
+			}
+			values[i] = v
+		}
+		s := opStats{
+			ops:       values[0],
+			trans:     values[1],
+			timeouts:  values[2],
+			bytesSent: values[3],
+			bytesRecv: values[4],
+			queue:     values[5],
+			rtt:       values[6],
+			execute:   values[7],
+		}
+		stats.total = stats.total.add(s)
+		switch op {
+		case "READ":
+			stats.read = s
+		case "WRITE":
+			stats.write = s
+		}
+	}
+	flush()
+	return mounts, scanner.Err()
+}
+
+// unescape replaces the octal escapes the kernel uses for white space in the
+// mount points, e.g. "\040" for a space.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func init() {
+	inputs.Add(measurement, func() telegraf.Input {
+		return &NFS{
+			ProcfsRoot: defaultProcfsRoot,
+		}
+	})
+}
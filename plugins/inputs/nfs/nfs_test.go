@@ -0,0 +1,112 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nfs
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMountStats(t *testing.T) {
+	mounts, err := parseMountStats(strings.NewReader(`device proc mounted on /proc with fstype proc
+device server:/export mounted on /mnt/a\040b with fstype nfs statvers=1.1
+	per-op statistics
+	        READ: 1 2 0 3 4 5 6 7
+	       WRITE: 10 10 0 30 40 50 60 70 0
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]mountStats{
+		"/mnt/a b": {
+			export: "server:/export",
+			total:  opStats{ops: 11, trans: 12, bytesSent: 33, bytesRecv: 44, queue: 55, rtt: 66, execute: 77},
+			read:   opStats{ops: 1, trans: 2, bytesSent: 3, bytesRecv: 4, queue: 5, rtt: 6, execute: 7},
+			write:  opStats{ops: 10, trans: 10, bytesSent: 30, bytesRecv: 40, queue: 50, rtt: 60, execute: 70},
+		},
+	}, mounts)
+
+	_, err = parseMountStats(strings.NewReader(`device server:/export mounted on /mnt with fstype nfs4
+	per-op statistics
+	        READ: 1 2 3
+`))
+	assert.Error(t, err)
+}
+
+func TestGather(t *testing.T) {
+	plugin := &NFS{ProcfsRoot: filepath.Join("testdata", "first"), Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Empty(t, acc.GetTelegrafMetrics())
+
+	plugin.ProcfsRoot = filepath.Join("testdata", "second")
+	require.NoError(t, plugin.Gather(&acc))
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(measurement, map[string]string{"path": "/mnt/efs", "export": "fs-0123.efs.us-east-1.amazonaws.com:/"}, map[string]interface{}{
+			"ops":          uint64(60),
+			"retransmits":  uint64(5),
+			"timeouts":     uint64(1),
+			"bytes_sent":   uint64(168000),
+			"bytes_recv":   uint64(644000),
+			"read_ops":     uint64(40),
+			"write_ops":    uint64(10),
+			"rtt":          320.0 / 60,
+			"execute_time": 375.0 / 60,
+			"queue_time":   22.0 / 60,
+			"read_rtt":     5.0,
+			"write_rtt":    10.0,
+		}, time.Unix(0, 0), telegraf.Gauge),
+		testutil.MustMetric(measurement, map[string]string{"path": "/home/shared dir", "export": "server:/export/home dir"}, map[string]interface{}{
+			"ops":         uint64(0),
+			"retransmits": uint64(0),
+			"timeouts":    uint64(0),
+			"bytes_sent":  uint64(0),
+			"bytes_recv":  uint64(0),
+			"read_ops":    uint64(0),
+			"write_ops":   uint64(0),
+		}, time.Unix(0, 0), telegraf.Gauge),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime(), testutil.SortMetrics())
+}
+
+func TestGatherMountPoints(t *testing.T) {
+	plugin := &NFS{
+		MountPoints: []string{"/home/shared dir"},
+		ProcfsRoot:  filepath.Join("testdata", "first"),
+		Log:         &testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	plugin.ProcfsRoot = filepath.Join("testdata", "second")
+	require.NoError(t, plugin.Gather(&acc))
+
+	metrics := acc.GetTelegrafMetrics()
+	require.Len(t, metrics, 1)
+	assert.Equal(t, "/home/shared dir", metrics[0].Tags()["path"])
+}
+
+func TestGatherReset(t *testing.T) {
+	plugin := &NFS{ProcfsRoot: filepath.Join("testdata", "second"), Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	plugin.ProcfsRoot = filepath.Join("testdata", "first")
+	require.NoError(t, plugin.Gather(&acc))
+
+	// the counters of /mnt/efs decreased, so only the unchanged mount is reported
+	metrics := acc.GetTelegrafMetrics()
+	require.Len(t, metrics, 1)
+	assert.Equal(t, "/home/shared dir", metrics[0].Tags()["path"])
+}
@@ -0,0 +1,263 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement       = "pressure"
+	defaultProcfsRoot = "/proc"
+	defaultSysfsRoot  = "/sys"
+	cgroupTag         = "cgroup"
+)
+
+// resources are the resources with pressure stall information. The host
+// values are in <procfs>/pressure/<resource> and the cgroup values in
+// <cgroup>/<resource>.pressure.
+var resources = []string{"cpu", "memory", "io"}
+
+// memoryEvents are the counters of memory.events that are collected.
+var memoryEvents = map[string]bool{"low": true, "high": true, "max": true, "oom": true, "oom_kill": true}
+
+// Pressure gathers the pressure stall information (PSI) of the host and the
+// cpu, memory and io statistics of cgroup v2 cgroups.
+type Pressure struct {
+	// Cgroups are glob patterns of the cgroups to collect, relative to the
+	// cgroup v2 mount point, e.g. "system.slice/*.service".
+	Cgroups    []string        `toml:"cgroups"`
+	ProcfsRoot string          `toml:"procfs_root"`
+	SysfsRoot  string          `toml:"sysfs_root"`
+	Log        telegraf.Logger `toml:"-"`
+}
+
+// Description returns the description of the Pressure plugin
+func (*Pressure) Description() string {
+	return "Gathers pressure stall information and cgroup v2 statistics"
+}
+
+func (*Pressure) SampleConfig() string {
+	return sampleConfig
+}
+
+func (p *Pressure) Init() error {
+	if p.ProcfsRoot == "" {
+		p.ProcfsRoot = defaultProcfsRoot
+	}
+	if p.SysfsRoot == "" {
+		p.SysfsRoot = defaultSysfsRoot
+	}
+	for _, pattern := range p.Cgroups {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid cgroup pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Gather implements the telegraf interface
+func (p *Pressure) Gather(acc telegraf.Accumulator) error {
+	fields := map[string]interface{}{}
+	for _, resource := range resources {
+		if err := readPressure(filepath.Join(p.ProcfsRoot, "pressure", resource), resource, fields); err != nil {
+			acc.AddError(err)
+		}
+	}
+	if len(fields) > 0 {
+		acc.AddFields(measurement, fields, nil)
+	}
+
+	cgroups, err := p.matchingCgroups()
+	if err != nil {
+		return err
+	}
+	for _, cgroup := range cgroups {
+		fields = map[string]interface{}{}
+		if err = p.gatherCgroup(cgroup, fields); err != nil {
+			acc.AddError(err)
+		}
+		if len(fields) > 0 {
+			acc.AddFields(measurement, fields, map[string]string{cgroupTag: cgroup})
+		}
+	}
+	return nil
+}
+
+func (p *Pressure) cgroupRoot() string {
+	return filepath.Join(p.SysfsRoot, "fs", "cgroup")
+}
+
+// matchingCgroups returns the sorted cgroup directories matching the
+// patterns, relative to the cgroup root.
+func (p *Pressure) matchingCgroups() ([]string, error) {
+	root := p.cgroupRoot()
+	set := map[string]struct{}{}
+	for _, pattern := range p.Cgroups {
+		matches, err := filepath.Glob(filepath.Join(root, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.IsDir() {
+				continue
+			}
+			rel, err := filepath.Rel(root, match)
+			if err != nil {
+				continue
+			}
+			set[filepath.ToSlash(rel)] = struct{}{}
+		}
+	}
+	cgroups := make([]string, 0, len(set))
+	for cgroup := range set {
+		cgroups = append(cgroups, cgroup)
+	}
+	sort.Strings(cgroups)
+	return cgroups, nil
+}
+
+func (p *Pressure) gatherCgroup(cgroup string, fields map[string]interface{}) error {
+	dir := filepath.Join(p.cgroupRoot(), filepath.FromSlash(cgroup))
+	var errs []error
+	for _, resource := range resources {
+		errs = append(errs, readPressure(filepath.Join(dir, resource+".pressure"), resource, fields))
+	}
+	errs = append(errs,
+		readFlatKeyed(filepath.Join(dir, "cpu.stat"), "cpu_", nil, fields),
+		readFlatKeyed(filepath.Join(dir, "memory.events"), "memory_events_", memoryEvents, fields),
+		readIOStat(filepath.Join(dir, "io.stat"), fields),
+	)
+	return errors.Join(errs...)
+}
+
+// readPressure parses a PSI file such as
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//
+// into fields named <resource>_<some|full>_<avg10|avg60|avg300|total>. Missing
+// files are skipped since PSI depends on the kernel and the enabled
+// controllers.
+func readPressure(path, resource string, fields map[string]interface{}) error {
+	return scanFile(path, func(line string) error {
+		columns := strings.Fields(line)
+		if len(columns) == 0 {
+			return nil
+		}
+		prefix := resource + "_" + columns[0] + "_"
+		for _, column := range columns[1:] {
+			key, value, ok := strings.Cut(column, "=")
+			if !ok {
+				return fmt.Errorf("invalid pressure value %q", column)
+			}
+			if key == "total" {
+				v, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					return err
+				}
+				fields[prefix+key] = v
+				continue
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return err
+			}
+			fields[prefix+key] = v
+		}
+		return nil
+	})
+}
+
+// readFlatKeyed parses a cgroup file of "<key> <value>" lines. If allowed is
+// not nil, the keys not in it are skipped.
+func readFlatKeyed(path, prefix string, allowed map[string]bool, fields map[string]interface{}) error {
+	return scanFile(path, func(line string) error {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok || (allowed != nil && !allowed[key]) {
+			return nil
+		}
+		v, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return err
+		}
+		fields[prefix+key] = v
+		return nil
+	})
+}
+
+// readIOStat parses io.stat lines such as
+//
+//	8:0 rbytes=1024 wbytes=4096 rios=1 wios=4 dbytes=0 dios=0
+//
+// and sums the values of every device into fields named io_<key>.
+func readIOStat(path string, fields map[string]interface{}) error {
+	totals := map[string]uint64{}
+	err := scanFile(path, func(line string) error {
+		columns := strings.Fields(line)
+		if len(columns) < 2 {
+			return nil
+		}
+		for _, column := range columns[1:] {
+			key, value, ok := strings.Cut(column, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return err
+			}
+			totals[key] += v
+		}
+		return nil
+	})
+	for key, total := range totals {
+		fields["io_"+key] = total
+	}
+	return err
+}
+
+func scanFile(path string, fn func(line string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			if err = fn(line); err != nil {
+				return fmt.Errorf("unable to parse %s: %w", path, err)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func init() {
+	inputs.Add(measurement, func() telegraf.Input {
+		return &Pressure{
+			ProcfsRoot: defaultProcfsRoot,
+			SysfsRoot:  defaultSysfsRoot,
+		}
+	})
+}
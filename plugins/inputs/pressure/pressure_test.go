@@ -0,0 +1,147 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPlugin(cgroups ...string) *Pressure {
+	return &Pressure{
+		Cgroups:    cgroups,
+		ProcfsRoot: filepath.Join("testdata", "proc"),
+		SysfsRoot:  filepath.Join("testdata", "sys"),
+		Log:        &testutil.Logger{},
+	}
+}
+
+func TestInit(t *testing.T) {
+	plugin := &Pressure{Cgroups: []string{"["}}
+	assert.Error(t, plugin.Init())
+
+	plugin = &Pressure{}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, "/proc", plugin.ProcfsRoot)
+	assert.Equal(t, "/sys", plugin.SysfsRoot)
+}
+
+func TestGatherHost(t *testing.T) {
+	plugin := newTestPlugin()
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Empty(t, acc.Errors)
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(measurement, map[string]string{}, map[string]interface{}{
+			"cpu_some_avg10":     1.53,
+			"cpu_some_avg60":     0.87,
+			"cpu_some_avg300":    0.43,
+			"cpu_some_total":     uint64(43241),
+			"cpu_full_avg10":     0.0,
+			"cpu_full_avg60":     0.0,
+			"cpu_full_avg300":    0.0,
+			"cpu_full_total":     uint64(0),
+			"memory_some_avg10":  0.2,
+			"memory_some_avg60":  0.1,
+			"memory_some_avg300": 0.05,
+			"memory_some_total":  uint64(1200),
+			"memory_full_avg10":  0.1,
+			"memory_full_avg60":  0.05,
+			"memory_full_avg300": 0.01,
+			"memory_full_total":  uint64(800),
+			"io_some_avg10":      4.0,
+			"io_some_avg60":      2.5,
+			"io_some_avg300":     1.25,
+			"io_some_total":      uint64(998877),
+			"io_full_avg10":      3.0,
+			"io_full_avg60":      2.0,
+			"io_full_avg300":     1.0,
+			"io_full_total":      uint64(776655),
+		}, time.Unix(0, 0)),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestGatherCgroups(t *testing.T) {
+	plugin := newTestPlugin("system.slice/*.service", "system.slice/nginx.service", "missing.slice")
+	plugin.ProcfsRoot = t.TempDir()
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Empty(t, acc.Errors)
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(measurement, map[string]string{"cgroup": "system.slice/nginx.service"}, map[string]interface{}{
+			"cpu_some_avg10":         0.5,
+			"cpu_some_avg60":         0.25,
+			"cpu_some_avg300":        0.1,
+			"cpu_some_total":         uint64(1000),
+			"cpu_full_avg10":         0.2,
+			"cpu_full_avg60":         0.1,
+			"cpu_full_avg300":        0.05,
+			"cpu_full_total":         uint64(500),
+			"memory_some_avg10":      0.0,
+			"memory_some_avg60":      0.0,
+			"memory_some_avg300":     0.0,
+			"memory_some_total":      uint64(0),
+			"memory_full_avg10":      0.0,
+			"memory_full_avg60":      0.0,
+			"memory_full_avg300":     0.0,
+			"memory_full_total":      uint64(0),
+			"cpu_usage_usec":         uint64(2345678),
+			"cpu_user_usec":          uint64(1345678),
+			"cpu_system_usec":        uint64(1000000),
+			"cpu_nr_periods":         uint64(120),
+			"cpu_nr_throttled":       uint64(7),
+			"cpu_throttled_usec":     uint64(56789),
+			"memory_events_low":      uint64(0),
+			"memory_events_high":     uint64(12),
+			"memory_events_max":      uint64(3),
+			"memory_events_oom":      uint64(2),
+			"memory_events_oom_kill": uint64(1),
+			"io_rbytes":              uint64(1049600),
+			"io_wbytes":              uint64(2101248),
+			"io_rios":                uint64(101),
+			"io_wios":                uint64(204),
+			"io_dbytes":              uint64(512),
+			"io_dios":                uint64(1),
+		}, time.Unix(0, 0)),
+		testutil.MustMetric(measurement, map[string]string{"cgroup": "system.slice/sshd.service"}, map[string]interface{}{
+			"cpu_usage_usec":         uint64(1000),
+			"cpu_user_usec":          uint64(600),
+			"cpu_system_usec":        uint64(400),
+			"memory_events_low":      uint64(0),
+			"memory_events_high":     uint64(0),
+			"memory_events_max":      uint64(0),
+			"memory_events_oom":      uint64(0),
+			"memory_events_oom_kill": uint64(0),
+		}, time.Unix(0, 0)),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestGatherInvalidFile(t *testing.T) {
+	procfs := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(procfs, "pressure"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(procfs, "pressure", "cpu"), []byte("some avg10=abc\n"), 0600))
+	plugin := newTestPlugin()
+	plugin.ProcfsRoot = procfs
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Len(t, acc.Errors, 1)
+	assert.Empty(t, acc.GetTelegrafMetrics())
+}
@@ -0,0 +1,240 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package probes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement    = "probes"
+	defaultTimeout = 5 * time.Second
+	probeTag       = "probe"
+	endpointTag    = "endpoint"
+)
+
+// HTTPProbe is a request to a URL. The probe is up if the response status
+// code is one of the expected status codes, or below 400 if none are set.
+type HTTPProbe struct {
+	URL                 string            `toml:"url"`
+	Method              string            `toml:"method"`
+	Headers             map[string]string `toml:"headers"`
+	ExpectedStatusCodes []int             `toml:"expected_status_codes"`
+	Timeout             config.Duration   `toml:"timeout"`
+	InsecureSkipVerify  bool              `toml:"insecure_skip_verify"`
+
+	client *http.Client
+}
+
+// TCPProbe is a connection to an address. With TLS, the probe completes a
+// TLS handshake after connecting.
+type TCPProbe struct {
+	Address            string          `toml:"address"`
+	Timeout            config.Duration `toml:"timeout"`
+	TLS                bool            `toml:"tls"`
+	InsecureSkipVerify bool            `toml:"insecure_skip_verify"`
+}
+
+// Probes gathers whether HTTP and TCP endpoints are up, their response time
+// and the days until their TLS certificates expire.
+type Probes struct {
+	HTTP    []*HTTPProbe    `toml:"http"`
+	TCP     []*TCPProbe     `toml:"tcp"`
+	Timeout config.Duration `toml:"timeout"`
+	Log     telegraf.Logger `toml:"-"`
+
+	now func() time.Time
+}
+
+// Description returns the description of the Probes plugin
+func (*Probes) Description() string {
+	return "Probes HTTP and TCP endpoints for availability, response time and TLS certificate expiry"
+}
+
+func (*Probes) SampleConfig() string {
+	return sampleConfig
+}
+
+func (p *Probes) Init() error {
+	if len(p.HTTP) == 0 && len(p.TCP) == 0 {
+		return errors.New("at least one http or tcp probe must be set")
+	}
+	if p.Timeout <= 0 {
+		p.Timeout = config.Duration(defaultTimeout)
+	}
+	if p.now == nil {
+		p.now = time.Now
+	}
+	for _, probe := range p.HTTP {
+		u, err := url.Parse(probe.URL)
+		if err != nil {
+			return fmt.Errorf("invalid url %q: %w", probe.URL, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("unsupported scheme of url %q", probe.URL)
+		}
+		if probe.Method == "" {
+			probe.Method = http.MethodGet
+		}
+		if probe.Timeout <= 0 {
+			probe.Timeout = p.Timeout
+		}
+		probe.client = &http.Client{
+			Timeout: time.Duration(probe.Timeout),
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				// Every probe opens a new connection so that the response
+				// time includes connecting and the TLS handshake.
+				DisableKeepAlives: true,
+				TLSClientConfig:   &tls.Config{InsecureSkipVerify: probe.InsecureSkipVerify}, //nolint:gosec
+			},
+		}
+	}
+	for _, probe := range p.TCP {
+		if _, _, err := net.SplitHostPort(probe.Address); err != nil {
+			return fmt.Errorf("invalid address %q: %w", probe.Address, err)
+		}
+		if probe.Timeout <= 0 {
+			probe.Timeout = p.Timeout
+		}
+	}
+	return nil
+}
+
+// Gather implements the telegraf interface. The probes run concurrently so
+// that a slow endpoint does not delay the others.
+func (p *Probes) Gather(acc telegraf.Accumulator) error {
+	var wg sync.WaitGroup
+	for _, probe := range p.HTTP {
+		wg.Add(1)
+		go func(probe *HTTPProbe) {
+			defer wg.Done()
+			acc.AddFields(measurement, p.probeHTTP(probe), map[string]string{probeTag: "http", endpointTag: probe.URL})
+		}(probe)
+	}
+	for _, probe := range p.TCP {
+		wg.Add(1)
+		go func(probe *TCPProbe) {
+			defer wg.Done()
+			acc.AddFields(measurement, p.probeTCP(probe), map[string]string{probeTag: "tcp", endpointTag: probe.Address})
+		}(probe)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (p *Probes) probeHTTP(probe *HTTPProbe) map[string]interface{} {
+	fields := map[string]interface{}{"up": 0}
+	req, err := http.NewRequest(probe.Method, probe.URL, nil)
+	if err != nil {
+		p.Log.Debugf("Unable to create the request for %s: %v", probe.URL, err)
+		return fields
+	}
+	for key, value := range probe.Headers {
+		if strings.EqualFold(key, "host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+	start := time.Now()
+	resp, err := probe.client.Do(req)
+	if err != nil {
+		p.Log.Debugf("Unable to probe %s: %v", probe.URL, err)
+		return fields
+	}
+	fields["response_time"] = milliseconds(time.Since(start))
+	resp.Body.Close()
+	fields["status_code"] = resp.StatusCode
+	if probe.expected(resp.StatusCode) {
+		fields["up"] = 1
+	}
+	if resp.TLS != nil {
+		p.addCertExpiry(fields, resp.TLS.PeerCertificates)
+	}
+	return fields
+}
+
+func (probe *HTTPProbe) expected(statusCode int) bool {
+	if len(probe.ExpectedStatusCodes) == 0 {
+		return statusCode < http.StatusBadRequest
+	}
+	for _, expected := range probe.ExpectedStatusCodes {
+		if statusCode == expected {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Probes) probeTCP(probe *TCPProbe) map[string]interface{} {
+	fields := map[string]interface{}{"up": 0}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(probe.Timeout))
+	defer cancel()
+	start := time.Now()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", probe.Address)
+	if err != nil {
+		p.Log.Debugf("Unable to connect to %s: %v", probe.Address, err)
+		return fields
+	}
+	defer conn.Close()
+	if probe.TLS {
+		host, _, _ := net.SplitHostPort(probe.Address)
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host, InsecureSkipVerify: probe.InsecureSkipVerify}) //nolint:gosec
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			p.Log.Debugf("Unable to complete the TLS handshake with %s: %v", probe.Address, err)
+			return fields
+		}
+		p.addCertExpiry(fields, tlsConn.ConnectionState().PeerCertificates)
+	}
+	fields["response_time"] = milliseconds(time.Since(start))
+	fields["up"] = 1
+	return fields
+}
+
+// addCertExpiry adds the days until the first of the certificates expires,
+// since an expired intermediate breaks the chain as much as the leaf.
+func (p *Probes) addCertExpiry(fields map[string]interface{}, certs []*x509.Certificate) {
+	if len(certs) == 0 {
+		return
+	}
+	notAfter := certs[0].NotAfter
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	fields["cert_expiry_days"] = notAfter.Sub(p.now()).Hours() / 24
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func init() {
+	inputs.Add(measurement, func() telegraf.Input {
+		return &Probes{
+			Timeout: config.Duration(defaultTimeout),
+		}
+	})
+}
@@ -0,0 +1,213 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package probes
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fieldsByEndpoint(t *testing.T, acc *testutil.Accumulator) map[string]map[string]interface{} {
+	t.Helper()
+	result := map[string]map[string]interface{}{}
+	for _, m := range acc.GetTelegrafMetrics() {
+		assert.Equal(t, measurement, m.Name())
+		endpoint, ok := m.GetTag(endpointTag)
+		require.True(t, ok)
+		result[endpoint] = m.Fields()
+	}
+	return result
+}
+
+func TestInit(t *testing.T) {
+	plugin := &Probes{Log: &testutil.Logger{}}
+	require.Error(t, plugin.Init())
+
+	plugin = &Probes{HTTP: []*HTTPProbe{{URL: "ftp://localhost"}}, Log: &testutil.Logger{}}
+	require.Error(t, plugin.Init())
+
+	plugin = &Probes{TCP: []*TCPProbe{{Address: "localhost"}}, Log: &testutil.Logger{}}
+	require.Error(t, plugin.Init())
+
+	plugin = &Probes{
+		HTTP: []*HTTPProbe{{URL: "http://localhost/health"}, {URL: "http://localhost/slow", Timeout: config.Duration(time.Minute)}},
+		TCP:  []*TCPProbe{{Address: "localhost:5432"}},
+		Log:  &testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, http.MethodGet, plugin.HTTP[0].Method)
+	assert.Equal(t, config.Duration(defaultTimeout), plugin.HTTP[0].Timeout)
+	assert.Equal(t, config.Duration(time.Minute), plugin.HTTP[1].Timeout)
+	assert.Equal(t, config.Duration(defaultTimeout), plugin.TCP[0].Timeout)
+}
+
+func TestGatherHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			assert.Equal(t, http.MethodHead, r.Method)
+			assert.Equal(t, "example.com", r.Host)
+			assert.Equal(t, "probe", r.Header.Get("X-Source"))
+			w.WriteHeader(http.StatusNoContent)
+		case "/accepted":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	plugin := &Probes{
+		HTTP: []*HTTPProbe{
+			{URL: server.URL + "/health", Method: http.MethodHead, Headers: map[string]string{"Host": "example.com", "X-Source": "probe"}},
+			{URL: server.URL + "/accepted", ExpectedStatusCodes: []int{http.StatusOK}},
+			{URL: server.URL + "/unavailable"},
+		},
+		Log: &testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	fields := fieldsByEndpoint(t, &acc)
+	require.Len(t, fields, 3)
+
+	health := fields[server.URL+"/health"]
+	assert.Equal(t, int64(1), health["up"])
+	assert.Equal(t, int64(http.StatusNoContent), health["status_code"])
+	assert.IsType(t, float64(0), health["response_time"])
+	assert.NotContains(t, health, "cert_expiry_days")
+
+	accepted := fields[server.URL+"/accepted"]
+	assert.Equal(t, int64(0), accepted["up"])
+	assert.Equal(t, int64(http.StatusAccepted), accepted["status_code"])
+
+	unavailable := fields[server.URL+"/unavailable"]
+	assert.Equal(t, int64(0), unavailable["up"])
+	assert.Equal(t, int64(http.StatusServiceUnavailable), unavailable["status_code"])
+
+	for _, m := range acc.GetTelegrafMetrics() {
+		assert.Equal(t, map[string]string{probeTag: "http", endpointTag: m.Tags()[endpointTag]}, m.Tags())
+	}
+}
+
+func TestGatherHTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	plugin := &Probes{
+		HTTP: []*HTTPProbe{
+			{URL: server.URL + "/insecure", InsecureSkipVerify: true},
+			{URL: server.URL + "/verified"},
+		},
+		Log: &testutil.Logger{},
+		now: func() time.Time { return now },
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	fields := fieldsByEndpoint(t, &acc)
+
+	insecure := fields[server.URL+"/insecure"]
+	assert.Equal(t, int64(1), insecure["up"])
+	assert.Equal(t, server.Certificate().NotAfter.Sub(now).Hours()/24, insecure["cert_expiry_days"])
+
+	// The test certificate is not trusted, so the probe fails without
+	// insecure_skip_verify.
+	assert.Equal(t, map[string]interface{}{"up": int64(0)}, fields[server.URL+"/verified"])
+}
+
+func TestGatherTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddress := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	tlsAddress := server.Listener.Addr().String()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	plugin := &Probes{
+		TCP: []*TCPProbe{
+			{Address: listener.Addr().String()},
+			{Address: closedAddress, Timeout: config.Duration(time.Second)},
+			{Address: tlsAddress, TLS: true, InsecureSkipVerify: true},
+		},
+		Log: &testutil.Logger{},
+		now: func() time.Time { return now },
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	fields := fieldsByEndpoint(t, &acc)
+	require.Len(t, fields, 3)
+
+	open := fields[listener.Addr().String()]
+	assert.Equal(t, int64(1), open["up"])
+	assert.IsType(t, float64(0), open["response_time"])
+	assert.NotContains(t, open, "cert_expiry_days")
+
+	assert.Equal(t, map[string]interface{}{"up": int64(0)}, fields[closedAddress])
+
+	secure := fields[tlsAddress]
+	assert.Equal(t, int64(1), secure["up"])
+	assert.Equal(t, server.Certificate().NotAfter.Sub(now).Hours()/24, secure["cert_expiry_days"])
+}
+
+func TestLoadConfig(t *testing.T) {
+	c := config.NewConfig()
+	require.NoError(t, c.LoadConfigData([]byte(`
+[[inputs.probes]]
+  timeout = "10s"
+
+  [[inputs.probes.http]]
+    url = "https://localhost:8443/health"
+    expected_status_codes = [200]
+    timeout = "3s"
+    [inputs.probes.http.headers]
+      Host = "example.com"
+
+  [[inputs.probes.tcp]]
+    address = "localhost:636"
+    tls = true
+`)))
+	require.Len(t, c.Inputs, 1)
+	plugin, ok := c.Inputs[0].Input.(*Probes)
+	require.True(t, ok)
+	assert.Equal(t, config.Duration(10*time.Second), plugin.Timeout)
+	require.Len(t, plugin.HTTP, 1)
+	assert.Equal(t, "https://localhost:8443/health", plugin.HTTP[0].URL)
+	assert.Equal(t, []int{200}, plugin.HTTP[0].ExpectedStatusCodes)
+	assert.Equal(t, config.Duration(3*time.Second), plugin.HTTP[0].Timeout)
+	assert.Equal(t, map[string]string{"Host": "example.com"}, plugin.HTTP[0].Headers)
+	require.Len(t, plugin.TCP, 1)
+	assert.Equal(t, "localhost:636", plugin.TCP[0].Address)
+	assert.True(t, plugin.TCP[0].TLS)
+}
@@ -52,8 +52,15 @@ func (c *Calculator) Calculate(pmb PrometheusMetricBatch) (result PrometheusMetr
 	return
 }
 
-func NewCalculator() *Calculator {
+// Shutdown saves the state of the delta calculator.
+func (c *Calculator) Shutdown() {
+	c.deltaCalculator.Shutdown()
+}
+
+// NewCalculator creates a calculator. The delta calculator state is saved to
+// the deltaStateFile, if set.
+func NewCalculator(deltaStateFile string) *Calculator {
 	return &Calculator{
-		deltaCalculator: NewDeltaCalculator(),
+		deltaCalculator: NewDeltaCalculator(deltaStateFile),
 	}
 }
@@ -5,6 +5,7 @@ package prometheus
 
 import (
 	"log"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/mapWithExpiry"
@@ -16,12 +17,23 @@ const (
 )
 
 type dataPoint struct {
-	value    float64
-	timeInMS int64
+	value         float64
+	timeInMS      int64
+	startTimeInMS int64
 }
 type DeltaCalculator struct {
 	preDataPoints       *mapWithExpiry.MapWithExpiry
 	lastCleanUpTimeInMs int64
+	// stateFile is where the previous data points are saved, so that the
+	// deltas continue across restarts of the agent. Not saved if empty.
+	stateFile string
+	// watchedSinceInMS is the time since which the calculator has seen every
+	// series. A counter created after it was not reported before, so its
+	// first sample is the delta from zero.
+	watchedSinceInMS int64
+	// firstObservationDropped is the number of samples dropped because there
+	// was no previous data point to calculate the delta from.
+	firstObservationDropped atomic.Int64
 }
 
 func (dc *DeltaCalculator) calculate(pm *PrometheusMetric) (res *PrometheusMetric) {
@@ -41,27 +53,77 @@ func (dc *DeltaCalculator) calculate(pm *PrometheusMetric) (res *PrometheusMetri
 	if v, ok := dc.preDataPoints.Get(metricKey); ok {
 		preDataPoint := v.(dataPoint)
 		if curTimeInMS > preDataPoint.timeInMS {
-			if curVal >= preDataPoint.value {
-				pm.metricValue = curVal - preDataPoint.value
-			} else {
+			if isCounterReset(preDataPoint, pm) {
 				// the counter has been reset, keep the current value as delta
 				pm.metricValue = curVal
+			} else {
+				pm.metricValue = curVal - preDataPoint.value
 			}
 		}
 		res = pm
+	} else if pm.startTimeInMS > 0 && pm.startTimeInMS >= dc.watchedSinceInMS {
+		// the counter was created after the calculator started watching, e.g.
+		// by a restarted pod, so all of its value is new
+		res = pm
+	} else {
+		dc.firstObservationDropped.Add(1)
 	}
 
 	// Clean up the stale cache periodically
 	if curTimeInMS-dc.lastCleanUpTimeInMs >= CleanUpTimeThreshold {
 		dc.preDataPoints.CleanUp(time.Now())
 		dc.lastCleanUpTimeInMs = curTimeInMS
+		dc.saveState()
 	}
 
-	dc.preDataPoints.Set(metricKey, dataPoint{value: curVal, timeInMS: curTimeInMS})
+	dc.preDataPoints.Set(metricKey, dataPoint{value: curVal, timeInMS: curTimeInMS, startTimeInMS: pm.startTimeInMS})
 
 	return
 }
 
-func NewDeltaCalculator() *DeltaCalculator {
-	return &DeltaCalculator{preDataPoints: mapWithExpiry.NewMapWithExpiry(CacheTTL), lastCleanUpTimeInMs: 0}
+// isCounterReset returns whether the counter restarted since the previous
+// data point. The created timestamp changes on a reset even if the counter
+// has already counted past the previous value.
+func isCounterReset(pre dataPoint, pm *PrometheusMetric) bool {
+	if pre.startTimeInMS > 0 && pm.startTimeInMS > 0 && pm.startTimeInMS != pre.startTimeInMS {
+		return true
+	}
+	return pm.metricValue < pre.value
+}
+
+// FirstObservationDropped returns the number of samples dropped so far
+// because they were the first observation of their series.
+func (dc *DeltaCalculator) FirstObservationDropped() int64 {
+	return dc.firstObservationDropped.Load()
+}
+
+// Shutdown saves the previous data points for the next start.
+func (dc *DeltaCalculator) Shutdown() {
+	dc.saveState()
+}
+
+func (dc *DeltaCalculator) saveState() {
+	if dc.stateFile == "" {
+		return
+	}
+	if err := dc.save(); err != nil {
+		log.Printf("W! DeltaCalculator: failed to save the state to %s: %v", dc.stateFile, err)
+	}
+}
+
+// NewDeltaCalculator creates a delta calculator that restores the previous
+// data points from the state file, if set.
+func NewDeltaCalculator(stateFile string) *DeltaCalculator {
+	dc := &DeltaCalculator{
+		preDataPoints:       mapWithExpiry.NewMapWithExpiry(CacheTTL),
+		lastCleanUpTimeInMs: 0,
+		stateFile:           stateFile,
+		watchedSinceInMS:    time.Now().UnixMilli(),
+	}
+	if stateFile != "" {
+		if err := dc.restore(); err != nil {
+			log.Printf("W! DeltaCalculator: failed to restore the state from %s: %v", stateFile, err)
+		}
+	}
+	return dc
 }
@@ -0,0 +1,101 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCounter(value float64, timeInMS, startTimeInMS int64) *PrometheusMetric {
+	return &PrometheusMetric{
+		tags:          map[string]string{"job": "app"},
+		metricName:    "requests_total",
+		metricValue:   value,
+		metricType:    "counter",
+		timeInMS:      timeInMS,
+		startTimeInMS: startTimeInMS,
+	}
+}
+
+func TestDeltaCalculator(t *testing.T) {
+	dc := NewDeltaCalculator("")
+	now := time.Now().UnixMilli()
+
+	// no previous value
+	assert.Nil(t, dc.calculate(newCounter(10, now, 0)))
+	assert.EqualValues(t, 1, dc.FirstObservationDropped())
+
+	res := dc.calculate(newCounter(15, now+1000, 0))
+	require.NotNil(t, res)
+	assert.Equal(t, float64(5), res.metricValue)
+
+	// the counter went down, so it was reset
+	res = dc.calculate(newCounter(3, now+2000, 0))
+	require.NotNil(t, res)
+	assert.Equal(t, float64(3), res.metricValue)
+	assert.EqualValues(t, 1, dc.FirstObservationDropped())
+}
+
+func TestDeltaCalculatorCreatedTimestamp(t *testing.T) {
+	dc := NewDeltaCalculator("")
+	now := time.Now().UnixMilli()
+
+	// created before the calculator started, so it may have been reported
+	assert.Nil(t, dc.calculate(newCounter(100, now, now-time.Hour.Milliseconds())))
+	assert.EqualValues(t, 1, dc.FirstObservationDropped())
+
+	// created after the calculator started, so the whole value is new
+	pm := newCounter(7, now, now+500)
+	pm.metricName = "errors_total"
+	res := dc.calculate(pm)
+	require.NotNil(t, res)
+	assert.Equal(t, float64(7), res.metricValue)
+
+	// the counter restarted and counted past the previous value between
+	// two scrapes
+	res = dc.calculate(newCounter(120, now+60000, now+30000))
+	require.NotNil(t, res)
+	assert.Equal(t, float64(120), res.metricValue)
+
+	res = dc.calculate(newCounter(130, now+120000, now+30000))
+	require.NotNil(t, res)
+	assert.Equal(t, float64(10), res.metricValue)
+	assert.EqualValues(t, 1, dc.FirstObservationDropped())
+}
+
+func TestDeltaCalculatorState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state", "prometheus.json")
+	now := time.Now().UnixMilli()
+
+	dc := NewDeltaCalculator(stateFile)
+	assert.Nil(t, dc.calculate(newCounter(10, now, 0)))
+	stale := newCounter(1, now-time.Hour.Milliseconds(), 0)
+	stale.metricName = "stale_total"
+	assert.Nil(t, dc.calculate(stale))
+	dc.Shutdown()
+	_, err := os.Stat(stateFile)
+	require.NoError(t, err)
+
+	// the restarted calculator continues from the saved value
+	dc = NewDeltaCalculator(stateFile)
+	res := dc.calculate(newCounter(25, now+1000, 0))
+	require.NotNil(t, res)
+	assert.Equal(t, float64(15), res.metricValue)
+	// expired data points are not restored
+	stale.timeInMS = now + 1000
+	stale.metricValue = 2
+	assert.Nil(t, dc.calculate(stale))
+	assert.EqualValues(t, 1, dc.FirstObservationDropped())
+
+	// an unreadable state is ignored
+	require.NoError(t, os.WriteFile(stateFile, []byte(`{"version": 2}`), 0600))
+	dc = NewDeltaCalculator(stateFile)
+	assert.Nil(t, dc.calculate(newCounter(30, now+2000, 0)))
+}
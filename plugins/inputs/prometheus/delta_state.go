@@ -0,0 +1,102 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const deltaStateVersion = 1
+
+// deltaState is the saved previous data points of the delta calculator,
+// keyed by the unique metric key of the series.
+type deltaState struct {
+	Version   int                       `json:"version"`
+	SavedAtMS int64                     `json:"saved_at_ms"`
+	Series    map[string]dataPointState `json:"series"`
+}
+
+type dataPointState struct {
+	Value         float64 `json:"value"`
+	TimeInMS      int64   `json:"time_ms"`
+	StartTimeInMS int64   `json:"start_time_ms,omitempty"`
+}
+
+// save writes the state to a temporary file first, so that a crash while
+// writing does not corrupt the previous state.
+func (dc *DeltaCalculator) save() error {
+	state := deltaState{
+		Version:   deltaStateVersion,
+		SavedAtMS: time.Now().UnixMilli(),
+		Series:    map[string]dataPointState{},
+	}
+	dc.preDataPoints.Range(func(key string, content interface{}) bool {
+		if dp, ok := content.(dataPoint); ok {
+			state.Series[key] = dataPointState{Value: dp.value, TimeInMS: dp.timeInMS, StartTimeInMS: dp.startTimeInMS}
+		}
+		return true
+	})
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(dc.stateFile), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dc.stateFile), filepath.Base(dc.stateFile)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dc.stateFile)
+}
+
+// restore loads the data points that have not expired yet. A missing state
+// file is not an error, it is the first start of the calculator. The
+// calculator has seen every series since the state was saved, so counters
+// created after that are reported from zero. Deltas between the last save
+// and a crash may be reported twice.
+func (dc *DeltaCalculator) restore() error {
+	content, err := os.ReadFile(dc.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var state deltaState
+	if err = json.Unmarshal(content, &state); err != nil {
+		return err
+	}
+	if state.Version != deltaStateVersion {
+		return fmt.Errorf("unsupported state version %d", state.Version)
+	}
+	expiredBeforeMS := time.Now().Add(-CacheTTL).UnixMilli()
+	restored := 0
+	for key, dp := range state.Series {
+		if dp.TimeInMS < expiredBeforeMS {
+			continue
+		}
+		dc.preDataPoints.Set(key, dataPoint{value: dp.Value, timeInMS: dp.TimeInMS, startTimeInMS: dp.StartTimeInMS})
+		restored++
+	}
+	if state.SavedAtMS > 0 && state.SavedAtMS < dc.watchedSinceInMS {
+		dc.watchedSinceInMS = state.SavedAtMS
+	}
+	log.Printf("I! DeltaCalculator: restored %d of %d series from %s", restored, len(state.Series), dc.stateFile)
+	return nil
+}
@@ -36,6 +36,7 @@ func (mh *metricsHandler) start(shutDownChan chan interface{}, wg *sync.WaitGrou
 			log.Printf("D! receive metric batch with %v prometheus metrics\n", len(metricBatch))
 			mh.handle(metricBatch)
 		case <-shutDownChan:
+			mh.calculator.Shutdown()
 			wg.Done()
 			return
 		}
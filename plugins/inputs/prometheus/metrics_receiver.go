@@ -31,6 +31,9 @@ type PrometheusMetric struct {
 	metricValue             float64
 	metricType              string
 	timeInMS                int64 // Unix time in milli-seconds
+	// startTimeInMS is the created timestamp of a counter, e.g. from the
+	// _created series of OpenMetrics. It is 0 if the target does not expose it.
+	startTimeInMS int64
 }
 
 func (pm *PrometheusMetric) isValueValid() bool {
@@ -46,10 +49,19 @@ type metricsReceiver struct {
 type metricAppender struct {
 	receiver *metricsReceiver
 	batch    PrometheusMetricBatch
+	// createdTimestamps are the created timestamps of the series, keyed by
+	// the hash of their labels, until their sample is appended.
+	createdTimestamps map[uint64]int64
 }
 
-func (ma *metricAppender) AppendCTZeroSample(storage.SeriesRef, labels.Labels, int64, int64) (storage.SeriesRef, error) {
-	// TODO: implement this func
+// AppendCTZeroSample is called by the scraper with the created timestamp of a
+// series right before its sample. The timestamp is kept for the sample, so
+// that the delta calculator can tell when the counter was reset.
+func (ma *metricAppender) AppendCTZeroSample(_ storage.SeriesRef, ls labels.Labels, _ int64, ct int64) (storage.SeriesRef, error) {
+	if ma.createdTimestamps == nil {
+		ma.createdTimestamps = map[uint64]int64{}
+	}
+	ma.createdTimestamps[ls.Hash()] = ct
 	return 0, nil
 }
 
@@ -101,6 +113,13 @@ func (ma *metricAppender) Append(_ storage.SeriesRef, ls labels.Labels, t int64,
 		metricValue:             v,
 		timeInMS:                t,
 	}
+	if len(ma.createdTimestamps) > 0 {
+		hash := ls.Hash()
+		if ct, ok := ma.createdTimestamps[hash]; ok {
+			pm.startTimeInMS = ct
+			delete(ma.createdTimestamps, hash)
+		}
+	}
 
 	// Remove magic labels
 	delete(labelMap, savedScrapeNameLabel)
@@ -119,6 +138,7 @@ func (ma *metricAppender) Commit() error {
 func (ma *metricAppender) Rollback() error {
 	// wipe the batch
 	ma.batch = PrometheusMetricBatch{}
+	ma.createdTimestamps = nil
 	return nil
 }
 
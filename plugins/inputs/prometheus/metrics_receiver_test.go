@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/prometheus/common/promslog"
 	"github.com/prometheus/prometheus/config"
@@ -58,6 +59,71 @@ func Test_metricAppender_Add(t *testing.T) {
 	assert.Equal(t, expected, *mac.batch[0])
 }
 
+func Test_metricAppender_AppendCTZeroSample(t *testing.T) {
+	mr := metricsReceiver{}
+	ma := mr.Appender(nil)
+	ls := []labels.Label{
+		{Name: "__name__", Value: "requests_total"},
+		{Name: "tag_a", Value: "a"},
+	}
+	other := []labels.Label{
+		{Name: "__name__", Value: "errors_total"},
+		{Name: "tag_a", Value: "a"},
+	}
+
+	_, err := ma.AppendCTZeroSample(0, ls, 20, 5)
+	assert.NoError(t, err)
+	_, err = ma.Append(0, other, 20, 1)
+	assert.NoError(t, err)
+	_, err = ma.Append(0, ls, 20, 10)
+	assert.NoError(t, err)
+	// the created timestamp only applies to the next sample of the series
+	_, err = ma.Append(0, ls, 30, 12)
+	assert.NoError(t, err)
+
+	mac, _ := ma.(*metricAppender)
+	require.Len(t, mac.batch, 3)
+	assert.EqualValues(t, 0, mac.batch[0].startTimeInMS)
+	assert.EqualValues(t, 5, mac.batch[1].startTimeInMS)
+	assert.EqualValues(t, 0, mac.batch[2].startTimeInMS)
+}
+
+func Test_metricAppender_CreatedTimestampResetWithoutStateFile(t *testing.T) {
+	mbCh := make(chan PrometheusMetricBatch, 3)
+	mr := metricsReceiver{pmbCh: mbCh}
+	calculator := NewCalculator("")
+	now := time.Now().UnixMilli()
+	ls := []labels.Label{
+		{Name: "__name__", Value: "requests_total"},
+		{Name: "pod", Value: "app-1"},
+	}
+	scrape := func(ct int64, ts int64, v float64) PrometheusMetricBatch {
+		ma := mr.Appender(nil)
+		_, err := ma.AppendCTZeroSample(0, ls, ts, ct)
+		require.NoError(t, err)
+		_, err = ma.Append(0, ls, ts, v)
+		require.NoError(t, err)
+		require.NoError(t, ma.Commit())
+		pmb := <-mbCh
+		for _, pm := range pmb {
+			pm.metricType = "counter"
+		}
+		return calculator.Calculate(pmb)
+	}
+
+	// created before the agent started, so the first value is dropped
+	assert.Empty(t, scrape(now-time.Hour.Milliseconds(), now, 50))
+	got := scrape(now-time.Hour.Milliseconds(), now+60000, 60)
+	require.Len(t, got, 1)
+	assert.Equal(t, float64(10), got[0].metricValue)
+	// the pod restarted and counted past the previous value, which only the
+	// created timestamp shows
+	got = scrape(now+90000, now+120000, 70)
+	require.Len(t, got, 1)
+	assert.Equal(t, float64(70), got[0].metricValue)
+	assert.EqualValues(t, 1, calculator.deltaCalculator.FirstObservationDropped())
+}
+
 func Test_metricAppender_isValueStale(t *testing.T) {
 	nonStaleValue := PrometheusMetric{
 		metricValue: 10.0,
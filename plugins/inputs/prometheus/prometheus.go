@@ -14,6 +14,7 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth"
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/status"
 	"github.com/aws/amazon-cloudwatch-agent/internal/ecsservicediscovery"
 )
 
@@ -24,10 +25,13 @@ type Prometheus struct {
 	PrometheusConfigPath string                                      `toml:"prometheus_config_path"`
 	ClusterName          string                                      `toml:"cluster_name"`
 	ECSSDConfig          *ecsservicediscovery.ServiceDiscoveryConfig `toml:"ecs_service_discovery"`
+	DeltaStateFile       string                                      `toml:"delta_state_file"`
+	UseCreatedTimestamps bool                                        `toml:"use_created_timestamps"`
 	mbCh                 chan PrometheusMetricBatch
 	shutDownChan         chan interface{}
 	wg                   sync.WaitGroup
 	middleware           awsmiddleware.Middleware
+	unregisterCounter    func()
 }
 
 func (p *Prometheus) SampleConfig() string {
@@ -49,12 +53,18 @@ func (p *Prometheus) Start(accIn telegraf.Accumulator) error {
 	handler := &metricsHandler{
 		mbCh:        p.mbCh,
 		acc:         accIn,
-		calculator:  NewCalculator(),
+		calculator:  NewCalculator(p.DeltaStateFile),
 		filter:      NewMetricsFilter(),
 		clusterName: p.ClusterName,
 		mtHandler:   mth,
 	}
 
+	// Report the counter samples that could not be turned into a delta,
+	// since there was no previous value and no created timestamp. The status
+	// endpoint sums the counters of the prometheus inputs.
+	p.unregisterCounter = status.Get().RegisterCounter("prometheus/first_observation_dropped",
+		handler.calculator.deltaCalculator.FirstObservationDropped)
+
 	var configurer *awsmiddleware.Configurer
 	var ecssd *ecsservicediscovery.ServiceDiscovery
 	needEcssd := true
@@ -74,9 +84,11 @@ func (p *Prometheus) Start(accIn telegraf.Accumulator) error {
 	p.wg.Add(1)
 	go ecsservicediscovery.StartECSServiceDiscovery(ecssd, p.shutDownChan, &p.wg)
 
-	// Start scraping prometheus metrics from prometheus endpoints
+	// Start scraping prometheus metrics from prometheus endpoints. The created
+	// timestamps replace the _created series only if enabled, so that the
+	// series are still emitted by default.
 	p.wg.Add(1)
-	go Start(p.PrometheusConfigPath, receiver, p.shutDownChan, &p.wg, mth)
+	go Start(p.PrometheusConfigPath, receiver, p.shutDownChan, &p.wg, mth, p.UseCreatedTimestamps)
 
 	// Start filter our prometheus metrics, calculate delta value if its a Counter or Summary count sum
 	// and convert Prometheus metrics to Telegraf Metrics
@@ -89,6 +101,9 @@ func (p *Prometheus) Start(accIn telegraf.Accumulator) error {
 func (p *Prometheus) Stop() {
 	close(p.shutDownChan)
 	p.wg.Wait()
+	if p.unregisterCounter != nil {
+		p.unregisterCounter()
+	}
 }
 
 func init() {
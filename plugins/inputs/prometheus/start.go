@@ -79,7 +79,10 @@ func init() {
 	prometheus.MustRegister(v.NewCollector("prometheus"))
 }
 
-func Start(configFilePath string, receiver storage.Appendable, shutDownChan chan interface{}, wg *sync.WaitGroup, mth *metricsTypeHandler) {
+// Start runs the scrape loop until the shutDownChan is closed. If
+// createdTimestamps is set, the created timestamps of the counters are
+// passed to the receiver instead of being scraped as _created series.
+func Start(configFilePath string, receiver storage.Appendable, shutDownChan chan interface{}, wg *sync.WaitGroup, mth *metricsTypeHandler, createdTimestamps bool) {
 	logLevel := &promslog.AllowedLevel{}
 	logLevel.Set("info")
 
@@ -121,7 +124,10 @@ func Start(configFilePath string, receiver storage.Appendable, shutDownChan chan
 		)
 
 		scrapeManager, _ = scrape.NewManager(
-			&scrape.Options{},
+			// The created timestamps of the counters are passed to the metric
+			// appender, so that the delta calculator can detect resets. The
+			// OpenMetrics _created series are skipped by the parser then.
+			&scrape.Options{EnableCreatedTimestampZeroIngestion: createdTimestamps},
 			logger,
 			nil,
 			receiver,
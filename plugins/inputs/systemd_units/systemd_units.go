@@ -0,0 +1,272 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement     = "systemd_units"
+	defaultUnitType = "service"
+	defaultTimeout  = 5 * time.Second
+	notSet          = "[not set]"
+)
+
+// showProperties are the unit properties queried with systemctl show.
+var showProperties = []string{"Id", "LoadState", "ActiveState", "SubState", "NRestarts", "MemoryCurrent", "CPUUsageNSec", "TasksCurrent"}
+
+// Below are mappings of the systemd states as defined in
+// https://github.com/systemd/systemd/blob/main/src/basic/unit-def.c
+var loadStates = map[string]int{
+	"loaded":      0,
+	"stub":        1,
+	"not-found":   2,
+	"bad-setting": 3,
+	"error":       4,
+	"merged":      5,
+	"masked":      6,
+}
+
+var activeStates = map[string]int{
+	"active":       0,
+	"reloading":    1,
+	"inactive":     2,
+	"failed":       3,
+	"activating":   4,
+	"deactivating": 5,
+}
+
+// subStates only contains the service sub states since they are the ones
+// that change when a service crashes or restarts.
+var subStates = map[string]int{
+	"running":       0,
+	"dead":          1,
+	"start-pre":     2,
+	"start":         3,
+	"exited":        4,
+	"reload":        5,
+	"stop":          6,
+	"stop-watchdog": 7,
+	"stop-sigterm":  8,
+	"stop-sigkill":  9,
+	"stop-post":     10,
+	"final-sigterm": 11,
+	"failed":        12,
+	"auto-restart":  13,
+}
+
+// systemctl runs systemctl with the arguments and returns its standard output.
+type systemctl func(timeout time.Duration, args ...string) ([]byte, error)
+
+// SystemdUnits gathers the state, restart count and resource accounting of
+// the systemd units matching the unit names or pattern.
+type SystemdUnits struct {
+	Units    []string        `toml:"units"`
+	Pattern  string          `toml:"pattern"`
+	UnitType string          `toml:"unit_type"`
+	Timeout  config.Duration `toml:"timeout"`
+	Log      telegraf.Logger `toml:"-"`
+
+	filter    *regexp.Regexp
+	systemctl systemctl
+}
+
+// Description returns the description of the SystemdUnits plugin
+func (*SystemdUnits) Description() string {
+	return "Gathers the state, restarts and resource accounting of systemd units"
+}
+
+func (*SystemdUnits) SampleConfig() string {
+	return sampleConfig
+}
+
+func (s *SystemdUnits) Init() error {
+	if len(s.Units) == 0 && s.Pattern == "" {
+		return errors.New("either units or pattern must be set")
+	}
+	if s.UnitType == "" {
+		s.UnitType = defaultUnitType
+	}
+	if s.Pattern != "" {
+		filter, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.filter = filter
+	}
+	for i, unit := range s.Units {
+		s.Units[i] = s.withUnitType(unit)
+	}
+	return nil
+}
+
+// Gather implements the telegraf interface
+func (s *SystemdUnits) Gather(acc telegraf.Accumulator) error {
+	units, err := s.matchingUnits()
+	if err != nil {
+		return err
+	}
+	if len(units) == 0 {
+		return nil
+	}
+	args := []string{"show", "--no-pager", "--property=" + strings.Join(showProperties, ",")}
+	args = append(args, "--")
+	args = append(args, units...)
+	out, err := s.systemctl(time.Duration(s.Timeout), args...)
+	if err != nil {
+		return fmt.Errorf("unable to show units: %w", err)
+	}
+	for _, properties := range parseShow(out) {
+		unit := properties["Id"]
+		if unit == "" {
+			continue
+		}
+		acc.AddFields(measurement, unitFields(properties), map[string]string{"unit": unit})
+	}
+	return nil
+}
+
+// matchingUnits returns the sorted names of the configured units along with
+// the loaded units of the unit type matching the pattern.
+func (s *SystemdUnits) matchingUnits() ([]string, error) {
+	set := make(map[string]struct{}, len(s.Units))
+	for _, unit := range s.Units {
+		set[unit] = struct{}{}
+	}
+	if s.filter != nil {
+		out, err := s.systemctl(time.Duration(s.Timeout), "list-units", "--all", "--plain", "--no-legend", "--no-pager", "--type="+s.UnitType)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list units: %w", err)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(out))
+		for scanner.Scan() {
+			columns := strings.Fields(scanner.Text())
+			if len(columns) == 0 {
+				continue
+			}
+			if unit := columns[0]; s.filter.MatchString(unit) {
+				set[unit] = struct{}{}
+			}
+		}
+	}
+	units := make([]string, 0, len(set))
+	for unit := range set {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	return units, nil
+}
+
+func (s *SystemdUnits) withUnitType(unit string) string {
+	if strings.Contains(unit, ".") {
+		return unit
+	}
+	return unit + "." + s.UnitType
+}
+
+// parseShow splits the output of systemctl show into the properties of each
+// unit. The units are separated by empty lines.
+func parseShow(out []byte) []map[string]string {
+	var result []map[string]string
+	properties := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			if len(properties) > 0 {
+				result = append(result, properties)
+				properties = map[string]string{}
+			}
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			properties[key] = value
+		}
+	}
+	if len(properties) > 0 {
+		result = append(result, properties)
+	}
+	return result
+}
+
+func unitFields(properties map[string]string) map[string]interface{} {
+	fields := map[string]interface{}{}
+	active := 0
+	if properties["ActiveState"] == "active" {
+		active = 1
+	}
+	fields["active"] = active
+	if code, ok := loadStates[properties["LoadState"]]; ok {
+		fields["load_state"] = code
+	}
+	if code, ok := activeStates[properties["ActiveState"]]; ok {
+		fields["active_state"] = code
+	}
+	if code, ok := subStates[properties["SubState"]]; ok {
+		fields["sub_state"] = code
+	}
+	for property, field := range map[string]string{
+		"NRestarts":     "restarts",
+		"MemoryCurrent": "memory_current",
+		"CPUUsageNSec":  "cpu_usage_nsec",
+		"TasksCurrent":  "tasks_current",
+	} {
+		if value, ok := parseCounter(properties[property]); ok {
+			fields[field] = value
+		}
+	}
+	return fields
+}
+
+// parseCounter parses the value of a numeric property. systemd reports
+// unavailable accounting as "[not set]" or as the maximum uint64.
+func parseCounter(value string) (uint64, bool) {
+	if value == "" || value == notSet {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || v == math.MaxUint64 {
+		return 0, false
+	}
+	return v, true
+}
+
+func runSystemctl(timeout time.Duration, args ...string) ([]byte, error) {
+	path, err := exec.LookPath("systemctl")
+	if err != nil {
+		return nil, err
+	}
+	return internal.StdOutputTimeout(exec.Command(path, args...), timeout)
+}
+
+func init() {
+	inputs.Add(measurement, func() telegraf.Input {
+		return &SystemdUnits{
+			UnitType:  defaultUnitType,
+			Timeout:   config.Duration(defaultTimeout),
+			systemctl: runSystemctl,
+		}
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSystemctl struct {
+	t        *testing.T
+	calls    [][]string
+	showErr  error
+	listFile string
+}
+
+func (f *fakeSystemctl) run(_ time.Duration, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	switch args[0] {
+	case "list-units":
+		return os.ReadFile(filepath.Join("testdata", f.listFile))
+	case "show":
+		if f.showErr != nil {
+			return nil, f.showErr
+		}
+		return os.ReadFile(filepath.Join("testdata", "show.txt"))
+	}
+	f.t.Fatalf("unexpected systemctl command: %v", args)
+	return nil, nil
+}
+
+func TestInit(t *testing.T) {
+	plugin := &SystemdUnits{Log: &testutil.Logger{}}
+	require.Error(t, plugin.Init())
+
+	plugin = &SystemdUnits{Pattern: "(", Log: &testutil.Logger{}}
+	require.Error(t, plugin.Init())
+
+	plugin = &SystemdUnits{Units: []string{"sshd", "app.socket"}, Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, []string{"sshd.service", "app.socket"}, plugin.Units)
+}
+
+func TestGather(t *testing.T) {
+	fake := &fakeSystemctl{t: t, listFile: "list-units.txt"}
+	plugin := &SystemdUnits{
+		Units:     []string{"sshd", "missing.service"},
+		Pattern:   "^app-",
+		Log:       &testutil.Logger{},
+		systemctl: fake.run,
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+
+	require.Len(t, fake.calls, 2)
+	assert.Equal(t, []string{"list-units", "--all", "--plain", "--no-legend", "--no-pager", "--type=service"}, fake.calls[0])
+	assert.Equal(t, []string{
+		"show", "--no-pager", "--property=Id,LoadState,ActiveState,SubState,NRestarts,MemoryCurrent,CPUUsageNSec,TasksCurrent", "--",
+		"app-api.service", "app-worker.service", "missing.service", "sshd.service",
+	}, fake.calls[1])
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(measurement, map[string]string{"unit": "app-api.service"}, map[string]interface{}{
+			"active":         1,
+			"load_state":     0,
+			"active_state":   0,
+			"sub_state":      0,
+			"restarts":       uint64(2),
+			"memory_current": uint64(52428800),
+			"cpu_usage_nsec": uint64(1234567890),
+			"tasks_current":  uint64(12),
+		}, time.Unix(0, 0)),
+		testutil.MustMetric(measurement, map[string]string{"unit": "app-worker.service"}, map[string]interface{}{
+			"active":       0,
+			"load_state":   0,
+			"active_state": 3,
+			"sub_state":    12,
+			"restarts":     uint64(5),
+		}, time.Unix(0, 0)),
+		testutil.MustMetric(measurement, map[string]string{"unit": "sshd.service"}, map[string]interface{}{
+			"active":         1,
+			"load_state":     0,
+			"active_state":   0,
+			"sub_state":      0,
+			"restarts":       uint64(0),
+			"memory_current": uint64(4194304),
+			"cpu_usage_nsec": uint64(98765432),
+			"tasks_current":  uint64(1),
+		}, time.Unix(0, 0)),
+		testutil.MustMetric(measurement, map[string]string{"unit": "missing.service"}, map[string]interface{}{
+			"active":       0,
+			"load_state":   2,
+			"active_state": 2,
+			"sub_state":    1,
+			"restarts":     uint64(0),
+		}, time.Unix(0, 0)),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestGatherWithoutMatchingUnits(t *testing.T) {
+	fake := &fakeSystemctl{t: t, listFile: "list-units.txt"}
+	plugin := &SystemdUnits{
+		Pattern:   "^nginx",
+		Log:       &testutil.Logger{},
+		systemctl: fake.run,
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Len(t, fake.calls, 1)
+	assert.Empty(t, acc.GetTelegrafMetrics())
+}
+
+func TestGatherError(t *testing.T) {
+	fake := &fakeSystemctl{t: t, showErr: errors.New("exit status 1")}
+	plugin := &SystemdUnits{
+		Units:     []string{"sshd"},
+		Log:       &testutil.Logger{},
+		systemctl: fake.run,
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	assert.ErrorContains(t, plugin.Gather(&acc), "exit status 1")
+	assert.Len(t, fake.calls, 1)
+}
@@ -0,0 +1,144 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/textparse"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement = "textfile"
+	filePattern = "*.prom"
+	fileTag     = "file"
+)
+
+// Textfile gathers the metrics of the Prometheus text exposition files in a
+// directory, so that cron jobs and scripts can publish metrics by writing a
+// file. Every sample is reported as a field named after the metric, with
+// the labels as tags. The files are described by their modification time, so
+// that files which stopped being updated can be alarmed on.
+type Textfile struct {
+	Directory string          `toml:"directory"`
+	Log       telegraf.Logger `toml:"-"`
+
+	now func() time.Time
+}
+
+// Description returns the description of the Textfile plugin
+func (*Textfile) Description() string {
+	return "Gathers metrics from Prometheus text exposition files in a directory"
+}
+
+func (*Textfile) SampleConfig() string {
+	return sampleConfig
+}
+
+func (t *Textfile) Init() error {
+	if t.Directory == "" {
+		return errors.New("directory must be set")
+	}
+	if t.now == nil {
+		t.now = time.Now
+	}
+	return nil
+}
+
+// Gather implements the telegraf interface
+func (t *Textfile) Gather(acc telegraf.Accumulator) error {
+	files, err := filepath.Glob(filepath.Join(t.Directory, filePattern))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	now := t.now()
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		scrapeError := 0
+		if err = t.gatherFile(file, acc); err != nil {
+			acc.AddError(fmt.Errorf("unable to parse %s: %w", file, err))
+			scrapeError = 1
+		}
+		mtime := info.ModTime()
+		acc.AddGauge(measurement, map[string]interface{}{
+			"mtime_seconds": float64(mtime.UnixNano()) / float64(time.Second),
+			"age_seconds":   now.Sub(mtime).Seconds(),
+			"scrape_error":  scrapeError,
+		}, map[string]string{fileTag: filepath.Base(file)})
+	}
+	return nil
+}
+
+// gatherFile adds the samples of a file. The file is parsed completely before
+// any sample is added, so that a file that is being written or is invalid
+// does not report partial results. Timestamps in the file are ignored.
+func (t *Textfile) gatherFile(file string, acc telegraf.Accumulator) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	type sample struct {
+		name  string
+		tags  map[string]string
+		value float64
+	}
+	var samples []sample
+	parser := textparse.NewPromParser(data, labels.NewSymbolTable())
+	for {
+		entry, err := parser.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if entry != textparse.EntrySeries {
+			continue
+		}
+		_, _, value := parser.Series()
+		// CloudWatch does not accept NaN and infinite values.
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		var lset labels.Labels
+		parser.Metric(&lset)
+		s := sample{tags: map[string]string{}, value: value}
+		lset.Range(func(l labels.Label) {
+			if l.Name == labels.MetricName {
+				s.name = l.Value
+				return
+			}
+			s.tags[l.Name] = l.Value
+		})
+		samples = append(samples, s)
+	}
+	for _, s := range samples {
+		acc.AddGauge(measurement, map[string]interface{}{s.name: s.value}, s.tags)
+	}
+	return nil
+}
+
+func init() {
+	inputs.Add(measurement, func() telegraf.Input {
+		return &Textfile{}
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func copyTestdata(t *testing.T, mtime time.Time, names ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		require.NoError(t, err)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0600))
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+	return dir
+}
+
+func TestInit(t *testing.T) {
+	plugin := &Textfile{Log: &testutil.Logger{}}
+	require.Error(t, plugin.Init())
+
+	plugin = &Textfile{Directory: "testdata", Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+}
+
+func TestGather(t *testing.T) {
+	mtime := time.Unix(1700000000, 0)
+	now := mtime.Add(90 * time.Second)
+	plugin := &Textfile{
+		Directory: copyTestdata(t, mtime, "backup.prom", "invalid.prom", "notes.txt"),
+		Log:       &testutil.Logger{},
+		now:       func() time.Time { return now },
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Len(t, acc.Errors, 1)
+	assert.ErrorContains(t, acc.Errors[0], "invalid.prom")
+
+	expected := []telegraf.Metric{
+		testutil.MustMetric(measurement, map[string]string{"volume": "data"}, map[string]interface{}{
+			"backup_last_success_timestamp_seconds": 1.7e+09,
+		}, time.Unix(0, 0), telegraf.Gauge),
+		testutil.MustMetric(measurement, map[string]string{"volume": "logs"}, map[string]interface{}{
+			"backup_last_success_timestamp_seconds": 1.69e+09,
+		}, time.Unix(0, 0), telegraf.Gauge),
+		testutil.MustMetric(measurement, map[string]string{"volume": "data"}, map[string]interface{}{
+			"backup_bytes_total": float64(1024),
+		}, time.Unix(0, 0), telegraf.Gauge),
+		testutil.MustMetric(measurement, map[string]string{"file": "backup.prom"}, map[string]interface{}{
+			"mtime_seconds": float64(1700000000),
+			"age_seconds":   float64(90),
+			"scrape_error":  0,
+		}, time.Unix(0, 0), telegraf.Gauge),
+		testutil.MustMetric(measurement, map[string]string{"file": "invalid.prom"}, map[string]interface{}{
+			"mtime_seconds": float64(1700000000),
+			"age_seconds":   float64(90),
+			"scrape_error":  1,
+		}, time.Unix(0, 0), telegraf.Gauge),
+	}
+	testutil.RequireMetricsEqual(t, expected, acc.GetTelegrafMetrics(), testutil.IgnoreTime())
+}
+
+func TestGatherEmptyDirectory(t *testing.T) {
+	plugin := &Textfile{Directory: filepath.Join(t.TempDir(), "missing"), Log: &testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Empty(t, acc.Errors)
+	assert.Empty(t, acc.GetTelegrafMetrics())
+}
@@ -26,12 +26,14 @@ import (
 	"golang.org/x/exp/maps"
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/status"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/useragent"
 	"github.com/aws/amazon-cloudwatch-agent/handlers"
 	"github.com/aws/amazon-cloudwatch-agent/internal/publisher"
 	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/exph"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch/cloudwatchiface"
 )
@@ -81,6 +83,7 @@ type CloudWatch struct {
 	aggregatorShutdownChan chan struct{}
 	aggregatorWaitGroup    sync.WaitGroup
 	lastRequestBytes       int
+	unregisterQueues       []func()
 }
 
 // Compile time interface check.
@@ -138,6 +141,10 @@ func (c *CloudWatch) startRoutines() {
 	c.aggregator = NewAggregator(c.metricChan, c.aggregatorShutdownChan, &c.aggregatorWaitGroup)
 	perRequestConstSize := overallConstPerRequestSize + len(c.config.Namespace) + namespaceOverheads
 	c.metricDatumBatch = newMetricDatumBatch(c.config.MaxDatumsPerCall, perRequestConstSize)
+	c.unregisterQueues = []func(){
+		status.Get().RegisterQueue("cloudwatch/metrics", func() int { return len(c.metricChan) }),
+		status.Get().RegisterQueue("cloudwatch/batches", func() int { return len(c.datumBatchChan) }),
+	}
 	go c.pushMetricDatum()
 	go c.publish()
 }
@@ -156,6 +163,9 @@ func (c *CloudWatch) Shutdown(ctx context.Context) error {
 		log.Printf("D! CloudWatch Close, metricChan length = %v, datumBatchChan length = %v.", metricChanLen, datumBatchChanLen)
 	}
 	close(c.shutdownChan)
+	for _, unregister := range c.unregisterQueues {
+		unregister()
+	}
 	c.publisher.Close()
 	c.retryer.Stop()
 	log.Println("D! Stopped the CloudWatch output plugin")
@@ -488,6 +498,9 @@ func (c *CloudWatch) buildMetricDatumDist(metric *aggregationDatum, dimensionsLi
 	if metric.distribution.Unit() != "" {
 		metric.SetUnit(metric.distribution.Unit())
 	}
+	if exp, ok := metric.distribution.(*exph.ExpHistogramDistribution); ok {
+		exp.Compress(c.exponentialHistogramCompression())
+	}
 	distList := metric.distribution.Resize(c.config.MaxValuesPerDatum)
 
 	for _, dist := range distList {
@@ -544,6 +557,19 @@ func (c *CloudWatch) buildMetricDatumDist(metric *aggregationDatum, dimensionsLi
 	return datums
 }
 
+// exponentialHistogramCompression returns the configured compression with
+// the max buckets capped at the values that fit in a datum.
+func (c *CloudWatch) exponentialHistogramCompression() exph.Compression {
+	var compression exph.Compression
+	if c.config.ExponentialHistogramCompression != nil {
+		compression = *c.config.ExponentialHistogramCompression
+	}
+	if compression.MaxBuckets <= 0 || compression.MaxBuckets > c.config.MaxValuesPerDatum {
+		compression.MaxBuckets = c.config.MaxValuesPerDatum
+	}
+	return compression
+}
+
 func minAndMax(values []float64) (float64, float64) {
 	// assumes values has at least one value
 	myMin, myMax := values[0], values[0] // avoid conflict with built-in min, max functions
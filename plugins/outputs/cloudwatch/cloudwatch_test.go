@@ -25,11 +25,13 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/useragent"
 	"github.com/aws/amazon-cloudwatch-agent/internal/publisher"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/exph"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch/cloudwatchiface"
 )
@@ -225,6 +227,57 @@ func TestBuildMetricDatumDropUnsupported(t *testing.T) {
 	}
 }
 
+func TestBuildMetricDatumCompressesExponentialHistogram(t *testing.T) {
+	svc := new(mockCloudWatchClient)
+	cw := newCloudWatchClient(svc, time.Second)
+	newDatum := func() *aggregationDatum {
+		dp := pmetric.NewExponentialHistogramDataPoint()
+		dp.SetScale(5)
+		dp.Positive().SetOffset(-100)
+		counts := make([]uint64, 400)
+		for i := range counts {
+			counts[i] = 1
+		}
+		dp.Positive().BucketCounts().FromRaw(counts)
+		dp.SetCount(400)
+		dp.SetSum(1000)
+		dp.SetMin(0.1)
+		dp.SetMax(5000)
+		dist := exph.NewExponentialDistribution()
+		dist.ConvertFromOtel(dp, "Milliseconds")
+		return &aggregationDatum{
+			MetricDatum: cloudwatch.MetricDatum{
+				MetricName: aws.String("latency"),
+			},
+			distribution: dist,
+		}
+	}
+
+	// the buckets are merged to fit in one datum
+	_, datums := cw.BuildMetricDatum(newDatum())
+	require.Len(t, datums, 1)
+	assert.LessOrEqual(t, len(datums[0].Values), defaultMaxValuesPerDatum)
+	assert.Len(t, datums[0].Counts, len(datums[0].Values))
+	var total float64
+	for _, count := range datums[0].Counts {
+		total += *count
+	}
+	assert.Equal(t, float64(400), total)
+
+	cw.config.ExponentialHistogramCompression = &exph.Compression{MaxRelativeError: 0.1}
+	datum := newDatum()
+	_, datums = cw.BuildMetricDatum(datum)
+	require.Len(t, datums, 1)
+	assert.LessOrEqual(t, datum.distribution.(*exph.ExpHistogramDistribution).RelativeError(), 0.1)
+	assert.Len(t, datums[0].Values, datum.distribution.Size())
+
+	cw.config.ExponentialHistogramCompression = &exph.Compression{MaxRelativeError: 0.01, MaxBuckets: 20}
+	_, datums = cw.BuildMetricDatum(newDatum())
+	require.Len(t, datums, 1)
+	assert.LessOrEqual(t, len(datums[0].Values), 20)
+	assert.NoError(t, cw.Shutdown(context.Background()))
+}
+
 func TestGetUniqueRollupList(t *testing.T) {
 	testCases := map[string]struct {
 		input [][]string
@@ -9,6 +9,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/resourcetotelemetry"
 	"go.opentelemetry.io/collector/component"
+
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/exph"
 )
 
 // Config represent a configuration for the CloudWatch metrics exporter.
@@ -27,6 +29,11 @@ type Config struct {
 	RollupDimensions         [][]string      `mapstructure:"rollup_dimensions,omitempty"`
 	DropOriginalConfigs      map[string]bool `mapstructure:"drop_original_metrics,omitempty"`
 	Namespace                string          `mapstructure:"namespace"`
+	// ExponentialHistogramCompression merges the adjacent buckets of
+	// exponential histograms to a target relative error. The buckets are
+	// always merged to fit in MaxValuesPerDatum, so each histogram is sent
+	// as a single datum.
+	ExponentialHistogramCompression *exph.Compression `mapstructure:"exponential_histogram_compression,omitempty"`
 
 	// ResourceToTelemetrySettings is the option for converting resource
 	// attributes to telemetry attributes.
@@ -50,5 +57,13 @@ func (c *Config) Validate() error {
 	if c.ForceFlushInterval < time.Millisecond {
 		return errors.New("'force_flush_interval' must be at least 1 millisecond")
 	}
+	if c.ExponentialHistogramCompression != nil {
+		if c.ExponentialHistogramCompression.MaxRelativeError < 0 {
+			return errors.New("'exponential_histogram_compression::max_relative_error' must not be negative")
+		}
+		if c.ExponentialHistogramCompression.MaxBuckets < 0 {
+			return errors.New("'exponential_histogram_compression::max_buckets' must not be negative")
+		}
+	}
 	return nil
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/otelcol/otelcoltest"
+
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/exph"
 )
 
 // TestConfig will verify various config files can be loaded.
@@ -64,6 +66,7 @@ func TestConfig(t *testing.T) {
 	assert.Equal(t, "val9", c2.Token)
 	assert.Equal(t, 7, c2.MaxDatumsPerCall)
 	assert.Equal(t, 9, c2.MaxValuesPerDatum)
+	assert.Equal(t, &exph.Compression{MaxRelativeError: 0.05, MaxBuckets: 100}, c2.ExponentialHistogramCompression)
 	assert.Equal(t, 60*time.Second, c2.ForceFlushInterval)
 	// todo: verify MetricDecorations
 }
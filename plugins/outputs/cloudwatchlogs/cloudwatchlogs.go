@@ -186,10 +186,13 @@ func (c *CloudWatchLogs) createClient(retryer aws.RequestRetryer) *cloudwatchlog
 		},
 	)
 	client.Handlers.Build.PushBackNamed(handlers.NewRequestCompressionHandler([]string{"PutLogEvents"}))
-	if c.middleware != nil {
-		c.configurerOnce.Do(func() {
+	// The OTel exporter sets the configurer from the middleware extension of the collector.
+	c.configurerOnce.Do(func() {
+		if c.middleware != nil && c.configurer == nil {
 			c.configurer = awsmiddleware.NewConfigurer(c.middleware.Handlers())
-		})
+		}
+	})
+	if c.configurer != nil {
 		if err := c.configurer.Configure(awsmiddleware.SDKv1(&client.Handlers)); err != nil {
 			c.Log.Errorf("Unable to configure middleware on cloudwatch logs client: %v", err)
 		} else {
@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/otlp"
+)
+
+// Config represent a configuration for the CloudWatch Logs exporter of
+// OpenTelemetry log records.
+type Config struct {
+	Region                   string `mapstructure:"region"`
+	EndpointOverride         string `mapstructure:"endpoint_override,omitempty"`
+	AccessKey                string `mapstructure:"access_key,omitempty"`
+	SecretKey                string `mapstructure:"secret_key,omitempty"`
+	RoleARN                  string `mapstructure:"role_arn,omitempty"`
+	Profile                  string `mapstructure:"profile,omitempty"`
+	SharedCredentialFilename string `mapstructure:"shared_credential_file,omitempty"`
+	Token                    string `mapstructure:"token,omitempty"`
+
+	// LogGroupName and LogStreamName are templates expanded with the resource
+	// attributes of the log records, e.g. "/aws/otlp/{service.name}". The
+	// aws.log.group.names and aws.log.stream.names resource attributes take
+	// precedence over them.
+	LogGroupName    string `mapstructure:"log_group_name"`
+	LogStreamName   string `mapstructure:"log_stream_name"`
+	RetentionInDays int    `mapstructure:"retention_in_days,omitempty"`
+	LogGroupClass   string `mapstructure:"log_group_class,omitempty"`
+
+	// BodyFormat is either "raw" or "json".
+	BodyFormat string `mapstructure:"body_format"`
+	// SeverityFormat is one of "none", "text" or "number".
+	SeverityFormat string `mapstructure:"severity_format"`
+
+	ForceFlushInterval time.Duration `mapstructure:"force_flush_interval"`
+	Concurrency        int           `mapstructure:"concurrency,omitempty"`
+
+	// MiddlewareID is an ID for an extension that can be used to configure the AWS client.
+	MiddlewareID *component.ID `mapstructure:"middleware,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.Region == "" {
+		return errors.New("'region' must be set")
+	}
+	if c.LogGroupName == "" {
+		return errors.New("'log_group_name' must be set")
+	}
+	if c.LogStreamName == "" {
+		return errors.New("'log_stream_name' must be set")
+	}
+	if !slices.Contains(otlp.BodyFormats, c.BodyFormat) {
+		return fmt.Errorf("'body_format' must be one of %v", otlp.BodyFormats)
+	}
+	if !slices.Contains(otlp.SeverityFormats, c.SeverityFormat) {
+		return fmt.Errorf("'severity_format' must be one of %v", otlp.SeverityFormats)
+	}
+	if c.ForceFlushInterval < time.Millisecond {
+		return errors.New("'force_flush_interval' must be at least 1 millisecond")
+	}
+	return nil
+}
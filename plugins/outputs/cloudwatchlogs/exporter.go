@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"context"
+	"sync"
+
+	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
+	"github.com/influxdata/telegraf/models"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/otlp"
+)
+
+// logsExporter publishes OpenTelemetry log records through the same pushers
+// used for the log files collected by the agent.
+type logsExporter struct {
+	config *Config
+	logger *zap.Logger
+	name   string
+
+	cwl    *CloudWatchLogs
+	router *otlp.Router
+}
+
+func (e *logsExporter) Start(_ context.Context, host component.Host) error {
+	e.cwl = &CloudWatchLogs{
+		Region:             e.config.Region,
+		EndpointOverride:   e.config.EndpointOverride,
+		AccessKey:          e.config.AccessKey,
+		SecretKey:          e.config.SecretKey,
+		RoleARN:            e.config.RoleARN,
+		Profile:            e.config.Profile,
+		Filename:           e.config.SharedCredentialFilename,
+		Token:              e.config.Token,
+		Concurrency:        e.config.Concurrency,
+		ForceFlushInterval: internal.Duration{Duration: e.config.ForceFlushInterval},
+		Log:                models.NewLogger("outputs", "cloudwatchlogs", e.name),
+		cwDests:            sync.Map{},
+	}
+	if e.config.MiddlewareID != nil {
+		configurer, err := awsmiddleware.GetConfigurer(host.GetExtensions(), *e.config.MiddlewareID)
+		if err != nil {
+			e.logger.Warn("Unable to find middleware extension", zap.Error(err))
+		} else {
+			e.cwl.configurer = configurer
+		}
+	}
+	e.router = otlp.NewRouter(otlp.Config{
+		LogGroupName:    e.config.LogGroupName,
+		LogStreamName:   e.config.LogStreamName,
+		RetentionInDays: e.config.RetentionInDays,
+		LogGroupClass:   e.config.LogGroupClass,
+		BodyFormat:      e.config.BodyFormat,
+		SeverityFormat:  e.config.SeverityFormat,
+	}, e.cwl)
+	return nil
+}
+
+func (e *logsExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return e.router.ConsumeLogs(ctx, ld)
+}
+
+func (e *logsExporter) Shutdown(context.Context) error {
+	if e.router != nil {
+		e.router.Stop()
+	}
+	if e.cwl != nil {
+		return e.cwl.Close()
+	}
+	return nil
+}
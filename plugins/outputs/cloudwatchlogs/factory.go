@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/otlp"
+)
+
+const (
+	stability = component.StabilityLevelAlpha
+
+	defaultLogGroupName = "/aws/otlp/{service.name}"
+)
+
+var (
+	// TypeStr is distinct from the awscloudwatchlogs exporter, which sends
+	// every log record to a single log group and stream.
+	TypeStr, _ = component.NewType("awscloudwatchlogs_otlp")
+)
+
+// NewFactory creates a log exporter that routes each OpenTelemetry log record
+// to the log group and stream of its resource.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		TypeStr,
+		createDefaultConfig,
+		exporter.WithLogs(createLogsExporter, stability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		LogGroupName:       defaultLogGroupName,
+		LogStreamName:      "{service.instance.id}",
+		BodyFormat:         otlp.BodyFormatRaw,
+		SeverityFormat:     otlp.SeverityFormatText,
+		ForceFlushInterval: defaultFlushTimeout,
+	}
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	settings exporter.Settings,
+	config component.Config,
+) (exporter.Logs, error) {
+	exp := &logsExporter{
+		config: config.(*Config),
+		logger: settings.Logger,
+		name:   settings.ID.String(),
+	}
+	return exporterhelper.NewLogs(
+		ctx,
+		settings,
+		config,
+		exp.ConsumeLogs,
+		exporterhelper.WithStart(exp.Start),
+		exporterhelper.WithShutdown(exp.Shutdown),
+	)
+}
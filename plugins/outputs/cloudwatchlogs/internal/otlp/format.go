@@ -0,0 +1,104 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlp
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	// BodyFormatRaw sends the body of the log record as the message.
+	BodyFormatRaw = "raw"
+	// BodyFormatJSON sends the log record as a JSON object.
+	BodyFormatJSON = "json"
+
+	SeverityFormatNone   = "none"
+	SeverityFormatText   = "text"
+	SeverityFormatNumber = "number"
+
+	// missingAttributeValue replaces the template placeholders of resource
+	// attributes that are not set.
+	missingAttributeValue = "unknown"
+)
+
+var (
+	BodyFormats     = []string{BodyFormatRaw, BodyFormatJSON}
+	SeverityFormats = []string{SeverityFormatNone, SeverityFormatText, SeverityFormatNumber}
+
+	placeholderRegexp = regexp.MustCompile(`\{([^{}]+)\}`)
+)
+
+// ExpandTemplate replaces the {attribute} placeholders in the template with
+// the values of the resource attributes.
+func ExpandTemplate(template string, attrs pcommon.Map) string {
+	return placeholderRegexp.ReplaceAllStringFunc(template, func(placeholder string) string {
+		if value, ok := attrs.Get(placeholder[1 : len(placeholder)-1]); ok && value.AsString() != "" {
+			return value.AsString()
+		}
+		return missingAttributeValue
+	})
+}
+
+// formatter builds the message of a CloudWatch Logs event from a log record.
+type formatter struct {
+	bodyFormat     string
+	severityFormat string
+}
+
+func (f formatter) format(record plog.LogRecord) (string, error) {
+	if f.bodyFormat == BodyFormatJSON {
+		return f.formatJSON(record)
+	}
+	body := record.Body().AsString()
+	if severity := f.severity(record); severity != nil {
+		return fmt.Sprintf("[%v] %s", severity, body), nil
+	}
+	return body, nil
+}
+
+func (f formatter) formatJSON(record plog.LogRecord) (string, error) {
+	entry := map[string]any{"body": record.Body().AsRaw()}
+	if severity := f.severity(record); severity != nil {
+		entry["severity"] = severity
+	}
+	if record.Attributes().Len() > 0 {
+		entry["attributes"] = record.Attributes().AsRaw()
+	}
+	if traceID := record.TraceID(); !traceID.IsEmpty() {
+		entry["trace_id"] = traceID.String()
+	}
+	if spanID := record.SpanID(); !spanID.IsEmpty() {
+		entry["span_id"] = spanID.String()
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// severity returns the severity of the record in the configured format or
+// nil if the record has none.
+func (f formatter) severity(record plog.LogRecord) any {
+	switch f.severityFormat {
+	case SeverityFormatText:
+		if text := record.SeverityText(); text != "" {
+			return text
+		}
+		if record.SeverityNumber() != plog.SeverityNumberUnspecified {
+			return strings.ToUpper(record.SeverityNumber().String())
+		}
+	case SeverityFormatNumber:
+		if record.SeverityNumber() != plog.SeverityNumberUnspecified {
+			return int32(record.SeverityNumber())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("service.name", "checkout")
+	attrs.PutStr("deployment.environment", "")
+	attrs.PutInt("service.instance.id", 7)
+
+	assert.Equal(t, "/aws/otlp/checkout", ExpandTemplate("/aws/otlp/{service.name}", attrs))
+	assert.Equal(t, "checkout-7", ExpandTemplate("{service.name}-{service.instance.id}", attrs))
+	assert.Equal(t, "unknown/unknown", ExpandTemplate("{deployment.environment}/{host.name}", attrs))
+	assert.Equal(t, "i-123456789", ExpandTemplate("i-123456789", attrs))
+}
+
+func newRecord() plog.LogRecord {
+	record := plog.NewLogRecord()
+	record.Body().SetStr("payment accepted")
+	record.SetSeverityNumber(plog.SeverityNumberWarn)
+	return record
+}
+
+func TestFormatRaw(t *testing.T) {
+	record := newRecord()
+	testCases := map[string]struct {
+		severityFormat string
+		severityText   string
+		want           string
+	}{
+		"None":         {severityFormat: SeverityFormatNone, want: "payment accepted"},
+		"TextFromNum":  {severityFormat: SeverityFormatText, want: "[WARN] payment accepted"},
+		"TextFromText": {severityFormat: SeverityFormatText, severityText: "warning", want: "[warning] payment accepted"},
+		"Number":       {severityFormat: SeverityFormatNumber, want: "[13] payment accepted"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			record.SetSeverityText(testCase.severityText)
+			got, err := formatter{bodyFormat: BodyFormatRaw, severityFormat: testCase.severityFormat}.format(record)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+
+	record = plog.NewLogRecord()
+	record.Body().SetStr("no severity")
+	got, err := formatter{bodyFormat: BodyFormatRaw, severityFormat: SeverityFormatText}.format(record)
+	require.NoError(t, err)
+	assert.Equal(t, "no severity", got)
+}
+
+func TestFormatJSON(t *testing.T) {
+	record := newRecord()
+	record.Attributes().PutStr("order.id", "1234")
+	record.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	f := formatter{bodyFormat: BodyFormatJSON, severityFormat: SeverityFormatNumber}
+	got, err := f.format(record)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"body":"payment accepted","severity":13,"attributes":{"order.id":"1234"},"trace_id":"0102030405060708090a0b0c0d0e0f10"}`, got)
+
+	record = plog.NewLogRecord()
+	record.Body().SetEmptyMap().PutStr("event", "login")
+	got, err = f.format(record)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"body":{"event":"login"}}`, got)
+}
@@ -0,0 +1,268 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package otlp routes OpenTelemetry log records to CloudWatch Logs
+// destinations based on the attributes of their resources.
+package otlp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/entityattributes"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+const (
+	// AttributeAwsLogGroupNames takes precedence over the log group name
+	// template. Multiple names are separated by '&' and the first is used.
+	AttributeAwsLogGroupNames = "aws.log.group.names"
+	// AttributeAwsLogStreamNames takes precedence over the log stream name
+	// template.
+	AttributeAwsLogStreamNames = "aws.log.stream.names"
+
+	sourceDescription = "otlp"
+
+	// defaultIdleTimeout is how long a destination is kept without log
+	// records, since the templated names can create a destination for every
+	// value of a resource attribute.
+	defaultIdleTimeout = 30 * time.Minute
+	// evictionInterval is how often the idle destinations are looked for.
+	evictionInterval = time.Minute
+)
+
+type Config struct {
+	LogGroupName    string
+	LogStreamName   string
+	RetentionInDays int
+	LogGroupClass   string
+	BodyFormat      string
+	SeverityFormat  string
+}
+
+type target struct {
+	group, stream string
+}
+
+// Router publishes log records to the destination of their resource. A
+// destination is created from the backend the first time a log group and
+// stream pair is seen and kept until it has been idle for the idle timeout
+// or the router is stopped.
+type Router struct {
+	cfg         Config
+	backend     logs.LogBackend
+	formatter   formatter
+	idleTimeout time.Duration
+	now         func() time.Time
+
+	mu           sync.Mutex
+	routes       map[target]*route
+	lastEviction time.Time
+}
+
+type route struct {
+	dest     logs.LogDest
+	src      *source
+	lastUsed time.Time
+}
+
+func NewRouter(cfg Config, backend logs.LogBackend) *Router {
+	return &Router{
+		cfg:          cfg,
+		backend:      backend,
+		formatter:    formatter{bodyFormat: cfg.BodyFormat, severityFormat: cfg.SeverityFormat},
+		idleTimeout:  defaultIdleTimeout,
+		now:          time.Now,
+		routes:       map[target]*route{},
+		lastEviction: time.Now(),
+	}
+}
+
+// ConsumeLogs publishes the log records to their destinations. The records
+// of the other resources are still published when one of them fails, so the
+// error is permanent to keep the batch from being retried and duplicated.
+func (r *Router) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	var errs []error
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		resourceAttrs := rls.At(i).Resource().Attributes()
+		var events []logs.LogEvent
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			records := sls.At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				message, err := r.formatter.format(records.At(k))
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				if message == "" {
+					continue
+				}
+				events = append(events, &event{message: message, timestamp: recordTime(records.At(k))})
+			}
+		}
+		if len(events) == 0 {
+			continue
+		}
+		rt := r.route(r.target(resourceAttrs))
+		if entity := entityattributes.CreateCloudWatchLogsEntityFromAttributes(resourceAttrs); entity != nil {
+			rt.src.entity.Store(entity)
+		}
+		if err := rt.dest.Publish(events); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return consumererror.NewPermanent(errors.Join(errs...))
+	}
+	return nil
+}
+
+// Stop releases all the destinations created by the router.
+func (r *Router) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for t, rt := range r.routes {
+		rt.dest.NotifySourceStopped()
+		delete(r.routes, t)
+	}
+}
+
+func (r *Router) target(attrs pcommon.Map) target {
+	t := target{
+		group:  firstName(attrs, AttributeAwsLogGroupNames),
+		stream: firstName(attrs, AttributeAwsLogStreamNames),
+	}
+	if t.group == "" {
+		t.group = ExpandTemplate(r.cfg.LogGroupName, attrs)
+	}
+	if t.stream == "" {
+		t.stream = ExpandTemplate(r.cfg.LogStreamName, attrs)
+	}
+	return t
+}
+
+func (r *Router) route(t target) *route {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if now.Sub(r.lastEviction) >= evictionInterval {
+		r.evictIdle(now)
+	}
+	rt, ok := r.routes[t]
+	if !ok {
+		src := &source{group: t.group, stream: t.stream, retention: r.cfg.RetentionInDays, class: r.cfg.LogGroupClass}
+		rt = &route{
+			dest: r.backend.CreateDest(t.group, t.stream, r.cfg.RetentionInDays, r.cfg.LogGroupClass, src),
+			src:  src,
+		}
+		r.routes[t] = rt
+	}
+	rt.lastUsed = now
+	return rt
+}
+
+// evictIdle releases the destinations that have not been used for the idle
+// timeout. A later record for the destination creates it again.
+func (r *Router) evictIdle(now time.Time) {
+	for t, rt := range r.routes {
+		if now.Sub(rt.lastUsed) >= r.idleTimeout {
+			rt.dest.NotifySourceStopped()
+			delete(r.routes, t)
+		}
+	}
+	r.lastEviction = now
+}
+
+func firstName(attrs pcommon.Map, key string) string {
+	if value, ok := attrs.Get(key); ok {
+		for _, name := range strings.Split(value.AsString(), "&") {
+			if name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// recordTime returns the time the event occurred, falling back to the time it
+// was observed. The zero time lets the pusher pick one.
+func recordTime(record plog.LogRecord) time.Time {
+	if record.Timestamp() != 0 {
+		return record.Timestamp().AsTime()
+	}
+	if record.ObservedTimestamp() != 0 {
+		return record.ObservedTimestamp().AsTime()
+	}
+	return time.Time{}
+}
+
+type event struct {
+	message   string
+	timestamp time.Time
+}
+
+var _ logs.LogEvent = (*event)(nil)
+
+func (e *event) Message() string {
+	return e.message
+}
+
+func (e *event) Time() time.Time {
+	return e.timestamp
+}
+
+func (e *event) Done() {}
+
+// source is the logs.LogSrc of a destination. It carries the entity of the
+// last resource published to the destination.
+type source struct {
+	group, stream string
+	retention     int
+	class         string
+	entity        atomic.Pointer[cloudwatchlogs.Entity]
+}
+
+var _ logs.LogSrc = (*source)(nil)
+
+func (s *source) Entity() *cloudwatchlogs.Entity {
+	return s.entity.Load()
+}
+
+func (s *source) SetOutput(func(logs.LogEvent)) {}
+
+func (s *source) Group() string {
+	return s.group
+}
+
+func (s *source) Stream() string {
+	return s.stream
+}
+
+func (s *source) Destination() string {
+	return "cloudwatchlogs"
+}
+
+func (s *source) Description() string {
+	return sourceDescription
+}
+
+func (s *source) Retention() int {
+	return s.retention
+}
+
+func (s *source) Class() string {
+	return s.class
+}
+
+func (s *source) Stop() {}
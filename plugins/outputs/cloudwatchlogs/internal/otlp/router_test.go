@@ -0,0 +1,164 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/entityattributes"
+)
+
+type stubDest struct {
+	src     logs.LogSrc
+	events  []logs.LogEvent
+	stopped bool
+	err     error
+}
+
+func (d *stubDest) Publish(events []logs.LogEvent) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.events = append(d.events, events...)
+	return nil
+}
+
+func (d *stubDest) NotifySourceStopped() {
+	d.stopped = true
+}
+
+type stubBackend struct {
+	dests map[string]*stubDest
+	err   error
+}
+
+func (b *stubBackend) CreateDest(group, stream string, _ int, _ string, src logs.LogSrc) logs.LogDest {
+	d := &stubDest{src: src, err: b.err}
+	b.dests[group+"/"+stream] = d
+	return d
+}
+
+func appendResourceLogs(ld plog.Logs, attrs map[string]any, bodies ...string) {
+	rl := ld.ResourceLogs().AppendEmpty()
+	_ = rl.Resource().Attributes().FromRaw(attrs)
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range bodies {
+		record := records.AppendEmpty()
+		record.Body().SetStr(body)
+		record.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1700000000, 0)))
+	}
+}
+
+func TestRouter(t *testing.T) {
+	backend := &stubBackend{dests: map[string]*stubDest{}}
+	router := NewRouter(Config{
+		LogGroupName:   "/aws/otlp/{service.name}",
+		LogStreamName:  "i-123456789",
+		BodyFormat:     BodyFormatRaw,
+		SeverityFormat: SeverityFormatNone,
+	}, backend)
+
+	ld := plog.NewLogs()
+	appendResourceLogs(ld, map[string]any{"service.name": "checkout"}, "first", "", "second")
+	appendResourceLogs(ld, map[string]any{
+		"service.name":                               "payments",
+		AttributeAwsLogGroupNames:                    "&payments-group&other-group",
+		AttributeAwsLogStreamNames:                   "payments-stream",
+		entityattributes.AttributeEntityType:         "Service",
+		entityattributes.AttributeEntityServiceName:  "payments",
+		entityattributes.AttributeEntityAwsAccountId: "123456789012",
+	}, "third")
+	appendResourceLogs(ld, map[string]any{}, "fourth")
+	require.NoError(t, router.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, router.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, backend.dests, 3)
+	checkout := backend.dests["/aws/otlp/checkout/i-123456789"]
+	require.NotNil(t, checkout)
+	require.Len(t, checkout.events, 4)
+	assert.Equal(t, "first", checkout.events[0].Message())
+	assert.Equal(t, "second", checkout.events[1].Message())
+	assert.True(t, time.Unix(1700000000, 0).Equal(checkout.events[0].Time()))
+	assert.Nil(t, checkout.src.Entity())
+
+	payments := backend.dests["payments-group/payments-stream"]
+	require.NotNil(t, payments)
+	assert.Len(t, payments.events, 2)
+	require.NotNil(t, payments.src.Entity())
+	assert.Equal(t, "payments", *payments.src.Entity().KeyAttributes[entityattributes.ServiceName])
+
+	assert.NotNil(t, backend.dests["/aws/otlp/unknown/i-123456789"])
+
+	router.Stop()
+	for _, d := range backend.dests {
+		assert.True(t, d.stopped)
+	}
+}
+
+func TestRouterEvictsIdleRoutes(t *testing.T) {
+	backend := &stubBackend{dests: map[string]*stubDest{}}
+	router := NewRouter(Config{
+		LogGroupName:   "/aws/otlp/{service.name}",
+		LogStreamName:  "i-123456789",
+		BodyFormat:     BodyFormatRaw,
+		SeverityFormat: SeverityFormatNone,
+	}, backend)
+	now := time.Now()
+	router.now = func() time.Time { return now }
+
+	checkout := plog.NewLogs()
+	appendResourceLogs(checkout, map[string]any{"service.name": "checkout"}, "first")
+	payments := plog.NewLogs()
+	appendResourceLogs(payments, map[string]any{"service.name": "payments"}, "second")
+	require.NoError(t, router.ConsumeLogs(context.Background(), checkout))
+	require.NoError(t, router.ConsumeLogs(context.Background(), payments))
+
+	// only payments keeps receiving records
+	now = now.Add(defaultIdleTimeout / 2)
+	require.NoError(t, router.ConsumeLogs(context.Background(), payments))
+	now = now.Add(defaultIdleTimeout / 2)
+	require.NoError(t, router.ConsumeLogs(context.Background(), payments))
+
+	assert.True(t, backend.dests["/aws/otlp/checkout/i-123456789"].stopped)
+	assert.False(t, backend.dests["/aws/otlp/payments/i-123456789"].stopped)
+	assert.Len(t, router.routes, 1)
+
+	// the destination is created again for new records
+	require.NoError(t, router.ConsumeLogs(context.Background(), checkout))
+	assert.False(t, backend.dests["/aws/otlp/checkout/i-123456789"].stopped)
+	assert.Len(t, router.routes, 2)
+}
+
+func TestRouterPublishesRemainingResources(t *testing.T) {
+	backend := &stubBackend{dests: map[string]*stubDest{}}
+	router := NewRouter(Config{
+		LogGroupName:   "/aws/otlp/{service.name}",
+		LogStreamName:  "i-123456789",
+		BodyFormat:     BodyFormatRaw,
+		SeverityFormat: SeverityFormatNone,
+	}, backend)
+
+	ld := plog.NewLogs()
+	appendResourceLogs(ld, map[string]any{"service.name": "checkout"}, "first")
+	appendResourceLogs(ld, map[string]any{"service.name": "payments"}, "second")
+	backend.err = logs.ErrOutputStopped
+	router.route(router.target(ld.ResourceLogs().At(0).Resource().Attributes()))
+	backend.err = nil
+
+	err := router.ConsumeLogs(context.Background(), ld)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.True(t, errors.Is(err, logs.ErrOutputStopped))
+	assert.Len(t, backend.dests["/aws/otlp/payments/i-123456789"].events, 1)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/status"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/profiler"
 )
@@ -40,8 +41,10 @@ type queue struct {
 	lastSentTime atomic.Value
 
 	initNonBlockingChOnce sync.Once
+	nonBlockingChReady    atomic.Bool
 	startNonBlockCh       chan struct{}
 	wg                    *sync.WaitGroup
+	unregisterQueue       func()
 }
 
 var _ (Queue) = (*queue)(nil)
@@ -69,6 +72,7 @@ func newQueue(
 		wg:              wg,
 	}
 	q.flushTimeout.Store(flushTimeout)
+	q.unregisterQueue = status.Get().RegisterQueue("cloudwatchlogs/"+target.Group+"/"+target.Stream, q.depth)
 	q.wg.Add(1)
 	go q.start()
 	return q
@@ -93,6 +97,7 @@ func (q *queue) AddEventNonBlocking(e logs.LogEvent) {
 
 	q.initNonBlockingChOnce.Do(func() {
 		q.nonBlockingEventsCh = make(chan logs.LogEvent, reqEventsLimit*2)
+		q.nonBlockingChReady.Store(true)
 		q.startNonBlockCh <- struct{}{} // Unblock the select loop to recognize the channel merge
 	})
 
@@ -114,9 +119,21 @@ func (q *queue) Stop() {
 		return
 	}
 	close(q.stopCh)
+	if q.unregisterQueue != nil {
+		q.unregisterQueue()
+	}
 	q.stopped = true
 }
 
+// depth returns the number of events waiting to be added to a batch.
+func (q *queue) depth() int {
+	depth := len(q.eventsCh)
+	if q.nonBlockingChReady.Load() {
+		depth += len(q.nonBlockingEventsCh)
+	}
+	return depth
+}
+
 // start is the main loop for processing events and managing the queue.
 func (q *queue) start() {
 	defer q.wg.Done()
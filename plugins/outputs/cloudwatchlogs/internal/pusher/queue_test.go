@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/status"
 	"github.com/aws/amazon-cloudwatch-agent/internal/state"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
@@ -679,6 +680,30 @@ func triggerSend(t *testing.T, q *queue) {
 	q.flushCh <- struct{}{}
 }
 
+func TestQueueDepthInStatus(t *testing.T) {
+	var wg sync.WaitGroup
+	logger := testutil.NewNopLogger()
+	service := &stubLogsService{
+		ple: func(*cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+			return &cloudwatchlogs.PutLogEventsOutput{}, nil
+		},
+	}
+	s := newSender(logger, service, NewTargetManager(logger, service), time.Hour)
+	q := newQueue(logger, Target{"DepthGroup", "S", util.StandardLogGroupClass, -1}, time.Hour, nil, s, &wg).(*queue)
+
+	depth, ok := status.Get().Snapshot().Queues["cloudwatchlogs/DepthGroup/S"]
+	assert.True(t, ok)
+	assert.Equal(t, 0, depth)
+	q.eventsCh <- newStubLogEvent("MSG", time.Now())
+	q.AddEventNonBlocking(newStubLogEvent("MSG", time.Now()))
+	assert.LessOrEqual(t, q.depth(), 2)
+
+	q.Stop()
+	s.Stop()
+	wg.Wait()
+	assert.NotContains(t, status.Get().Snapshot().Queues, "cloudwatchlogs/DepthGroup/S")
+}
+
 func testPreparation(
 	t *testing.T,
 	retention int,
@@ -0,0 +1,39 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awsapplicationsignals
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// metricsProcessors are the started metrics processors by the ID of their
+// component. The traces processor of a component sends the metrics that it
+// generates from spans to the metrics processor of the same component, so that
+// they are resolved, normalized and limited like the metrics of the ADOT SDKs.
+var metricsProcessors = struct {
+	sync.RWMutex
+	consumers map[component.ID]consumer.Metrics
+}{consumers: map[component.ID]consumer.Metrics{}}
+
+func registerMetricsProcessor(id component.ID, mp consumer.Metrics) {
+	metricsProcessors.Lock()
+	defer metricsProcessors.Unlock()
+	metricsProcessors.consumers[id] = mp
+}
+
+func unregisterMetricsProcessor(id component.ID) {
+	metricsProcessors.Lock()
+	defer metricsProcessors.Unlock()
+	delete(metricsProcessors.consumers, id)
+}
+
+func getMetricsProcessor(id component.ID) (consumer.Metrics, bool) {
+	metricsProcessors.RLock()
+	defer metricsProcessors.RUnlock()
+	mp, ok := metricsProcessors.consumers[id]
+	return mp, ok
+}
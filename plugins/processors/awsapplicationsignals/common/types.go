@@ -26,6 +26,11 @@ const (
 	AttributeEC2AutoScalingGroup = "EC2.AutoScalingGroup"
 	AttributeEC2InstanceId       = "EC2.InstanceId"
 	AttributeHost                = "Host"
+	AttributeDockerContainer     = "Docker.Container"
+	AttributeNomadNamespace      = "Nomad.Namespace"
+	AttributeNomadJob            = "Nomad.Job"
+	AttributeNomadTaskGroup      = "Nomad.TaskGroup"
+	AttributeNomadAllocID        = "Nomad.AllocId"
 )
 
 // Platform attribute used as CloudWatch EMF log field.
@@ -6,6 +6,9 @@ package config
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
+	"net/url"
 	"time"
 
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
@@ -15,15 +18,28 @@ type Config struct {
 	Resolvers []Resolver     `mapstructure:"resolvers"`
 	Rules     []rules.Rule   `mapstructure:"rules"`
 	Limiter   *LimiterConfig `mapstructure:"limiter"`
+	// GenerateSpanMetrics derives the latency, fault and error metrics from
+	// the spans that do not come from an ADOT SDK. The metrics are processed
+	// by the metrics pipeline of the same processor.
+	GenerateSpanMetrics bool `mapstructure:"generate_span_metrics,omitempty"`
+	// RuleHitsReportInterval is how often the number of data points and spans
+	// matched by each rule is logged. A minute if 0.
+	RuleHitsReportInterval time.Duration `mapstructure:"rule_hits_report_interval,omitempty"`
 }
 
 type LimiterConfig struct {
-	Threshold                 int             `mapstructure:"drop_threshold"`
-	Disabled                  bool            `mapstructure:"disabled"`
-	LogDroppedMetrics         bool            `mapstructure:"log_dropped_metrics"`
-	RotationInterval          time.Duration   `mapstructure:"rotation_interval"`
-	GarbageCollectionInterval time.Duration   `mapstructure:"garbage_collection_interval"`
-	ParentContext             context.Context `mapstructure:"-"`
+	Threshold                 int           `mapstructure:"drop_threshold"`
+	Disabled                  bool          `mapstructure:"disabled"`
+	LogDroppedMetrics         bool          `mapstructure:"log_dropped_metrics"`
+	RotationInterval          time.Duration `mapstructure:"rotation_interval"`
+	GarbageCollectionInterval time.Duration `mapstructure:"garbage_collection_interval"`
+	// StateFile is where the limiter checkpoints its visit records on every
+	// rotation, to restore the admitted keys after a restart.
+	StateFile string `mapstructure:"state_file,omitempty"`
+	// DebugListenAddress is the loopback address serving the admitted and
+	// dropped keys of the limiter.
+	DebugListenAddress string          `mapstructure:"debug_listen_addr,omitempty"`
+	ParentContext      context.Context `mapstructure:"-"`
 }
 
 const (
@@ -62,14 +78,64 @@ func (cfg *Config) Validate() error {
 			if resolver.Name == "" {
 				return errors.New("name must not be empty for k8s resolver")
 			}
+		case PlatformDocker, PlatformNomad:
+			if err := validateEndpoint(resolver.Endpoint); err != nil {
+				return fmt.Errorf("invalid endpoint for %s resolver: %w", resolver.Platform, err)
+			}
 		case PlatformEC2, PlatformECS, PlatformGeneric:
 		default:
 			return errors.New("unknown resolver")
 		}
 	}
 
+	for _, rule := range cfg.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
 	if cfg.Limiter != nil {
 		cfg.Limiter.Validate()
+		if err := validateDebugListenAddress(cfg.Limiter.DebugListenAddress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "unix":
+		if u.Path == "" {
+			return errors.New("unix endpoint must have a path")
+		}
+	case "http", "https":
+		if u.Host == "" {
+			return errors.New("http endpoint must have a host")
+		}
+	default:
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+func validateDebugListenAddress(address string) error {
+	if address == "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid debug_listen_addr %q: %w", address, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("debug_listen_addr %q must use a loopback address", address)
 	}
 	return nil
 }
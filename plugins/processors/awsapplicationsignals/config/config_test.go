@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
 )
 
 func TestValidatePassed(t *testing.T) {
@@ -34,6 +36,14 @@ func TestValidatePassed(t *testing.T) {
 			"testGeneric",
 			NewGenericResolver("test"),
 		},
+		{
+			"testDocker",
+			NewDockerResolver("", ""),
+		},
+		{
+			"testNomad",
+			NewNomadResolver("test", "https://nomad.local:4646"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -80,3 +90,73 @@ func TestValidateFailedOnEmptyResolverName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFailedOnInvalidEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver Resolver
+	}{
+		{
+			"testUnixWithoutPath",
+			NewDockerResolver("", "unix://"),
+		},
+		{
+			"testHTTPWithoutHost",
+			NewNomadResolver("", "http://"),
+		},
+		{
+			"testUnsupportedScheme",
+			NewNomadResolver("", "tcp://127.0.0.1:4646"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{
+				Resolvers: []Resolver{tt.resolver},
+			}
+			assert.ErrorContains(t, config.Validate(), "invalid endpoint")
+		})
+	}
+}
+
+func TestValidateDebugListenAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		wantErr string
+	}{
+		{address: ""},
+		{address: "localhost:4317"},
+		{address: "127.0.0.1:0"},
+		{address: "[::1]:8080"},
+		{address: "0.0.0.0:8080", wantErr: "must use a loopback address"},
+		{address: "8080", wantErr: "invalid debug_listen_addr"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			limiterConfig := NewDefaultLimiterConfig()
+			limiterConfig.DebugListenAddress = tt.address
+			config := Config{
+				Resolvers: []Resolver{NewGenericResolver("")},
+				Limiter:   limiterConfig,
+			}
+			if tt.wantErr == "" {
+				assert.NoError(t, config.Validate())
+			} else {
+				assert.ErrorContains(t, config.Validate(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateFailedOnInvalidRule(t *testing.T) {
+	config := Config{
+		Resolvers: []Resolver{NewGenericResolver("")},
+		Rules: []rules.Rule{
+			{
+				Selectors: []rules.Selector{{Dimension: "Operation", MatchRegex: "(GET"}},
+				Action:    rules.AllowListActionKeep,
+			},
+		},
+	}
+	assert.ErrorContains(t, config.Validate(), "invalid match_regex")
+}
@@ -14,11 +14,21 @@ const (
 	PlatformEC2 = "ec2"
 	// PlatformECS Amazon ECS
 	PlatformECS = "ecs"
+	// PlatformDocker Docker containers, e.g. of Docker Compose
+	PlatformDocker = "docker"
+	// PlatformNomad HashiCorp Nomad allocations
+	PlatformNomad = "nomad"
+
+	DefaultDockerEndpoint = "unix:///var/run/docker.sock"
+	DefaultNomadEndpoint  = "http://127.0.0.1:4646"
 )
 
 type Resolver struct {
 	Name     string `mapstructure:"name"`
 	Platform string `mapstructure:"platform"`
+	// Endpoint is the address of the local API of the docker and nomad
+	// platforms. Either unix:// or http(s)://.
+	Endpoint string `mapstructure:"endpoint,omitempty"`
 }
 
 func NewEKSResolver(name string) Resolver {
@@ -55,3 +65,25 @@ func NewGenericResolver(name string) Resolver {
 		Platform: PlatformGeneric,
 	}
 }
+
+func NewDockerResolver(name, endpoint string) Resolver {
+	if endpoint == "" {
+		endpoint = DefaultDockerEndpoint
+	}
+	return Resolver{
+		Name:     name,
+		Platform: PlatformDocker,
+		Endpoint: endpoint,
+	}
+}
+
+func NewNomadResolver(name, endpoint string) Resolver {
+	if endpoint == "" {
+		endpoint = DefaultNomadEndpoint
+	}
+	return Resolver{
+		Name:     name,
+		Platform: PlatformNomad,
+		Endpoint: endpoint,
+	}
+}
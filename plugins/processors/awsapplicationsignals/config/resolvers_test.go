@@ -28,3 +28,17 @@ func TestNewGenericResolver(t *testing.T) {
 	resolver := NewGenericResolver("")
 	assert.Equal(t, "generic", resolver.Platform)
 }
+
+func TestDockerResolver(t *testing.T) {
+	resolver := NewDockerResolver("test", "")
+	assert.Equal(t, "docker", resolver.Platform)
+	assert.Equal(t, DefaultDockerEndpoint, resolver.Endpoint)
+	resolver = NewDockerResolver("test", "http://127.0.0.1:2375")
+	assert.Equal(t, "http://127.0.0.1:2375", resolver.Endpoint)
+}
+
+func TestNomadResolver(t *testing.T) {
+	resolver := NewNomadResolver("test", "")
+	assert.Equal(t, "nomad", resolver.Platform)
+	assert.Equal(t, DefaultNomadEndpoint, resolver.Endpoint)
+}
@@ -75,15 +75,26 @@ func createMetricsProcessor(
 		return nil, err
 	}
 
-	return processorhelper.NewMetrics(
+	var mp processor.Metrics
+	mp, err = processorhelper.NewMetrics(
 		ctx,
 		set,
 		cfg,
 		nextMetricsConsumer,
 		ap.processMetrics,
 		processorhelper.WithCapabilities(consumerCapabilities),
-		processorhelper.WithStart(ap.StartMetrics),
-		processorhelper.WithShutdown(ap.Shutdown))
+		processorhelper.WithStart(func(ctx context.Context, host component.Host) error {
+			if err := ap.StartMetrics(ctx, host); err != nil {
+				return err
+			}
+			registerMetricsProcessor(set.ID, mp)
+			return nil
+		}),
+		processorhelper.WithShutdown(func(ctx context.Context) error {
+			unregisterMetricsProcessor(set.ID)
+			return ap.Shutdown(ctx)
+		}))
+	return mp, err
 }
 
 func createProcessor(
@@ -94,7 +105,7 @@ func createProcessor(
 	if !ok {
 		return nil, errors.New("could not initialize awsapplicationsignalsprocessor")
 	}
-	ap := &awsapplicationsignalsprocessor{logger: params.Logger, config: pCfg}
+	ap := &awsapplicationsignalsprocessor{id: params.ID, logger: params.Logger, config: pCfg}
 
 	return ap, nil
 }
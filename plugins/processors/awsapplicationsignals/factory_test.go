@@ -4,14 +4,19 @@
 package awsapplicationsignals
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
 
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
@@ -155,3 +160,52 @@ func TestLoadGenericConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSpanMetrics(t *testing.T) {
+	ctx := context.Background()
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*config.Config)
+	cfg.Resolvers = []config.Resolver{config.NewGenericResolver("")}
+	cfg.GenerateSpanMetrics = true
+	settings := processortest.NewNopSettings(typeStr)
+
+	sink := new(consumertest.MetricsSink)
+	mp, err := factory.CreateMetrics(ctx, settings, cfg, sink)
+	require.NoError(t, err)
+	tp, err := factory.CreateTraces(ctx, settings, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(ctx, componenttest.NewNopHost()))
+	defer func() { require.NoError(t, tp.Shutdown(ctx)) }()
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetName("GET /orders")
+
+	// the metrics are dropped until the metrics processor is started
+	require.NoError(t, tp.ConsumeTraces(ctx, traces))
+	assert.Empty(t, sink.AllMetrics())
+
+	require.NoError(t, mp.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, tp.ConsumeTraces(ctx, traces))
+	require.Len(t, sink.AllMetrics(), 1)
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	var names []string
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+		attributes := metrics.At(i).Histogram().DataPoints().At(0).Attributes()
+		service, _ := attributes.Get("Service")
+		assert.Equal(t, "checkout", service.Str())
+		operation, _ := attributes.Get("Operation")
+		assert.Equal(t, "GET /orders", operation.Str())
+		source, _ := attributes.Get("Telemetry.Source")
+		assert.Equal(t, "ServerSpan", source.Str())
+	}
+	assert.Equal(t, []string{"Latency", "Fault", "Error"}, names)
+
+	require.NoError(t, mp.Shutdown(ctx))
+	require.NoError(t, tp.ConsumeTraces(ctx, traces))
+	assert.Len(t, sink.AllMetrics(), 1)
+}
@@ -25,6 +25,9 @@ const (
 	AWSECSClusterName = "aws.ecs.cluster.name"
 	AWSECSTaskID      = "aws.ecs.task.id"
 
+	// nomad resource attributes, set with OTEL_RESOURCE_ATTRIBUTES=nomad.alloc.id=${NOMAD_ALLOC_ID}
+	NomadAllocID = "nomad.alloc.id"
+
 	// resource detection processor attributes
 	ResourceDetectionHostId   = "host.id"
 	ResourceDetectionHostName = "host.name"
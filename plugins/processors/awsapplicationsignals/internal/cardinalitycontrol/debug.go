@@ -0,0 +1,111 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cardinalitycontrol
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const debugPath = "/limiter"
+
+type debugResponse struct {
+	Services []debugService `json:"services"`
+}
+
+type debugService struct {
+	Name        string     `json:"name"`
+	Rotations   int        `json:"rotations"`
+	TotalCount  int        `json:"total_count"`
+	TotalRollup int        `json:"total_rollup"`
+	Admitted    []debugKey `json:"admitted"`
+	Dropped     []debugKey `json:"dropped"`
+}
+
+// debugKey is an admitted key with the estimated frequency of the sketch, or
+// a dropped key with the number of data points rolled up since the last
+// rotation.
+type debugKey struct {
+	Metric    string            `json:"metric"`
+	Labels    map[string]string `json:"labels"`
+	Frequency int               `json:"frequency,omitempty"`
+	Count     int               `json:"count,omitempty"`
+}
+
+func (m *MetricsLimiter) startDebugServer(address string) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		m.logger.Error("failed to listen for the metrics limiter debug endpoint", zap.String("address", address), zap.Error(err))
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(debugPath, m.debugHandler)
+	m.debugServer = &http.Server{Handler: mux, ReadHeaderTimeout: 90 * time.Second}
+	go func() {
+		if err := m.debugServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			m.logger.Error("failed to serve the metrics limiter debug endpoint", zap.Error(err))
+		}
+	}()
+	m.logger.Info("serving metrics limiter debug endpoint", zap.String("address", listener.Addr().String()+debugPath))
+}
+
+// debugHandler serves the admitted and dropped keys of all services, or of
+// the service of the service query parameter.
+func (m *MetricsLimiter) debugHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(m.debugSnapshot(r.URL.Query().Get("service"))); err != nil {
+		m.logger.Debug("failed to write metrics limiter debug response", zap.Error(err))
+	}
+}
+
+func (m *MetricsLimiter) debugSnapshot(serviceName string) debugResponse {
+	response := debugResponse{Services: []debugService{}}
+	m.services.Range(func(key, value any) bool {
+		svc, ok := value.(*service)
+		if ok && (serviceName == "" || serviceName == key.(string)) {
+			response.Services = append(response.Services, svc.debugSnapshot())
+		}
+		return true
+	})
+	sort.Slice(response.Services, func(i, j int) bool {
+		return response.Services[i].Name < response.Services[j].Name
+	})
+	return response
+}
+
+func (s *service) debugSnapshot() debugService {
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+	snapshot := debugService{
+		Name:        s.name,
+		Rotations:   s.rotations,
+		TotalCount:  s.totalCount,
+		TotalRollup: s.totalRollup,
+		Admitted:    make([]debugKey, 0, len(s.primaryTopK.metricMap)),
+		Dropped:     make([]debugKey, 0, len(s.dropped)),
+	}
+	for _, md := range s.primaryTopK.metricMap {
+		snapshot.Admitted = append(snapshot.Admitted, debugKey{Metric: md.name, Labels: md.labels, Frequency: md.frequency})
+	}
+	for _, dropped := range s.dropped {
+		snapshot.Dropped = append(snapshot.Dropped, debugKey{Metric: dropped.metric.name, Labels: dropped.metric.labels, Count: dropped.count})
+	}
+	sort.Slice(snapshot.Admitted, func(i, j int) bool {
+		return snapshot.Admitted[i].Frequency > snapshot.Admitted[j].Frequency
+	})
+	sort.Slice(snapshot.Dropped, func(i, j int) bool {
+		return snapshot.Dropped[i].Count > snapshot.Dropped[j].Count
+	})
+	return snapshot
+}
@@ -0,0 +1,90 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cardinalitycontrol
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	awsapplicationsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+)
+
+func TestDebugHandler(t *testing.T) {
+	config := &awsapplicationsignalsconfig.LimiterConfig{
+		Threshold:        2,
+		RotationInterval: awsapplicationsignalsconfig.DefaultRotationInterval,
+	}
+	config.Validate()
+	limiter := NewMetricsLimiter(config, logger).(*MetricsLimiter)
+
+	for i, hits := range []int{3, 2, 2, 1} {
+		for j := 0; j < hits; j++ {
+			limiter.Admit("latency", newFixedAttributes(i), emptyResourceAttributes)
+		}
+	}
+	other := pcommon.NewMap()
+	other.PutStr("Service", "other")
+	limiter.Admit("error", other, emptyResourceAttributes)
+
+	testCases := map[string]struct {
+		query    string
+		services []string
+	}{
+		"All":     {services: []string{"app", "other"}},
+		"Service": {query: "?service=app", services: []string{"app"}},
+		"Unknown": {query: "?service=unknown", services: []string{}},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			limiter.debugHandler(recorder, httptest.NewRequest(http.MethodGet, debugPath+testCase.query, nil))
+			require.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+			var response debugResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			services := []string{}
+			for _, svc := range response.Services {
+				services = append(services, svc.Name)
+			}
+			assert.Equal(t, testCase.services, services)
+		})
+	}
+
+	snapshot := limiter.debugSnapshot("app").Services[0]
+	assert.Equal(t, 8, snapshot.TotalCount)
+	assert.Equal(t, 3, snapshot.TotalRollup)
+	require.Len(t, snapshot.Admitted, 2)
+	assert.Equal(t, newFixedAttributesLabels(0), snapshot.Admitted[0].Labels)
+	assert.Equal(t, 3, snapshot.Admitted[0].Frequency)
+	require.Len(t, snapshot.Dropped, 2)
+	assert.Equal(t, "latency", snapshot.Dropped[0].Metric)
+	assert.Equal(t, newFixedAttributesLabels(2), snapshot.Dropped[0].Labels)
+	assert.Equal(t, 2, snapshot.Dropped[0].Count)
+	assert.Equal(t, 1, snapshot.Dropped[1].Count)
+
+	recorder := httptest.NewRecorder()
+	limiter.debugHandler(recorder, httptest.NewRequest(http.MethodPost, debugPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestDebugServer(t *testing.T) {
+	config := &awsapplicationsignalsconfig.LimiterConfig{
+		Threshold:          2,
+		RotationInterval:   awsapplicationsignalsconfig.DefaultRotationInterval,
+		DebugListenAddress: "127.0.0.1:0",
+	}
+	config.Validate()
+	limiter := NewMetricsLimiter(config, logger).(*MetricsLimiter)
+	require.NotNil(t, limiter.debugServer)
+	require.NoError(t, limiter.Stop(t.Context()))
+	// stopping twice is a no-op
+	require.NoError(t, limiter.Stop(t.Context()))
+}
@@ -5,7 +5,9 @@ package cardinalitycontrol
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"sort"
 	"sync"
 	"time"
@@ -25,6 +27,10 @@ const (
 const (
 	defaultCMSDepth = 3
 	defaultCMSWidth = 5000
+
+	// droppedKeysLimit is the number of distinct dropped keys that are kept
+	// per service for the debug endpoint.
+	droppedKeysLimit = 100
 )
 
 var awsDeclaredMetricAttributes = []string{
@@ -49,9 +55,13 @@ type MetricsLimiter struct {
 	LogDroppedMetrics bool
 	RotationInterval  time.Duration
 
-	logger   *zap.Logger
-	ctx      context.Context
-	services sync.Map
+	logger      *zap.Logger
+	ctx         context.Context
+	services    sync.Map
+	stateFile   string
+	debugServer *http.Server
+	done        chan struct{}
+	stopOnce    sync.Once
 }
 
 func NewMetricsLimiter(config *config.LimiterConfig, logger *zap.Logger) Limiter {
@@ -67,9 +77,21 @@ func NewMetricsLimiter(config *config.LimiterConfig, logger *zap.Logger) Limiter
 		LogDroppedMetrics: config.LogDroppedMetrics,
 		RotationInterval:  config.RotationInterval,
 
-		logger:   logger,
-		ctx:      ctx,
-		services: sync.Map{},
+		logger:    logger,
+		ctx:       ctx,
+		services:  sync.Map{},
+		stateFile: config.StateFile,
+		done:      make(chan struct{}),
+	}
+
+	if limiter.stateFile != "" {
+		if err := limiter.restore(); err != nil {
+			logger.Warn("failed to restore metrics limiter state, starting from scratch", zap.String("file", limiter.stateFile), zap.Error(err))
+		}
+		go limiter.checkpointPeriodically()
+	}
+	if config.DebugListenAddress != "" {
+		limiter.startDebugServer(config.DebugListenAddress)
 	}
 
 	go func() {
@@ -118,6 +140,7 @@ func (m *MetricsLimiter) Admit(metricName string, attributes, resourceAttributes
 	svc.rwLock.Lock()
 	defer svc.rwLock.Unlock()
 	if !svc.admitMetricDataLocked(metricData) {
+		svc.recordDroppedLocked(metricData)
 		svc.rollupMetricDataLocked(attributes)
 
 		svc.totalRollup++
@@ -135,6 +158,21 @@ func (m *MetricsLimiter) Admit(metricName string, attributes, resourceAttributes
 	return admitted, nil
 }
 
+// Stop writes the last checkpoint of the state and stops the debug server.
+func (m *MetricsLimiter) Stop(ctx context.Context) error {
+	var err error
+	m.stopOnce.Do(func() {
+		close(m.done)
+		if m.stateFile != "" {
+			err = m.checkpoint()
+		}
+		if m.debugServer != nil {
+			err = errors.Join(err, m.debugServer.Shutdown(ctx))
+		}
+	})
+	return err
+}
+
 func (m *MetricsLimiter) filterAWSDeclaredAttributes(attributes, resourceAttributes pcommon.Map) (map[string]string, string, bool) {
 	svcNameAttr, exists := attributes.Get(common.CWMetricAttributeLocalService)
 	if !exists {
@@ -183,6 +221,14 @@ type service struct {
 
 	totalRollup     int
 	totalMetricSent int
+
+	// dropped are the keys that were rolled up since the last rotation.
+	dropped map[string]*droppedMetricData
+}
+
+type droppedMetricData struct {
+	metric *MetricData
+	count  int
 }
 
 func (s *service) InsertMetricDataToPrimary(md *MetricData) {
@@ -206,6 +252,7 @@ type MetricData struct {
 	hashKey   string
 	name      string
 	service   string
+	labels    map[string]string
 	frequency int
 }
 
@@ -223,6 +270,7 @@ func newMetricData(serviceName, metricName string, labels map[string]string) *Me
 		hashKey:   hashID,
 		name:      metricName,
 		service:   serviceName,
+		labels:    labels,
 		frequency: 1,
 	}
 }
@@ -232,6 +280,7 @@ func copyMetricDataWithUpdatedFrequency(md *MetricData, frequency int) *MetricDa
 		hashKey:   md.hashKey,
 		name:      md.name,
 		service:   md.service,
+		labels:    md.labels,
 		frequency: frequency,
 	}
 }
@@ -330,6 +379,16 @@ func (s *service) admitMetricDataLocked(metric *MetricData) bool {
 	return s.primaryTopK.Admit(metric)
 }
 
+func (s *service) recordDroppedLocked(metric *MetricData) {
+	if dropped, ok := s.dropped[metric.hashKey]; ok {
+		dropped.count++
+		return
+	}
+	if len(s.dropped) < droppedKeysLimit {
+		s.dropped[metric.hashKey] = &droppedMetricData{metric: metric, count: 1}
+	}
+}
+
 func (s *service) rollupMetricDataLocked(attributes pcommon.Map) {
 	for _, indexAttr := range awsDeclaredMetricAttributes {
 		if (indexAttr == common.CWMetricAttributeEnvironment) || (indexAttr == common.CWMetricAttributeLocalService) || (indexAttr == common.CWMetricAttributeRemoteService) {
@@ -368,6 +427,7 @@ func (s *service) rotateVisitRecords() error {
 
 	s.countSnapshot[s.rotations%3] = s.totalCount
 	s.rotations++
+	s.dropped = map[string]*droppedMetricData{}
 
 	return nil
 }
@@ -396,6 +456,7 @@ func newService(name string, limit int, rotationInterval time.Duration, parentCt
 		primaryCMS:    NewCountMinSketch(depth, width),
 		primaryTopK:   newTopKMetrics(limit),
 		countSnapshot: make([]int, 3),
+		dropped:       map[string]*droppedMetricData{},
 	}
 
 	// Create a ticker to create a new countMinSketch every 1 hour
@@ -0,0 +1,218 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cardinalitycontrol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const stateVersion = 1
+
+// limiterState is the checkpoint of the visit records of all services, so that
+// the admitted keys survive a restart of the agent.
+type limiterState struct {
+	Version  int                      `json:"version"`
+	Services map[string]*serviceState `json:"services"`
+}
+
+type serviceState struct {
+	Primary       *visitRecordsState `json:"primary"`
+	Secondary     *visitRecordsState `json:"secondary,omitempty"`
+	TotalCount    int                `json:"total_count"`
+	Rotations     int                `json:"rotations"`
+	CountSnapshot []int              `json:"count_snapshot"`
+}
+
+type visitRecordsState struct {
+	Sketch [][]int           `json:"sketch"`
+	TopK   []metricDataState `json:"top_k"`
+}
+
+type metricDataState struct {
+	Metric    string            `json:"metric"`
+	Labels    map[string]string `json:"labels"`
+	Frequency int               `json:"frequency"`
+}
+
+func (m *MetricsLimiter) checkpointPeriodically() {
+	ticker := time.NewTicker(m.RotationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.checkpoint(); err != nil {
+				m.logger.Warn("failed to checkpoint metrics limiter state", zap.String("file", m.stateFile), zap.Error(err))
+			}
+		case <-m.done:
+			return
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+// checkpoint writes the state to a temporary file first, so that a crash
+// while writing does not corrupt the previous checkpoint.
+func (m *MetricsLimiter) checkpoint() error {
+	state := limiterState{Version: stateVersion, Services: map[string]*serviceState{}}
+	m.services.Range(func(key, value any) bool {
+		if svc, ok := value.(*service); ok {
+			state.Services[key.(string)] = svc.snapshot()
+		}
+		return true
+	})
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(m.stateFile), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(m.stateFile), filepath.Base(m.stateFile)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), m.stateFile)
+}
+
+// restore recreates the services of the checkpoint. A missing state file is
+// not an error, it is the first start of the limiter.
+func (m *MetricsLimiter) restore() error {
+	content, err := os.ReadFile(m.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var state limiterState
+	if err = json.Unmarshal(content, &state); err != nil {
+		return err
+	}
+	if state.Version != stateVersion {
+		return fmt.Errorf("unsupported state version %d", state.Version)
+	}
+	for name, svcState := range state.Services {
+		svc, err := m.restoreService(name, svcState)
+		if err != nil {
+			m.logger.Warn(fmt.Sprintf("[%s] failed to restore visit records", name), zap.Error(err))
+			continue
+		}
+		m.services.Store(name, svc)
+	}
+	m.logger.Info("restored metrics limiter state", zap.String("file", m.stateFile), zap.Int("services", len(state.Services)))
+	return nil
+}
+
+func (m *MetricsLimiter) restoreService(name string, state *serviceState) (*service, error) {
+	if state.Primary == nil {
+		return nil, errors.New("missing primary visit records")
+	}
+	primaryCMS, err := restoreCountMinSketch(state.Primary.Sketch)
+	if err != nil {
+		return nil, err
+	}
+	var secondaryCMS *CountMinSketch
+	if state.Secondary != nil {
+		if secondaryCMS, err = restoreCountMinSketch(state.Secondary.Sketch); err != nil {
+			return nil, err
+		}
+	}
+
+	svc := newService(name, m.DropThreshold, m.RotationInterval, m.ctx, m.logger)
+	svc.rwLock.Lock()
+	defer svc.rwLock.Unlock()
+	svc.primaryCMS = primaryCMS
+	svc.primaryTopK = restoreTopK(name, state.Primary.TopK, m.DropThreshold)
+	if secondaryCMS != nil {
+		svc.secondaryCMS = secondaryCMS
+		svc.secondaryTopK = restoreTopK(name, state.Secondary.TopK, m.DropThreshold)
+	}
+	svc.totalCount = state.TotalCount
+	svc.rotations = state.Rotations
+	if len(state.CountSnapshot) == len(svc.countSnapshot) {
+		copy(svc.countSnapshot, state.CountSnapshot)
+	}
+	return svc, nil
+}
+
+func (s *service) snapshot() *serviceState {
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+	state := &serviceState{
+		Primary:       newVisitRecordsState(s.primaryCMS, s.primaryTopK),
+		TotalCount:    s.totalCount,
+		Rotations:     s.rotations,
+		CountSnapshot: append([]int(nil), s.countSnapshot...),
+	}
+	if s.secondaryCMS != nil && s.secondaryTopK != nil {
+		state.Secondary = newVisitRecordsState(s.secondaryCMS, s.secondaryTopK)
+	}
+	return state
+}
+
+func newVisitRecordsState(cms *CountMinSketch, topK *topKMetrics) *visitRecordsState {
+	state := &visitRecordsState{
+		Sketch: make([][]int, len(cms.matrix)),
+		TopK:   make([]metricDataState, 0, len(topK.metricMap)),
+	}
+	for i, row := range cms.matrix {
+		state.Sketch[i] = append([]int(nil), row...)
+	}
+	for _, md := range topK.metricMap {
+		state.TopK = append(state.TopK, metricDataState{Metric: md.name, Labels: md.labels, Frequency: md.frequency})
+	}
+	return state
+}
+
+// restoreCountMinSketch only accepts sketches of the current size, since the
+// positions of the counters depend on the width.
+func restoreCountMinSketch(sketch [][]int) (*CountMinSketch, error) {
+	if len(sketch) != defaultCMSDepth {
+		return nil, fmt.Errorf("sketch depth %d does not match %d", len(sketch), defaultCMSDepth)
+	}
+	cms := NewCountMinSketch(defaultCMSDepth, defaultCMSWidth)
+	for i, row := range sketch {
+		if len(row) != defaultCMSWidth {
+			return nil, fmt.Errorf("sketch width %d does not match %d", len(row), defaultCMSWidth)
+		}
+		copy(cms.matrix[i], row)
+	}
+	return cms, nil
+}
+
+// restoreTopK keeps the most frequent keys if the drop threshold was lowered
+// since the checkpoint.
+func restoreTopK(serviceName string, entries []metricDataState, limit int) *topKMetrics {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Frequency > entries[j].Frequency
+	})
+	topK := newTopKMetrics(limit)
+	for _, entry := range entries {
+		if len(topK.metricMap) >= limit {
+			break
+		}
+		md := newMetricData(serviceName, entry.Metric, entry.Labels)
+		md.frequency = entry.Frequency
+		topK.metricMap[md.hashKey] = md
+	}
+	topK.minMetric = topK.findMinMetricLocked()
+	return topK
+}
@@ -0,0 +1,108 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cardinalitycontrol
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsapplicationsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+)
+
+func newStatefulLimiter(t *testing.T, stateFile string, threshold int) *MetricsLimiter {
+	config := &awsapplicationsignalsconfig.LimiterConfig{
+		Threshold:        threshold,
+		RotationInterval: awsapplicationsignalsconfig.DefaultRotationInterval,
+		StateFile:        stateFile,
+	}
+	config.Validate()
+	limiter := NewMetricsLimiter(config, logger).(*MetricsLimiter)
+	t.Cleanup(func() { _ = limiter.Stop(t.Context()) })
+	return limiter
+}
+
+func TestRestoreAdmittedKeys(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "limiter", "state.json")
+	limiter := newStatefulLimiter(t, stateFile, 2)
+	for i, hits := range []int{3, 3, 1} {
+		for j := 0; j < hits; j++ {
+			ok, _ := limiter.Admit("latency", newFixedAttributes(i), emptyResourceAttributes)
+			assert.Equal(t, i < 2, ok)
+		}
+	}
+	require.NoError(t, limiter.Stop(t.Context()))
+	assert.FileExists(t, stateFile)
+
+	restored := newStatefulLimiter(t, stateFile, 2)
+	// the admitted keys of the previous run are still admitted, the others are still dropped
+	for i := 0; i < 3; i++ {
+		ok, _ := restored.Admit("latency", newFixedAttributes(i), emptyResourceAttributes)
+		assert.Equal(t, i < 2, ok, i)
+	}
+	val, ok := restored.services.Load("app")
+	require.True(t, ok)
+	svc := val.(*service)
+	assert.Equal(t, 10, svc.totalCount)
+	assert.Equal(t, 2, svc.primaryCMS.Get(newMetricData("app", "latency", newFixedAttributesLabels(2))))
+}
+
+func TestRestoreWithLowerThreshold(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	limiter := newStatefulLimiter(t, stateFile, 10)
+	for i := 0; i < 5; i++ {
+		for j := 0; j <= i; j++ {
+			limiter.Admit("latency", newFixedAttributes(i), emptyResourceAttributes)
+		}
+	}
+	require.NoError(t, limiter.checkpoint())
+
+	// only the most frequent keys are kept
+	restored := newStatefulLimiter(t, stateFile, 2)
+	for i := 0; i < 5; i++ {
+		ok, _ := restored.Admit("latency", newFixedAttributes(i), emptyResourceAttributes)
+		assert.Equal(t, i >= 3, ok, i)
+	}
+}
+
+func TestRestoreInvalidState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+
+	testCases := map[string]string{
+		"Corrupted":          "{",
+		"UnsupportedVersion": `{"version":2,"services":{}}`,
+		"InvalidSketch":      `{"version":1,"services":{"app":{"primary":{"sketch":[[1,2,3]],"top_k":[]}}}}`,
+	}
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(stateFile, []byte(content), 0600))
+			limiter := newStatefulLimiter(t, stateFile, 2)
+			_, ok := limiter.services.Load("app")
+			assert.False(t, ok)
+			// the limiter starts from scratch and overwrites the state
+			ok, _ = limiter.Admit("latency", newFixedAttributes(0), emptyResourceAttributes)
+			assert.True(t, ok)
+			require.NoError(t, limiter.Stop(t.Context()))
+
+			content, err := os.ReadFile(stateFile)
+			require.NoError(t, err)
+			var state limiterState
+			require.NoError(t, json.Unmarshal(content, &state))
+			assert.Equal(t, stateVersion, state.Version)
+			assert.Contains(t, state.Services, "app")
+		})
+	}
+}
+
+func newFixedAttributesLabels(val int) map[string]string {
+	labels := map[string]string{}
+	for k, v := range newFixedAttributes(val).All() {
+		labels[k] = v.Str()
+	}
+	return labels
+}
@@ -61,6 +61,10 @@ func NewAttributesResolver(resolvers []appsignalsconfig.Resolver, logger *zap.Lo
 			subResolvers = append(subResolvers, newResourceAttributesResolver(resolver.Platform, AttributePlatformEC2, DefaultInheritedAttributes))
 		case appsignalsconfig.PlatformECS:
 			subResolvers = append(subResolvers, newECSResourceAttributesResolver(resolver.Platform, resolver.Name))
+		case appsignalsconfig.PlatformDocker:
+			subResolvers = append(subResolvers, newDockerResolver(resolver, logger))
+		case appsignalsconfig.PlatformNomad:
+			subResolvers = append(subResolvers, newNomadResolver(resolver, logger))
 		default:
 			subResolvers = append(subResolvers, newResourceAttributesResolver(resolver.Platform, AttributePlatformGeneric, GenericInheritedAttributes))
 		}
@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+)
+
+const (
+	AttributePlatformDocker = "Docker"
+
+	dockerComposeServiceLabel = "com.docker.compose.service"
+	dockerComposeProjectLabel = "com.docker.compose.project"
+)
+
+// dockerContainer is the part of the response of the container inspect API
+// that is used.
+type dockerContainer struct {
+	Name   string `json:"Name"`
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+}
+
+type dockerFetcher struct {
+	client  *http.Client
+	baseURL string
+	err     error
+}
+
+// fetch inspects the container. The service is the compose service or the
+// name of the container, and the environment the compose project, unless
+// they are set by the Application Signals labels.
+func (f *dockerFetcher) fetch(ctx context.Context, id string) (*workload, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	body, err := getJSON(ctx, f.client, f.baseURL+"/containers/"+url.PathEscape(id)+"/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	var container dockerContainer
+	if err = json.Unmarshal(body, &container); err != nil {
+		return nil, err
+	}
+	labels := container.Config.Labels
+	name := strings.TrimPrefix(container.Name, "/")
+	w := &workload{
+		service:            labels[LabelService],
+		defaultService:     labels[dockerComposeServiceLabel],
+		environment:        labels[LabelEnvironment],
+		defaultEnvironment: labels[dockerComposeProjectLabel],
+		attributes:         map[string]string{},
+	}
+	if w.defaultService == "" {
+		w.defaultService = name
+	}
+	if name != "" {
+		w.attributes[common.AttributeDockerContainer] = name
+	}
+	return w, nil
+}
+
+// newDockerResolver resolves the workload of the container.id resource
+// attribute with the docker engine API.
+func newDockerResolver(resolver config.Resolver, logger *zap.Logger) *workloadResolver {
+	fetcher := &dockerFetcher{}
+	fetcher.client, fetcher.baseURL, fetcher.err = newAPIClient(resolver.Endpoint)
+	return newWorkloadResolver(config.PlatformDocker, AttributePlatformDocker, resolver.Name, semconv.AttributeContainerID, fetcher, logger)
+}
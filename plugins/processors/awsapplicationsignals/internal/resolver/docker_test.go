@@ -0,0 +1,212 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+// newDockerStandIn serves the container inspect API for the containers.
+func newDockerStandIn(t *testing.T, containers map[string]string, requests *atomic.Int32) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /containers/{id}/json", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		container, ok := containers[r.PathValue("id")]
+		if !ok {
+			http.Error(w, `{"message":"No such container"}`, http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(container))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// fetchWorkload waits for the resolver to cache the workload of the resource,
+// which is fetched in the background.
+func fetchWorkload(r *workloadResolver, resourceAttributes pcommon.Map) {
+	if val, ok := resourceAttributes.Get(r.idKey); ok {
+		<-r.fetch(val.Str())
+	}
+}
+
+var dockerContainers = map[string]string{
+	"compose": `{"Name":"/shop-cart-1","Config":{"Labels":{"com.docker.compose.project":"shop","com.docker.compose.service":"cart"}}}`,
+	"labeled": `{"Name":"/payments","Config":{"Labels":{"aws.application_signals.service":"payment-service","aws.application_signals.environment":"prod"}}}`,
+	"plain":   `{"Name":"/worker","Config":{"Labels":{}}}`,
+}
+
+func TestDockerResolver(t *testing.T) {
+	var requests atomic.Int32
+	server := newDockerStandIn(t, dockerContainers, &requests)
+
+	testCases := map[string]struct {
+		containerID         string
+		serviceName         string
+		hostIn              string
+		expectedService     string
+		expectedEnvironment string
+		expectedContainer   string
+	}{
+		"ComposeService": {
+			containerID:         "compose",
+			expectedService:     "cart",
+			expectedEnvironment: "docker:shop",
+			expectedContainer:   "shop-cart-1",
+		},
+		"ComposeServiceWithServiceName": {
+			containerID:         "compose",
+			serviceName:         "cart-api",
+			expectedEnvironment: "docker:shop",
+			expectedContainer:   "shop-cart-1",
+		},
+		"ComposeServiceWithHostedIn": {
+			containerID:         "compose",
+			hostIn:              "staging",
+			expectedService:     "cart",
+			expectedEnvironment: "docker:staging",
+			expectedContainer:   "shop-cart-1",
+		},
+		"Labels": {
+			containerID:         "labeled",
+			serviceName:         "payments-api",
+			hostIn:              "staging",
+			expectedService:     "payment-service",
+			expectedEnvironment: "prod",
+			expectedContainer:   "payments",
+		},
+		"ContainerName": {
+			containerID:         "plain",
+			serviceName:         "unknown_service:java",
+			expectedService:     "worker",
+			expectedEnvironment: "docker:default",
+			expectedContainer:   "worker",
+		},
+		"UnknownContainer": {
+			containerID:         "missing",
+			expectedEnvironment: "docker:default",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resolver := newDockerResolver(config.NewDockerResolver(testCase.hostIn, server.URL), zap.NewNop())
+			attributes := pcommon.NewMap()
+			resourceAttributes := pcommon.NewMap()
+			resourceAttributes.PutStr(semconv.AttributeContainerID, testCase.containerID)
+			if testCase.serviceName != "" {
+				resourceAttributes.PutStr(semconv.AttributeServiceName, testCase.serviceName)
+			}
+			fetchWorkload(resolver, resourceAttributes)
+			require.NoError(t, resolver.Process(attributes, resourceAttributes))
+
+			service, ok := attributes.Get(attr.AWSLocalService)
+			assert.Equal(t, testCase.expectedService != "", ok)
+			assert.Equal(t, testCase.expectedService, service.Str())
+			environment, _ := attributes.Get(attr.AWSLocalEnvironment)
+			assert.Equal(t, testCase.expectedEnvironment, environment.Str())
+			container, _ := attributes.Get(common.AttributeDockerContainer)
+			assert.Equal(t, testCase.expectedContainer, container.Str())
+			platform, _ := attributes.Get(common.AttributePlatformType)
+			assert.Equal(t, AttributePlatformDocker, platform.Str())
+			assert.NoError(t, resolver.Stop(t.Context()))
+		})
+	}
+}
+
+func TestDockerResolverCache(t *testing.T) {
+	var requests atomic.Int32
+	server := newDockerStandIn(t, dockerContainers, &requests)
+	resolver := newDockerResolver(config.NewDockerResolver("", server.URL), zap.NewNop())
+
+	for _, id := range []string{"compose", "compose", "missing", "missing"} {
+		resourceAttributes := pcommon.NewMap()
+		resourceAttributes.PutStr(semconv.AttributeContainerID, id)
+		require.NoError(t, resolver.Process(pcommon.NewMap(), resourceAttributes))
+		fetchWorkload(resolver, resourceAttributes)
+	}
+	// the containers that are not found are cached as well
+	assert.EqualValues(t, 2, requests.Load())
+
+	// without a container id, the docker API is not called
+	attributes := pcommon.NewMap()
+	require.NoError(t, resolver.Process(attributes, pcommon.NewMap()))
+	assert.EqualValues(t, 2, requests.Load())
+	environment, _ := attributes.Get(attr.AWSLocalEnvironment)
+	assert.Equal(t, "docker:default", environment.Str())
+}
+
+func TestDockerResolverDoesNotWaitForTheAPI(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		<-release
+		_, _ = w.Write([]byte(dockerContainers["compose"]))
+	}))
+	defer server.Close()
+	resolver := newDockerResolver(config.NewDockerResolver("", server.URL), zap.NewNop())
+	resourceAttributes := pcommon.NewMap()
+	resourceAttributes.PutStr(semconv.AttributeContainerID, "compose")
+
+	// the data points are not resolved while the API has not responded
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attributes := pcommon.NewMap()
+			assert.NoError(t, resolver.Process(attributes, resourceAttributes))
+			_, ok := attributes.Get(attr.AWSLocalService)
+			assert.False(t, ok)
+		}()
+	}
+	wg.Wait()
+	close(release)
+	fetchWorkload(resolver, resourceAttributes)
+	assert.EqualValues(t, 1, requests.Load())
+
+	attributes := pcommon.NewMap()
+	require.NoError(t, resolver.Process(attributes, resourceAttributes))
+	service, _ := attributes.Get(attr.AWSLocalService)
+	assert.Equal(t, "cart", service.Str())
+	assert.NoError(t, resolver.Stop(t.Context()))
+}
+
+func TestDockerResolverUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	var requests atomic.Int32
+	server := httptest.NewUnstartedServer(newDockerStandIn(t, dockerContainers, &requests).Config.Handler)
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	resolver := newDockerResolver(config.NewDockerResolver("", "unix://"+socket), zap.NewNop())
+	attributes := pcommon.NewMap()
+	resourceAttributes := pcommon.NewMap()
+	resourceAttributes.PutStr(semconv.AttributeContainerID, "compose")
+	fetchWorkload(resolver, resourceAttributes)
+	require.NoError(t, resolver.Process(attributes, resourceAttributes))
+	service, _ := attributes.Get(attr.AWSLocalService)
+	assert.Equal(t, "cart", service.Str())
+	assert.EqualValues(t, 1, requests.Load())
+}
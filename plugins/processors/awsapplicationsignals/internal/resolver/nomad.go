@@ -0,0 +1,102 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+const (
+	AttributePlatformNomad = "Nomad"
+
+	// nomadTokenEnvVar is the ACL token of the nomad CLI, which is also used
+	// for the requests to the nomad agent.
+	nomadTokenEnvVar = "NOMAD_TOKEN"
+)
+
+// nomadAllocation is the part of the response of the allocation API that is
+// used.
+type nomadAllocation struct {
+	ID        string `json:"ID"`
+	Namespace string `json:"Namespace"`
+	JobID     string `json:"JobID"`
+	TaskGroup string `json:"TaskGroup"`
+	Job       struct {
+		Meta       map[string]string `json:"Meta"`
+		TaskGroups []struct {
+			Name string            `json:"Name"`
+			Meta map[string]string `json:"Meta"`
+		} `json:"TaskGroups"`
+	} `json:"Job"`
+}
+
+// meta returns the meta of the task group of the allocation, which overrides
+// the meta of the job.
+func (a *nomadAllocation) meta(key string) string {
+	for _, group := range a.Job.TaskGroups {
+		if group.Name == a.TaskGroup {
+			if value := group.Meta[key]; value != "" {
+				return value
+			}
+		}
+	}
+	return a.Job.Meta[key]
+}
+
+type nomadFetcher struct {
+	client  *http.Client
+	baseURL string
+	err     error
+}
+
+// fetch gets the allocation. The service is the job and the environment the
+// namespace, unless they are set by the Application Signals meta of the task
+// group or the job.
+func (f *nomadFetcher) fetch(ctx context.Context, id string) (*workload, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	header := http.Header{}
+	if token := os.Getenv(nomadTokenEnvVar); token != "" {
+		header.Set("X-Nomad-Token", token)
+	}
+	body, err := getJSON(ctx, f.client, f.baseURL+"/v1/allocation/"+url.PathEscape(id), header)
+	if err != nil {
+		return nil, err
+	}
+	var alloc nomadAllocation
+	if err = json.Unmarshal(body, &alloc); err != nil {
+		return nil, err
+	}
+	return &workload{
+		service:            alloc.meta(LabelService),
+		defaultService:     alloc.JobID,
+		environment:        alloc.meta(LabelEnvironment),
+		defaultEnvironment: alloc.Namespace,
+		attributes: map[string]string{
+			common.AttributeNomadNamespace: alloc.Namespace,
+			common.AttributeNomadJob:       alloc.JobID,
+			common.AttributeNomadTaskGroup: alloc.TaskGroup,
+			common.AttributeNomadAllocID:   alloc.ID,
+		},
+	}, nil
+}
+
+// newNomadResolver resolves the workload of the nomad.alloc.id resource
+// attribute with the API of the local nomad agent.
+func newNomadResolver(resolver config.Resolver, logger *zap.Logger) *workloadResolver {
+	fetcher := &nomadFetcher{}
+	fetcher.client, fetcher.baseURL, fetcher.err = newAPIClient(resolver.Endpoint)
+	return newWorkloadResolver(config.PlatformNomad, AttributePlatformNomad, resolver.Name, attr.NomadAllocID, fetcher, logger)
+}
@@ -0,0 +1,129 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+var nomadAllocations = map[string]string{
+	"a1": `{"ID":"a1","Namespace":"default","JobID":"shop","TaskGroup":"cart","Job":{"Meta":{},"TaskGroups":[{"Name":"cart","Meta":null}]}}`,
+	"a2": `{"ID":"a2","Namespace":"prod","JobID":"shop","TaskGroup":"payments","Job":{"Meta":{"aws.application_signals.service":"shop-service","aws.application_signals.environment":"shop-prod"},"TaskGroups":[{"Name":"cart","Meta":null},{"Name":"payments","Meta":{"aws.application_signals.service":"payment-service"}}]}}`,
+}
+
+func TestNomadResolver(t *testing.T) {
+	t.Setenv(nomadTokenEnvVar, "secret")
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/allocation/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Nomad-Token") != "secret" {
+			http.Error(w, "Permission denied", http.StatusForbidden)
+			return
+		}
+		alloc, ok := nomadAllocations[r.PathValue("id")]
+		if !ok {
+			http.Error(w, "alloc not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(alloc))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testCases := map[string]struct {
+		allocID             string
+		serviceName         string
+		expectedService     string
+		expectedEnvironment string
+		expectedAttributes  map[string]string
+	}{
+		"Job": {
+			allocID:             "a1",
+			expectedService:     "shop",
+			expectedEnvironment: "nomad:default",
+			expectedAttributes: map[string]string{
+				common.AttributeNomadNamespace: "default",
+				common.AttributeNomadJob:       "shop",
+				common.AttributeNomadTaskGroup: "cart",
+				common.AttributeNomadAllocID:   "a1",
+			},
+		},
+		"JobWithServiceName": {
+			allocID:             "a1",
+			serviceName:         "cart",
+			expectedEnvironment: "nomad:default",
+		},
+		"GroupAndJobMeta": {
+			allocID:             "a2",
+			serviceName:         "payments",
+			expectedService:     "payment-service",
+			expectedEnvironment: "shop-prod",
+			expectedAttributes: map[string]string{
+				common.AttributeNomadNamespace: "prod",
+				common.AttributeNomadJob:       "shop",
+				common.AttributeNomadTaskGroup: "payments",
+				common.AttributeNomadAllocID:   "a2",
+			},
+		},
+		"UnknownAllocation": {
+			allocID:             "a3",
+			expectedEnvironment: "nomad:default",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resolver := newNomadResolver(config.NewNomadResolver("", server.URL), zap.NewNop())
+			attributes := pcommon.NewMap()
+			resourceAttributes := pcommon.NewMap()
+			resourceAttributes.PutStr(attr.NomadAllocID, testCase.allocID)
+			if testCase.serviceName != "" {
+				resourceAttributes.PutStr(semconv.AttributeServiceName, testCase.serviceName)
+			}
+			fetchWorkload(resolver, resourceAttributes)
+			require.NoError(t, resolver.Process(attributes, resourceAttributes))
+
+			service, ok := attributes.Get(attr.AWSLocalService)
+			assert.Equal(t, testCase.expectedService != "", ok)
+			assert.Equal(t, testCase.expectedService, service.Str())
+			environment, _ := attributes.Get(attr.AWSLocalEnvironment)
+			assert.Equal(t, testCase.expectedEnvironment, environment.Str())
+			for key, value := range testCase.expectedAttributes {
+				actual, _ := attributes.Get(key)
+				assert.Equal(t, value, actual.Str(), key)
+			}
+			platform, _ := attributes.Get(common.AttributePlatformType)
+			assert.Equal(t, AttributePlatformNomad, platform.Str())
+		})
+	}
+}
+
+func TestNomadResolverWithoutToken(t *testing.T) {
+	t.Setenv(nomadTokenEnvVar, "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("X-Nomad-Token"))
+		_, _ = w.Write([]byte(nomadAllocations["a1"]))
+	}))
+	defer server.Close()
+
+	resolver := newNomadResolver(config.NewNomadResolver("cluster", server.URL), zap.NewNop())
+	attributes := pcommon.NewMap()
+	resourceAttributes := pcommon.NewMap()
+	resourceAttributes.PutStr(attr.NomadAllocID, "a1")
+	fetchWorkload(resolver, resourceAttributes)
+	require.NoError(t, resolver.Process(attributes, resourceAttributes))
+	// hosted_in takes precedence over the namespace
+	environment, _ := attributes.Get(attr.AWSLocalEnvironment)
+	assert.Equal(t, "nomad:cluster", environment.Str())
+}
@@ -0,0 +1,252 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+const (
+	// The labels of docker containers and the meta of nomad jobs and groups
+	// that set the service and the environment of the workload.
+	LabelService     = "aws.application_signals.service"
+	LabelEnvironment = "aws.application_signals.environment"
+
+	workloadCacheTTL        = 5 * time.Minute
+	workloadFailureCacheTTL = 1 * time.Minute
+	workloadCacheCapacity   = 1000
+	workloadRequestTimeout  = 2 * time.Second
+
+	unknownService       = "UnknownService"
+	unknownServicePrefix = "unknown_service"
+)
+
+// workload is the identity of the container or allocation that is the source
+// of a span or metric.
+type workload struct {
+	// service is set explicitly by a label and always used.
+	service string
+	// defaultService is derived from the workload and only used if the
+	// application has no service name.
+	defaultService string
+	// environment is set explicitly by a label.
+	environment string
+	// defaultEnvironment is derived from the workload, e.g. the compose
+	// project, and used if no environment is set otherwise.
+	defaultEnvironment string
+	// attributes are the platform attributes of the workload.
+	attributes map[string]string
+}
+
+type workloadFetcher interface {
+	fetch(ctx context.Context, id string) (*workload, error)
+}
+
+// workloadResolver resolves the service and the environment of the workload
+// that is identified by a resource attribute with the local API of the
+// platform. The workloads are fetched in the background and cached, including
+// the ones that could not be fetched, so that the data points are not held
+// up by the API. The data points of a workload are not resolved until it is
+// cached.
+type workloadResolver struct {
+	resourceAttributesResolver
+	logger  *zap.Logger
+	hostIn  string
+	idKey   string
+	fetcher workloadFetcher
+	cache   *ttlcache.Cache[string, *workload]
+	// fetches makes the data points that miss the cache at the same time
+	// wait for a single fetch of the workload.
+	fetches singleflight.Group
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func newWorkloadResolver(defaultEnvPrefix, platformType, hostIn, idKey string, fetcher workloadFetcher, logger *zap.Logger) *workloadResolver {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &workloadResolver{
+		resourceAttributesResolver: resourceAttributesResolver{
+			defaultEnvPrefix: defaultEnvPrefix,
+			platformType:     platformType,
+			attributeMap:     DefaultInheritedAttributes,
+		},
+		logger:  logger,
+		hostIn:  hostIn,
+		idKey:   idKey,
+		fetcher: fetcher,
+		cache: ttlcache.New[string, *workload](
+			ttlcache.WithTTL[string, *workload](workloadCacheTTL),
+			ttlcache.WithCapacity[string, *workload](workloadCacheCapacity),
+			ttlcache.WithDisableTouchOnHit[string, *workload](),
+		),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (r *workloadResolver) Process(attributes, resourceAttributes pcommon.Map) error {
+	for attrKey, mappingKey := range r.attributeMap {
+		if val, ok := resourceAttributes.Get(attrKey); ok {
+			attributes.PutStr(mappingKey, val.Str())
+		}
+	}
+
+	w := r.getWorkload(resourceAttributes)
+	if w != nil {
+		if w.service != "" {
+			attributes.PutStr(attr.AWSLocalService, w.service)
+		} else if w.defaultService != "" && isUnknownService(attributes, resourceAttributes) {
+			attributes.PutStr(attr.AWSLocalService, w.defaultService)
+		}
+		for key, value := range w.attributes {
+			attributes.PutStr(key, value)
+		}
+	}
+	attributes.PutStr(attr.AWSLocalEnvironment, r.getLocalEnvironment(attributes, resourceAttributes, w))
+	attributes.PutStr(common.AttributePlatformType, r.platformType)
+	return nil
+}
+
+// getLocalEnvironment determines the environment based on the following priority:
+// 1. aws.local.environment (from deployment.environment)
+// 2. aws.hostedin.environment
+// 3. the environment label of the workload
+// 4. hosted_in (user-specified)
+// 5. the environment derived from the workload
+// 6. Hardcoded `default`
+func (r *workloadResolver) getLocalEnvironment(attributes, resourceAttributes pcommon.Map, w *workload) string {
+	if val, ok := attributes.Get(attr.AWSLocalEnvironment); ok {
+		return val.Str()
+	}
+	if val, found := resourceAttributes.Get(attr.AWSHostedInEnvironment); found {
+		return val.Str()
+	}
+	if w != nil && w.environment != "" {
+		return w.environment
+	}
+	if r.hostIn != "" {
+		return generateLocalEnvironment(r.defaultEnvPrefix, r.hostIn)
+	}
+	if w != nil && w.defaultEnvironment != "" {
+		return generateLocalEnvironment(r.defaultEnvPrefix, w.defaultEnvironment)
+	}
+	return generateLocalEnvironment(r.defaultEnvPrefix, AttributeEnvironmentDefault)
+}
+
+func (r *workloadResolver) getWorkload(resourceAttributes pcommon.Map) *workload {
+	val, ok := resourceAttributes.Get(r.idKey)
+	if !ok || val.Str() == "" {
+		return nil
+	}
+	id := val.Str()
+	if item := r.cache.Get(id); item != nil {
+		return item.Value()
+	}
+	r.fetch(id)
+	return nil
+}
+
+// fetch starts fetching the workload in the background unless it is already
+// being fetched. The returned channel receives a result once the workload is
+// cached.
+func (r *workloadResolver) fetch(id string) <-chan singleflight.Result {
+	return r.fetches.DoChan(id, func() (interface{}, error) {
+		// the workload may have been cached since the caller missed it
+		if r.cache.Has(id) || r.ctx.Err() != nil {
+			return nil, nil
+		}
+		ctx, cancel := context.WithTimeout(r.ctx, workloadRequestTimeout)
+		defer cancel()
+		w, err := r.fetcher.fetch(ctx, id)
+		if err != nil {
+			r.logger.Debug("failed to fetch the workload", zap.String("platform", r.defaultEnvPrefix), zap.String("id", id), zap.Error(err))
+			r.cache.Set(id, nil, workloadFailureCacheTTL)
+			return nil, nil
+		}
+		r.cache.Set(id, w, ttlcache.DefaultTTL)
+		return nil, nil
+	})
+}
+
+func (r *workloadResolver) Stop(_ context.Context) error {
+	r.cancel()
+	r.cache.DeleteAll()
+	return nil
+}
+
+// isUnknownService returns whether the application did not set a service name.
+func isUnknownService(attributes, resourceAttributes pcommon.Map) bool {
+	if val, ok := attributes.Get(attr.AWSLocalService); ok && val.Str() != unknownService && val.Str() != "" {
+		return false
+	}
+	if val, ok := resourceAttributes.Get(semconv.AttributeServiceName); ok && val.Str() != "" && !strings.HasPrefix(val.Str(), unknownServicePrefix) {
+		return false
+	}
+	return true
+}
+
+// newAPIClient returns the client and the base URL of a local API. The
+// requests to a unix socket are sent to http://localhost.
+func newAPIClient(endpoint string) (*http.Client, string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		return &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", socket)
+				},
+			},
+		}, "http://localhost", nil
+	case "http", "https":
+		return &http.Client{}, strings.TrimSuffix(endpoint, "/"), nil
+	}
+	return nil, "", fmt.Errorf("unsupported scheme %q", u.Scheme)
+}
+
+// getJSON sends a GET request and returns the body if the response is OK.
+func getJSON(ctx context.Context, client *http.Client, url string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(resp.Status)
+	}
+	return body, nil
+}
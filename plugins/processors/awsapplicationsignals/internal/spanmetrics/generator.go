@@ -0,0 +1,316 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package spanmetrics derives the Application Signals latency, fault and error
+// metrics from spans, like the ADOT SDKs do, for services that only export
+// spans.
+package spanmetrics
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	deprecatedsemconv "go.opentelemetry.io/collector/semconv/v1.18.0"
+	semconv "go.opentelemetry.io/collector/semconv/v1.25.0"
+
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+const (
+	// The metric names of the ADOT SDKs. The processor capitalizes them.
+	MetricLatency = "latency"
+	MetricFault   = "fault"
+	MetricError   = "error"
+
+	scopeName = "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/spanmetrics"
+
+	unknownService         = "UnknownService"
+	unknownOperation       = "UnknownOperation"
+	unknownRemoteService   = "UnknownRemoteService"
+	unknownRemoteOperation = "UnknownRemoteOperation"
+	internalOperation      = "InternalOperation"
+	unknownServicePrefix   = "unknown_service"
+)
+
+// datapoint aggregates the spans of a resource with the same metric attributes.
+type datapoint struct {
+	attributes pcommon.Map
+	start      pcommon.Timestamp
+	latency    histogram
+	fault      histogram
+	err        histogram
+}
+
+type histogram struct {
+	count    uint64
+	sum      float64
+	min, max float64
+}
+
+func (h *histogram) record(value float64) {
+	if h.count == 0 || value < h.min {
+		h.min = value
+	}
+	if h.count == 0 || value > h.max {
+		h.max = value
+	}
+	h.count++
+	h.sum += value
+}
+
+// Generator derives delta histograms from the server, consumer, client and
+// producer spans. Spans that already carry aws.span.kind are skipped, their
+// metrics are sent by the ADOT SDK that produced them.
+type Generator struct {
+	now func() time.Time
+}
+
+func NewGenerator() *Generator {
+	return &Generator{now: time.Now}
+}
+
+// Generate returns the metrics of the spans. The metrics keep the resource
+// attributes of the spans, so that the attributes can be resolved and
+// normalized like the metrics of the ADOT SDKs.
+func (g *Generator) Generate(td ptrace.Traces) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	now := pcommon.NewTimestampFromTime(g.now())
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceAttributes := rs.Resource().Attributes()
+		datapoints := map[string]*datapoint{}
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				g.record(datapoints, spans.At(k), resourceAttributes)
+			}
+		}
+		if len(datapoints) == 0 {
+			continue
+		}
+		rm := md.ResourceMetrics().AppendEmpty()
+		resourceAttributes.CopyTo(rm.Resource().Attributes())
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(scopeName)
+		appendMetrics(sm.Metrics(), datapoints, now)
+	}
+	return md
+}
+
+func (g *Generator) record(datapoints map[string]*datapoint, span ptrace.Span, resourceAttributes pcommon.Map) {
+	if _, ok := span.Attributes().Get(attr.AWSSpanKind); ok {
+		return
+	}
+	attributes := pcommon.NewMap()
+	switch span.Kind() {
+	case ptrace.SpanKindServer, ptrace.SpanKindConsumer:
+		attributes.PutStr(attr.AWSSpanKind, spanKind(span.Kind()))
+		attributes.PutStr(attr.AWSLocalService, localService(resourceAttributes))
+		attributes.PutStr(attr.AWSLocalOperation, localOperation(span))
+	case ptrace.SpanKindClient, ptrace.SpanKindProducer:
+		attributes.PutStr(attr.AWSSpanKind, spanKind(span.Kind()))
+		attributes.PutStr(attr.AWSLocalService, localService(resourceAttributes))
+		attributes.PutStr(attr.AWSLocalOperation, internalOperation)
+		attributes.PutStr(attr.AWSRemoteService, remoteService(span))
+		attributes.PutStr(attr.AWSRemoteOperation, remoteOperation(span))
+	default:
+		return
+	}
+
+	key := attributesKey(attributes)
+	dp, ok := datapoints[key]
+	if !ok {
+		dp = &datapoint{attributes: attributes, start: span.StartTimestamp()}
+		datapoints[key] = dp
+	}
+	if span.StartTimestamp() < dp.start {
+		dp.start = span.StartTimestamp()
+	}
+
+	latency := float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond)
+	if latency < 0 {
+		latency = 0
+	}
+	dp.latency.record(latency)
+	fault, isError := classify(span)
+	dp.fault.record(boolToFloat(fault))
+	dp.err.record(boolToFloat(isError))
+}
+
+func appendMetrics(metrics pmetric.MetricSlice, datapoints map[string]*datapoint, now pcommon.Timestamp) {
+	keys := make([]string, 0, len(datapoints))
+	for key := range datapoints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, m := range []struct {
+		name  string
+		unit  string
+		value func(*datapoint) histogram
+	}{
+		{name: MetricLatency, unit: "ms", value: func(dp *datapoint) histogram { return dp.latency }},
+		{name: MetricFault, unit: "1", value: func(dp *datapoint) histogram { return dp.fault }},
+		{name: MetricError, unit: "1", value: func(dp *datapoint) histogram { return dp.err }},
+	} {
+		metric := metrics.AppendEmpty()
+		metric.SetName(m.name)
+		metric.SetUnit(m.unit)
+		hist := metric.SetEmptyHistogram()
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		for _, key := range keys {
+			dp := datapoints[key]
+			value := m.value(dp)
+			hdp := hist.DataPoints().AppendEmpty()
+			dp.attributes.CopyTo(hdp.Attributes())
+			hdp.SetStartTimestamp(dp.start)
+			hdp.SetTimestamp(now)
+			hdp.SetCount(value.count)
+			hdp.SetSum(value.sum)
+			hdp.SetMin(value.min)
+			hdp.SetMax(value.max)
+			hdp.BucketCounts().Append(value.count)
+		}
+	}
+}
+
+// classify returns whether the span is a fault or an error. A fault is a
+// server side failure, an error is a client side failure.
+func classify(span ptrace.Span) (fault bool, isError bool) {
+	if code, ok := statusCode(span.Attributes()); ok {
+		switch {
+		case code >= 500 && code <= 599:
+			return true, false
+		case code >= 400 && code <= 499:
+			return false, true
+		}
+	}
+	return span.Status().Code() == ptrace.StatusCodeError, false
+}
+
+func statusCode(attributes pcommon.Map) (int64, bool) {
+	for _, key := range []string{semconv.AttributeHTTPResponseStatusCode, deprecatedsemconv.AttributeHTTPStatusCode} {
+		value, ok := attributes.Get(key)
+		if !ok {
+			continue
+		}
+		switch value.Type() {
+		case pcommon.ValueTypeInt:
+			return value.Int(), true
+		case pcommon.ValueTypeStr:
+			if code, err := strconv.ParseInt(value.Str(), 10, 64); err == nil {
+				return code, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func spanKind(kind ptrace.SpanKind) string {
+	return strings.ToUpper(kind.String())
+}
+
+func localService(resourceAttributes pcommon.Map) string {
+	if value, ok := resourceAttributes.Get(semconv.AttributeServiceName); ok && value.Str() != "" && !strings.HasPrefix(value.Str(), unknownServicePrefix) {
+		return value.Str()
+	}
+	return unknownService
+}
+
+// localOperation is the route of an HTTP server span, e.g. "GET /users/:id",
+// or the name of the span.
+func localOperation(span ptrace.Span) string {
+	attributes := span.Attributes()
+	method := getStr(attributes, semconv.AttributeHTTPRequestMethod, deprecatedsemconv.AttributeHTTPMethod)
+	if route := getStr(attributes, semconv.AttributeHTTPRoute); method != "" && route != "" {
+		return method + " " + route
+	}
+	if span.Name() != "" {
+		return span.Name()
+	}
+	return unknownOperation
+}
+
+// remoteService is the service the span calls. The semantic conventions are
+// checked from the most to the least specific one.
+func remoteService(span ptrace.Span) string {
+	attributes := span.Attributes()
+	if service := getStr(attributes, semconv.AttributePeerService, semconv.AttributeRPCService, semconv.AttributeDBSystem, semconv.AttributeMessagingSystem); service != "" {
+		return service
+	}
+	if address := getStr(attributes, semconv.AttributeServerAddress, deprecatedsemconv.AttributeNetPeerName); address != "" {
+		if port := getStr(attributes, semconv.AttributeServerPort, deprecatedsemconv.AttributeNetPeerPort); port != "" {
+			return address + ":" + port
+		}
+		return address
+	}
+	if u, err := url.Parse(getStr(attributes, semconv.AttributeURLFull, deprecatedsemconv.AttributeHTTPURL)); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return unknownRemoteService
+}
+
+// remoteOperation is the operation the span calls. The operation of an HTTP
+// client span is the method and the first segment of the path, e.g.
+// "GET /users", since the full path is usually of high cardinality.
+func remoteOperation(span ptrace.Span) string {
+	attributes := span.Attributes()
+	if operation := getStr(attributes, semconv.AttributeRPCMethod, semconv.AttributeDBOperation, semconv.AttributeMessagingOperation); operation != "" {
+		return operation
+	}
+	if method := getStr(attributes, semconv.AttributeHTTPRequestMethod, deprecatedsemconv.AttributeHTTPMethod); method != "" {
+		return method + " " + firstPathSegment(urlPath(attributes))
+	}
+	return unknownRemoteOperation
+}
+
+func urlPath(attributes pcommon.Map) string {
+	if path := getStr(attributes, semconv.AttributeURLPath, deprecatedsemconv.AttributeHTTPTarget); path != "" {
+		return path
+	}
+	if u, err := url.Parse(getStr(attributes, semconv.AttributeURLFull, deprecatedsemconv.AttributeHTTPURL)); err == nil {
+		return u.Path
+	}
+	return ""
+}
+
+func firstPathSegment(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+	return "/" + segments[0]
+}
+
+// getStr returns the value of the first key that is set.
+func getStr(attributes pcommon.Map, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := attributes.Get(key); ok && value.AsString() != "" {
+			return value.AsString()
+		}
+	}
+	return ""
+}
+
+func attributesKey(attributes pcommon.Map) string {
+	var b strings.Builder
+	for _, key := range []string{attr.AWSSpanKind, attr.AWSLocalService, attr.AWSLocalOperation, attr.AWSRemoteService, attr.AWSRemoteOperation} {
+		if value, ok := attributes.Get(key); ok {
+			fmt.Fprintf(&b, "%q=%q;", key, value.Str())
+		}
+	}
+	return b.String()
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
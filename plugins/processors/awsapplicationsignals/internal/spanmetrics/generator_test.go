@@ -0,0 +1,160 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spanmetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+var testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func addSpan(spans ptrace.SpanSlice, kind ptrace.SpanKind, name string, latency time.Duration, attributes map[string]any) ptrace.Span {
+	span := spans.AppendEmpty()
+	span.SetKind(kind)
+	span.SetName(name)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(testStart))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(testStart.Add(latency)))
+	_ = span.Attributes().FromRaw(attributes)
+	return span
+}
+
+func getStrAttribute(t *testing.T, attributes pcommon.Map, key string) string {
+	value, ok := attributes.Get(key)
+	require.True(t, ok, key)
+	return value.Str()
+}
+
+func TestGenerate(t *testing.T) {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	addSpan(spans, ptrace.SpanKindServer, "GET /orders/{id}", 10*time.Millisecond, map[string]any{"http.request.method": "GET", "http.route": "/orders/{id}", "http.response.status_code": 200})
+	addSpan(spans, ptrace.SpanKindServer, "GET /orders/{id}", 30*time.Millisecond, map[string]any{"http.request.method": "GET", "http.route": "/orders/{id}", "http.response.status_code": 503})
+	addSpan(spans, ptrace.SpanKindServer, "GET /orders/{id}", 20*time.Millisecond, map[string]any{"http.request.method": "GET", "http.route": "/orders/{id}", "http.response.status_code": 404})
+	addSpan(spans, ptrace.SpanKindClient, "SELECT", 5*time.Millisecond, map[string]any{"db.system": "postgresql", "db.operation": "SELECT"}).Status().SetCode(ptrace.StatusCodeError)
+	addSpan(spans, ptrace.SpanKindInternal, "render", time.Millisecond, nil)
+	// spans of ADOT SDKs are skipped
+	addSpan(spans, ptrace.SpanKindServer, "GET /", time.Millisecond, map[string]any{attr.AWSSpanKind: "SERVER"})
+
+	generator := &Generator{now: func() time.Time { return testStart.Add(time.Minute) }}
+	md := generator.Generate(traces)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{"service.name": "checkout"}, rm.Resource().Attributes().AsRaw())
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+
+	expected := map[string]map[string]struct {
+		count         uint64
+		sum, min, max float64
+	}{
+		MetricLatency: {"SERVER": {count: 3, sum: 60, min: 10, max: 30}, "CLIENT": {count: 1, sum: 5, min: 5, max: 5}},
+		MetricFault:   {"SERVER": {count: 3, sum: 1, min: 0, max: 1}, "CLIENT": {count: 1, sum: 1, min: 1, max: 1}},
+		MetricError:   {"SERVER": {count: 3, sum: 1, min: 0, max: 1}, "CLIENT": {count: 1, sum: 0, min: 0, max: 0}},
+	}
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		require.Equal(t, pmetric.MetricTypeHistogram, metric.Type())
+		assert.Equal(t, pmetric.AggregationTemporalityDelta, metric.Histogram().AggregationTemporality())
+		dps := metric.Histogram().DataPoints()
+		require.Equal(t, 2, dps.Len(), metric.Name())
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			kind := getStrAttribute(t, dp.Attributes(), attr.AWSSpanKind)
+			want := expected[metric.Name()][kind]
+			assert.Equal(t, want.count, dp.Count(), metric.Name()+" "+kind)
+			assert.Equal(t, want.sum, dp.Sum(), metric.Name()+" "+kind)
+			assert.Equal(t, want.min, dp.Min(), metric.Name()+" "+kind)
+			assert.Equal(t, want.max, dp.Max(), metric.Name()+" "+kind)
+			assert.Equal(t, pcommon.NewTimestampFromTime(testStart), dp.StartTimestamp())
+			assert.Equal(t, pcommon.NewTimestampFromTime(testStart.Add(time.Minute)), dp.Timestamp())
+			assert.Equal(t, "checkout", getStrAttribute(t, dp.Attributes(), attr.AWSLocalService))
+			switch kind {
+			case "SERVER":
+				assert.Equal(t, "GET /orders/{id}", getStrAttribute(t, dp.Attributes(), attr.AWSLocalOperation))
+				_, ok := dp.Attributes().Get(attr.AWSRemoteService)
+				assert.False(t, ok)
+			case "CLIENT":
+				assert.Equal(t, "InternalOperation", getStrAttribute(t, dp.Attributes(), attr.AWSLocalOperation))
+				assert.Equal(t, "postgresql", getStrAttribute(t, dp.Attributes(), attr.AWSRemoteService))
+				assert.Equal(t, "SELECT", getStrAttribute(t, dp.Attributes(), attr.AWSRemoteOperation))
+			}
+		}
+	}
+}
+
+func TestGenerateWithoutSpans(t *testing.T) {
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	addSpan(spans, ptrace.SpanKindInternal, "render", time.Millisecond, nil)
+	assert.Equal(t, 0, NewGenerator().Generate(traces).MetricCount())
+}
+
+func TestRemoteAttributes(t *testing.T) {
+	testCases := map[string]struct {
+		attributes map[string]any
+		service    string
+		operation  string
+	}{
+		"PeerService": {
+			attributes: map[string]any{"peer.service": "inventory", "rpc.service": "Inventory", "rpc.method": "Reserve"},
+			service:    "inventory",
+			operation:  "Reserve",
+		},
+		"HTTP": {
+			attributes: map[string]any{"http.request.method": "POST", "server.address": "api.example.com", "server.port": 8443, "url.full": "https://api.example.com:8443/users/42/orders?limit=1"},
+			service:    "api.example.com:8443",
+			operation:  "POST /users",
+		},
+		"DeprecatedHTTP": {
+			attributes: map[string]any{"http.method": "GET", "http.url": "http://example.com/health"},
+			service:    "example.com",
+			operation:  "GET /health",
+		},
+		"Messaging": {
+			attributes: map[string]any{"messaging.system": "kafka", "messaging.operation": "publish"},
+			service:    "kafka",
+			operation:  "publish",
+		},
+		"Unknown": {
+			service:   "UnknownRemoteService",
+			operation: "UnknownRemoteOperation",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			span := addSpan(ptrace.NewSpanSlice(), ptrace.SpanKindClient, "call", time.Millisecond, testCase.attributes)
+			assert.Equal(t, testCase.service, remoteService(span))
+			assert.Equal(t, testCase.operation, remoteOperation(span))
+		})
+	}
+}
+
+func TestLocalAttributes(t *testing.T) {
+	resourceAttributes := pcommon.NewMap()
+	assert.Equal(t, "UnknownService", localService(resourceAttributes))
+	resourceAttributes.PutStr("service.name", "unknown_service:java")
+	assert.Equal(t, "UnknownService", localService(resourceAttributes))
+	resourceAttributes.PutStr("service.name", "checkout")
+	assert.Equal(t, "checkout", localService(resourceAttributes))
+
+	span := addSpan(ptrace.NewSpanSlice(), ptrace.SpanKindServer, "GET", time.Millisecond, map[string]any{"http.method": "GET"})
+	assert.Equal(t, "GET", localOperation(span))
+	span.Attributes().PutStr("http.route", "/users/:id")
+	assert.Equal(t, "GET /users/:id", localOperation(span))
+	span.Attributes().Clear()
+	span.SetName("")
+	assert.Equal(t, "UnknownOperation", localOperation(span))
+}
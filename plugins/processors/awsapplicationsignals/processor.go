@@ -5,6 +5,7 @@ package awsapplicationsignals
 
 import (
 	"context"
+	"sync"
 	"unicode"
 
 	"go.opentelemetry.io/collector/component"
@@ -20,6 +21,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/metrichandlers"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/normalizer"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/resolver"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/spanmetrics"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
 )
 
@@ -42,15 +44,18 @@ type stopper interface {
 }
 
 type awsapplicationsignalsprocessor struct {
+	id                 component.ID
 	logger             *zap.Logger
 	config             *appsignalsconfig.Config
-	replaceActions     *rules.ReplaceActions
+	ruleMutators       []attributesMutator
 	allowlistMutators  []allowListMutator
 	metricMutators     []attributesMutator
 	traceMutators      []attributesMutator
 	limiter            cardinalitycontrol.Limiter
 	aggregationMutator metrichandlers.AggregationMutator
 	stoppers           []stopper
+	spanMetrics        *spanmetrics.Generator
+	warnNoMetricsOnce  sync.Once
 }
 
 func (ap *awsapplicationsignalsprocessor) StartMetrics(ctx context.Context, _ component.Host) error {
@@ -69,29 +74,41 @@ func (ap *awsapplicationsignalsprocessor) StartMetrics(ctx context.Context, _ co
 
 	if !limiterConfig.Disabled {
 		ap.limiter = cardinalitycontrol.NewMetricsLimiter(limiterConfig, ap.logger)
+		if limiterStopper, ok := ap.limiter.(stopper); ok {
+			ap.stoppers = append(ap.stoppers, limiterStopper)
+		}
 	} else {
 		ap.logger.Info("metrics limiter is disabled.")
 	}
 
-	ap.replaceActions = rules.NewReplacer(ap.config.Rules, !limiterConfig.Disabled)
+	replacer := rules.NewReplacer(ap.config.Rules, !limiterConfig.Disabled)
+	renamer := rules.NewRenamer(ap.config.Rules, !limiterConfig.Disabled)
+	ap.ruleMutators = []attributesMutator{replacer, renamer}
 
 	pruner := metrichandlers.NewPruner()
 	keeper := rules.NewKeeper(ap.config.Rules, !limiterConfig.Disabled)
 	dropper := rules.NewDropper(ap.config.Rules)
 	ap.allowlistMutators = []allowListMutator{pruner, keeper, dropper}
 
+	ap.startRuleHitsReporter(ctx, "metrics", keeper, dropper, replacer, renamer)
+
 	ap.aggregationMutator = metrichandlers.NewAggregationMutator()
 
 	return nil
 }
 
-func (ap *awsapplicationsignalsprocessor) StartTraces(_ context.Context, _ component.Host) error {
+func (ap *awsapplicationsignalsprocessor) StartTraces(ctx context.Context, _ component.Host) error {
 	attributesResolver := resolver.NewAttributesResolver(ap.config.Resolvers, ap.logger)
 	attributesNormalizer := normalizer.NewAttributesNormalizer(ap.logger)
 	customReplacer := rules.NewReplacer(ap.config.Rules, false)
+	customRenamer := rules.NewRenamer(ap.config.Rules, false)
 
 	ap.stoppers = append(ap.stoppers, attributesResolver)
-	ap.traceMutators = append(ap.traceMutators, attributesResolver, attributesNormalizer, customReplacer)
+	ap.traceMutators = append(ap.traceMutators, attributesResolver, attributesNormalizer, customReplacer, customRenamer)
+	ap.startRuleHitsReporter(ctx, "traces", customReplacer, customRenamer)
+	if ap.config.GenerateSpanMetrics {
+		ap.spanMetrics = spanmetrics.NewGenerator()
+	}
 	return nil
 }
 
@@ -105,7 +122,11 @@ func (ap *awsapplicationsignalsprocessor) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-func (ap *awsapplicationsignalsprocessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (ap *awsapplicationsignalsprocessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	// the metrics are generated before the spans are mutated, the metrics processor resolves and normalizes them
+	if ap.spanMetrics != nil {
+		ap.sendSpanMetrics(ctx, ap.spanMetrics.Generate(td))
+	}
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
 		rs := rss.At(i)
@@ -128,6 +149,23 @@ func (ap *awsapplicationsignalsprocessor) processTraces(_ context.Context, td pt
 	return td, nil
 }
 
+// sendSpanMetrics sends the metrics generated from spans to the metrics processor of the same component.
+func (ap *awsapplicationsignalsprocessor) sendSpanMetrics(ctx context.Context, md pmetric.Metrics) {
+	if md.MetricCount() == 0 {
+		return
+	}
+	mp, ok := getMetricsProcessor(ap.id)
+	if !ok {
+		ap.warnNoMetricsOnce.Do(func() {
+			ap.logger.Warn("no metrics pipeline uses this processor, the metrics generated from spans are dropped", zap.String("processor", ap.id.String()))
+		})
+		return
+	}
+	if err := mp.ConsumeMetrics(ctx, md); err != nil {
+		ap.logger.Debug("failed to send the metrics generated from spans", zap.Error(err))
+	}
+}
+
 func (ap *awsapplicationsignalsprocessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
 	rms := md.ResourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
@@ -180,9 +218,11 @@ func (ap *awsapplicationsignalsprocessor) processMetricAttributes(_ context.Cont
 			return false
 		})
 		for i := 0; i < dps.Len(); i++ {
-			err := ap.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false)
-			if err != nil {
-				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
+			for _, mutator := range ap.ruleMutators {
+				err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false)
+				if err != nil {
+					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
+				}
 			}
 		}
 		if ap.limiter != nil {
@@ -215,9 +255,11 @@ func (ap *awsapplicationsignalsprocessor) processMetricAttributes(_ context.Cont
 			return false
 		})
 		for i := 0; i < dps.Len(); i++ {
-			err := ap.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false)
-			if err != nil {
-				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
+			for _, mutator := range ap.ruleMutators {
+				err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false)
+				if err != nil {
+					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
+				}
 			}
 		}
 		if ap.limiter != nil {
@@ -250,9 +292,11 @@ func (ap *awsapplicationsignalsprocessor) processMetricAttributes(_ context.Cont
 			return false
 		})
 		for i := 0; i < dps.Len(); i++ {
-			err := ap.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false)
-			if err != nil {
-				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
+			for _, mutator := range ap.ruleMutators {
+				err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false)
+				if err != nil {
+					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
+				}
 			}
 		}
 		if ap.limiter != nil {
@@ -285,9 +329,11 @@ func (ap *awsapplicationsignalsprocessor) processMetricAttributes(_ context.Cont
 			return false
 		})
 		for i := 0; i < dps.Len(); i++ {
-			err := ap.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false)
-			if err != nil {
-				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
+			for _, mutator := range ap.ruleMutators {
+				err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false)
+				if err != nil {
+					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
+				}
 			}
 		}
 		if ap.limiter != nil {
@@ -320,9 +366,11 @@ func (ap *awsapplicationsignalsprocessor) processMetricAttributes(_ context.Cont
 			return false
 		})
 		for i := 0; i < dps.Len(); i++ {
-			err := ap.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false)
-			if err != nil {
-				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
+			for _, mutator := range ap.ruleMutators {
+				err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false)
+				if err != nil {
+					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
+				}
 			}
 		}
 		if ap.limiter != nil {
@@ -0,0 +1,103 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awsapplicationsignals
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
+)
+
+const defaultRuleHitsReportInterval = time.Minute
+
+type ruleHitCounter interface {
+	HitCounts() []rules.RuleHits
+}
+
+// ruleHitsReporter logs how many data points or spans each rule matched, so
+// that rules that never match or match far more than expected can be found.
+// The counts are only logged when they changed since the last report.
+type ruleHitsReporter struct {
+	logger   *zap.Logger
+	signal   string
+	counters []ruleHitCounter
+	interval time.Duration
+	last     map[int]uint64
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func (ap *awsapplicationsignalsprocessor) startRuleHitsReporter(_ context.Context, signal string, counters ...ruleHitCounter) {
+	if len(ap.config.Rules) == 0 {
+		return
+	}
+	// the context of start must not be used for background work, the reporter is stopped on shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	interval := ap.config.RuleHitsReportInterval
+	if interval <= 0 {
+		interval = defaultRuleHitsReportInterval
+	}
+	reporter := &ruleHitsReporter{
+		logger:   ap.logger,
+		signal:   signal,
+		counters: counters,
+		interval: interval,
+		last:     map[int]uint64{},
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go reporter.run(ctx)
+	ap.stoppers = append(ap.stoppers, reporter)
+}
+
+func (r *ruleHitsReporter) run(ctx context.Context) {
+	defer close(r.done)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.report()
+		}
+	}
+}
+
+// Stop stops the reporter and logs the counts one last time.
+func (r *ruleHitsReporter) Stop(_ context.Context) error {
+	r.cancel()
+	<-r.done
+	r.report()
+	return nil
+}
+
+func (r *ruleHitsReporter) report() {
+	var fields []zap.Field
+	names := map[string]bool{}
+	changed := false
+	for _, counter := range r.counters {
+		for _, hits := range counter.HitCounts() {
+			if last, ok := r.last[hits.Index]; !ok || last != hits.Hits {
+				changed = true
+				r.last[hits.Index] = hits.Hits
+			}
+			// rules that share a name are logged with their index
+			name := hits.Rule
+			if names[name] {
+				name = fmt.Sprintf("%s#%d", name, hits.Index)
+			}
+			names[name] = true
+			fields = append(fields, zap.Uint64(name, hits.Hits))
+		}
+	}
+	if !changed {
+		return
+	}
+	r.logger.Info("Application Signals rule hits", append([]zap.Field{zap.String("signal", r.signal)}, fields...)...)
+}
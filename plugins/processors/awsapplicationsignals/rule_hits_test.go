@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awsapplicationsignals
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
+)
+
+func TestRuleHitsReporter(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	ap := &awsapplicationsignalsprocessor{
+		logger: zap.New(core),
+		config: &config.Config{
+			Resolvers: []config.Resolver{config.NewGenericResolver("")},
+			Rules:     testRules,
+		},
+	}
+	keeper := rules.NewKeeper(testRules, false)
+	dropper := rules.NewDropper(testRules)
+	ap.startRuleHitsReporter(context.Background(), "metrics", keeper, dropper)
+	require.Len(t, ap.stoppers, 1)
+	reporter := ap.stoppers[0].(*ruleHitsReporter)
+
+	attributes := pcommon.NewMap()
+	attributes.PutStr("dim_action", "reserved")
+	_, err := keeper.ShouldBeDropped(attributes)
+	require.NoError(t, err)
+	reporter.report()
+	// the counts did not change since the last report
+	reporter.report()
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]any{"signal": "metrics", "keep#1": uint64(1), "drop#2": uint64(0)}, logs.All()[0].ContextMap())
+
+	assert.NoError(t, ap.Shutdown(context.Background()))
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestRuleHitsReporterSharedRuleNames(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	sharedRules := []rules.Rule{
+		{Selectors: []rules.Selector{{Dimension: "Operation", Match: "GET *"}}, Action: rules.AllowListActionDrop, RuleName: "health"},
+		{Selectors: []rules.Selector{{Dimension: "Operation", Match: "HEAD *"}}, Action: rules.AllowListActionDrop, RuleName: "health"},
+	}
+	ap := &awsapplicationsignalsprocessor{
+		logger: zap.New(core),
+		config: &config.Config{
+			Resolvers:              []config.Resolver{config.NewGenericResolver("")},
+			Rules:                  sharedRules,
+			RuleHitsReportInterval: time.Hour,
+		},
+	}
+	dropper := rules.NewDropper(sharedRules)
+	ap.startRuleHitsReporter(context.Background(), "metrics", dropper)
+	require.Len(t, ap.stoppers, 1)
+	reporter := ap.stoppers[0].(*ruleHitsReporter)
+	assert.Equal(t, time.Hour, reporter.interval)
+
+	attributes := pcommon.NewMap()
+	attributes.PutStr("Operation", "HEAD /ping")
+	_, err := dropper.ShouldBeDropped(attributes)
+	require.NoError(t, err)
+	reporter.report()
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]any{"signal": "metrics", "health": uint64(0), "health#1": uint64(1)}, logs.All()[0].ContextMap())
+
+	// a change of the second rule is reported although the first has the
+	// same name and did not change
+	_, err = dropper.ShouldBeDropped(attributes)
+	require.NoError(t, err)
+	reporter.report()
+	assert.Equal(t, 2, logs.Len())
+	assert.NoError(t, ap.Shutdown(context.Background()))
+}
+
+func TestRuleHitsReporterWithoutRules(t *testing.T) {
+	ap := &awsapplicationsignalsprocessor{
+		logger: zap.NewNop(),
+		config: &config.Config{Resolvers: []config.Resolver{config.NewGenericResolver("")}},
+	}
+	ap.startRuleHitsReporter(context.Background(), "traces", rules.NewReplacer(nil, false))
+	assert.Empty(t, ap.stoppers)
+}
@@ -5,10 +5,14 @@ package rules
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"sync/atomic"
 
 	"github.com/gobwas/glob"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/capture"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
 )
@@ -19,21 +23,36 @@ const (
 	AllowListActionKeep    AllowListAction = "keep"
 	AllowListActionDrop    AllowListAction = "drop"
 	AllowListActionReplace AllowListAction = "replace"
+	AllowListActionRename  AllowListAction = "rename"
 )
 
+// Selector matches the value of a dimension with either a glob or a regular
+// expression. The regular expression must match the whole value and its named
+// captures can be used in the values of the replacements of the rule.
 type Selector struct {
-	Dimension string `mapstructure:"dimension"`
-	Match     string `mapstructure:"match"`
+	Dimension  string `mapstructure:"dimension"`
+	Match      string `mapstructure:"match,omitempty"`
+	MatchRegex string `mapstructure:"match_regex,omitempty"`
 }
 
+// Replacement sets the value of a dimension. The value can reference the named
+// captures of the regex selectors of the rule as {{name}}. The ${name} syntax
+// is not used since the agent expands it to environment variables.
 type Replacement struct {
 	TargetDimension string `mapstructure:"target_dimension"`
 	Value           string `mapstructure:"value"`
 }
 
+// Rename moves the value of a dimension to another dimension.
+type Rename struct {
+	SourceDimension string `mapstructure:"source_dimension"`
+	TargetDimension string `mapstructure:"target_dimension"`
+}
+
 type Rule struct {
 	Selectors    []Selector      `mapstructure:"selectors"`
 	Replacements []Replacement   `mapstructure:"replacements,omitempty"`
+	Renames      []Rename        `mapstructure:"renames,omitempty"`
 	Action       AllowListAction `mapstructure:"action"`
 	RuleName     string          `mapstructure:"rule_name,omitempty"`
 }
@@ -41,11 +60,26 @@ type Rule struct {
 type SelectorMatcherItem struct {
 	Key     string
 	Matcher glob.Glob
+	Regex   *regexp.Regexp
 }
 
 type ActionItem struct {
 	SelectorMatchers []SelectorMatcherItem
 	Replacements     []Replacement `mapstructure:",omitempty"`
+	Renames          []Rename      `mapstructure:",omitempty"`
+	// Name is the rule name or, if the rule has no name, the action and the
+	// position of the rule, e.g. "replace#3".
+	Name  string
+	index int
+	hits  *atomic.Uint64
+}
+
+// RuleHits is the number of data points or spans a rule matched. The names
+// of the rules are not unique, so the rules are told apart by their index.
+type RuleHits struct {
+	Index int
+	Rule  string
+	Hits  uint64
 }
 
 var traceKeyMap = map[string]string{
@@ -67,10 +101,51 @@ func GetAllowListAction(action string) (AllowListAction, error) {
 		return AllowListActionKeep, nil
 	case "replace":
 		return AllowListActionReplace, nil
+	case "rename":
+		return AllowListActionRename, nil
 	}
 	return "", errors.New("invalid action in rule")
 }
 
+// Validate checks the selectors and the actions of a rule.
+func (r Rule) Validate() error {
+	if _, err := GetAllowListAction(string(r.Action)); err != nil {
+		return err
+	}
+	for _, selector := range r.Selectors {
+		// an empty match without match_regex only matches empty values
+		if selector.Match != "" && selector.MatchRegex != "" {
+			return fmt.Errorf("selector of dimension %q must not set both match and match_regex", selector.Dimension)
+		}
+		if selector.Match != "" {
+			if _, err := glob.Compile(selector.Match); err != nil {
+				return fmt.Errorf("invalid match %q: %w", selector.Match, err)
+			}
+		}
+		if selector.MatchRegex != "" {
+			if _, err := regexp.Compile(selector.MatchRegex); err != nil {
+				return fmt.Errorf("invalid match_regex %q: %w", selector.MatchRegex, err)
+			}
+		}
+	}
+	switch r.Action {
+	case AllowListActionReplace:
+		if len(r.Replacements) == 0 {
+			return errors.New("replace action set, but no replacements defined for service rule")
+		}
+	case AllowListActionRename:
+		if len(r.Renames) == 0 {
+			return errors.New("rename action set, but no renames defined for service rule")
+		}
+		for _, rename := range r.Renames {
+			if rename.SourceDimension == "" || rename.TargetDimension == "" {
+				return errors.New("rename must set source_dimension and target_dimension")
+			}
+		}
+	}
+	return nil
+}
+
 func convertToManagedAttributeKey(attributeKey string, isTrace bool) string {
 	val, ok := traceKeyMap[attributeKey]
 	if ok && isTrace {
@@ -80,25 +155,49 @@ func convertToManagedAttributeKey(attributeKey string, isTrace bool) string {
 }
 
 func matchesSelectors(attributes pcommon.Map, selectorMatchers []SelectorMatcherItem, isTrace bool) bool {
+	_, ok := matchSelectors(attributes, selectorMatchers, isTrace)
+	return ok
+}
+
+// matchSelectors returns the named captures of the regex selectors if all the
+// selectors match.
+func matchSelectors(attributes pcommon.Map, selectorMatchers []SelectorMatcherItem, isTrace bool) (map[string]string, bool) {
+	var captures map[string]string
 	for _, item := range selectorMatchers {
 		exactKey := convertToManagedAttributeKey(item.Key, isTrace)
 		value, ok := attributes.Get(exactKey)
 		if !ok {
-			return false
+			return nil, false
 		}
-		if !item.Matcher.Match(value.AsString()) {
-			return false
+		if item.Regex == nil {
+			if !item.Matcher.Match(value.AsString()) {
+				return nil, false
+			}
+			continue
+		}
+		submatches := item.Regex.FindStringSubmatch(value.AsString())
+		if submatches == nil {
+			return nil, false
+		}
+		for name, value := range capture.Named(item.Regex, submatches) {
+			if captures == nil {
+				captures = map[string]string{}
+			}
+			captures[name] = value
 		}
 	}
-	return true
+	return captures, true
 }
 
 func generateSelectorMatchers(selectors []Selector) []SelectorMatcherItem {
 	var selectorMatchers []SelectorMatcherItem
 	for _, selector := range selectors {
-		selectorMatcherItem := SelectorMatcherItem{
-			selector.Dimension,
-			glob.MustCompile(selector.Match),
+		selectorMatcherItem := SelectorMatcherItem{Key: selector.Dimension}
+		if selector.MatchRegex != "" {
+			// the regular expression must match the whole value like the glob does
+			selectorMatcherItem.Regex = regexp.MustCompile("^(?:" + selector.MatchRegex + ")$")
+		} else {
+			selectorMatcherItem.Matcher = glob.MustCompile(selector.Match)
 		}
 		selectorMatchers = append(selectorMatchers, selectorMatcherItem)
 	}
@@ -107,12 +206,20 @@ func generateSelectorMatchers(selectors []Selector) []SelectorMatcherItem {
 
 func generateActionDetails(rules []Rule, action AllowListAction) []ActionItem {
 	var actionItems []ActionItem
-	for _, rule := range rules {
+	for i, rule := range rules {
 		if rule.Action == action {
 			var selectorMatchers = generateSelectorMatchers(rule.Selectors)
+			name := rule.RuleName
+			if name == "" {
+				name = fmt.Sprintf("%s#%d", action, i)
+			}
 			actionItem := ActionItem{
-				selectorMatchers,
-				rule.Replacements,
+				SelectorMatchers: selectorMatchers,
+				Replacements:     rule.Replacements,
+				Renames:          rule.Renames,
+				Name:             name,
+				index:            i,
+				hits:             new(atomic.Uint64),
 			}
 			actionItems = append(actionItems, actionItem)
 		}
@@ -120,3 +227,19 @@ func generateActionDetails(rules []Rule, action AllowListAction) []ActionItem {
 
 	return actionItems
 }
+
+func (a ActionItem) hit() {
+	if a.hits != nil {
+		a.hits.Add(1)
+	}
+}
+
+func hitCounts(actions []ActionItem) []RuleHits {
+	var hits []RuleHits
+	for _, action := range actions {
+		if action.hits != nil {
+			hits = append(hits, RuleHits{Index: action.index, Rule: action.Name, Hits: action.hits.Load()})
+		}
+	}
+	return hits
+}
@@ -4,6 +4,9 @@
 package rules
 
 import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
@@ -44,3 +47,54 @@ func generateAttributesWithEnv(service string, operation string, environment str
 	}
 	return attributes
 }
+
+func TestRuleValidate(t *testing.T) {
+	testCases := map[string]struct {
+		rule    Rule
+		wantErr string
+	}{
+		"Glob": {
+			rule: Rule{Selectors: []Selector{{Dimension: "Operation", Match: "GET *"}}, Action: AllowListActionKeep},
+		},
+		"Regex": {
+			rule: Rule{Selectors: []Selector{{Dimension: "Operation", MatchRegex: `GET /users/(?P<id>\d+)`}}, Action: AllowListActionDrop},
+		},
+		"BothMatches": {
+			rule:    Rule{Selectors: []Selector{{Dimension: "Operation", Match: "*", MatchRegex: ".*"}}, Action: AllowListActionKeep},
+			wantErr: `selector of dimension "Operation" must not set both match and match_regex`,
+		},
+		"EmptyMatch": {
+			rule: Rule{Selectors: []Selector{{Dimension: "Operation"}}, Action: AllowListActionKeep},
+		},
+		"InvalidRegex": {
+			rule:    Rule{Selectors: []Selector{{Dimension: "Operation", MatchRegex: "(GET"}}, Action: AllowListActionKeep},
+			wantErr: "invalid match_regex \"(GET\": error parsing regexp: missing closing ): `(GET`",
+		},
+		"InvalidAction": {
+			rule:    Rule{Selectors: []Selector{{Dimension: "Operation", Match: "*"}}, Action: "collapse"},
+			wantErr: "invalid action in rule",
+		},
+		"ReplaceWithoutReplacements": {
+			rule:    Rule{Selectors: []Selector{{Dimension: "Operation", Match: "*"}}, Action: AllowListActionReplace},
+			wantErr: "replace action set, but no replacements defined for service rule",
+		},
+		"RenameWithoutRenames": {
+			rule:    Rule{Selectors: []Selector{{Dimension: "Operation", Match: "*"}}, Action: AllowListActionRename},
+			wantErr: "rename action set, but no renames defined for service rule",
+		},
+		"RenameWithoutTarget": {
+			rule:    Rule{Selectors: []Selector{{Dimension: "Operation", Match: "*"}}, Renames: []Rename{{SourceDimension: "Operation"}}, Action: AllowListActionRename},
+			wantErr: "rename must set source_dimension and target_dimension",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.rule.Validate()
+			if testCase.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -23,9 +23,15 @@ func (d *DropActions) ShouldBeDropped(attributes pcommon.Map) (bool, error) {
 	for _, element := range d.Actions {
 		isMatched := matchesSelectors(attributes, element.SelectorMatchers, false)
 		if isMatched {
+			element.hit()
 			// drop the datapoint as one of drop rules is matched
 			return true, nil
 		}
 	}
 	return false, nil
 }
+
+// HitCounts returns the number of data points each drop rule matched.
+func (d *DropActions) HitCounts() []RuleHits {
+	return hitCounts(d.Actions)
+}
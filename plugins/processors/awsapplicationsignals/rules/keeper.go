@@ -32,9 +32,15 @@ func (k *KeepActions) ShouldBeDropped(attributes pcommon.Map) (bool, error) {
 			attributes.PutBool(common.AttributeTmpReserved, true)
 		}
 		if isMatched {
+			element.hit()
 			// keep the datapoint as one of the keep rules is matched
 			return false, nil
 		}
 	}
 	return true, nil
 }
+
+// HitCounts returns the number of data points each keep rule matched.
+func (k *KeepActions) HitCounts() []RuleHits {
+	return hitCounts(k.Actions)
+}
@@ -0,0 +1,69 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package rules
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+)
+
+type RenameActions struct {
+	Actions                 []ActionItem
+	markDataPointAsReserved bool
+}
+
+func NewRenamer(rules []Rule, markDataPointAsReserved bool) *RenameActions {
+	return &RenameActions{
+		Actions:                 generateActionDetails(rules, AllowListActionRename),
+		markDataPointAsReserved: markDataPointAsReserved,
+	}
+}
+
+func (r *RenameActions) Process(attributes, _ pcommon.Map, isTrace bool) error {
+	// do nothing when there is no rename rule defined
+	if len(r.Actions) == 0 {
+		return nil
+	}
+	// The rules are matched against the dimensions before any of them is renamed, and every dimension is only
+	// renamed once. If more than one rule renames a dimension, the last one is executed like for the replace rules.
+	finalRules := make(map[string]string)
+	for i := len(r.Actions) - 1; i >= 0; i = i - 1 {
+		element := r.Actions[i]
+		if !matchesSelectors(attributes, element.SelectorMatchers, isTrace) {
+			continue
+		}
+		element.hit()
+		for _, rename := range element.Renames {
+			source := convertToManagedAttributeKey(rename.SourceDimension, isTrace)
+			if _, visited := finalRules[source]; !visited {
+				finalRules[source] = convertToManagedAttributeKey(rename.TargetDimension, isTrace)
+			}
+		}
+	}
+
+	// the values are moved after all of them are read, so that chained renames do not depend on the order
+	values := make(map[string]pcommon.Value)
+	for source, target := range finalRules {
+		if value, ok := attributes.Get(source); ok && source != target {
+			moved := pcommon.NewValueEmpty()
+			value.CopyTo(moved)
+			values[target] = moved
+			attributes.Remove(source)
+		}
+	}
+	for target, value := range values {
+		value.CopyTo(attributes.PutEmpty(target))
+	}
+
+	if len(values) > 0 && r.markDataPointAsReserved {
+		attributes.PutBool(common.AttributeTmpReserved, true)
+	}
+	return nil
+}
+
+// HitCounts returns the number of data points or spans each rename rule matched.
+func (r *RenameActions) HitCounts() []RuleHits {
+	return hitCounts(r.Actions)
+}
@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+func TestRenamerProcess(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "Service",
+					Match:     "checkout*",
+				},
+			},
+			Renames: []Rename{
+				{
+					SourceDimension: "RemoteOperation",
+					TargetDimension: "RemoteAction",
+				},
+			},
+			Action:   "rename",
+			RuleName: "rename01",
+		},
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "Service",
+					Match:     "*",
+				},
+			},
+			Replacements: []Replacement{
+				{
+					TargetDimension: "Operation",
+					Value:           "replaced",
+				},
+			},
+			Action: "replace",
+		},
+	}
+
+	testRenamer := NewRenamer(config, true)
+	assert.Equal(t, 1, len(testRenamer.Actions))
+
+	attributes := generateTestAttributes("checkout-service", "GET /cart", "payment", "POST /pay", false)
+	assert.NoError(t, testRenamer.Process(attributes, attributes, false))
+	_, ok := attributes.Get(common.CWMetricAttributeRemoteOperation)
+	assert.False(t, ok)
+	value, _ := attributes.Get("RemoteAction")
+	assert.Equal(t, "POST /pay", value.Str())
+	_, ok = attributes.Get(common.AttributeTmpReserved)
+	assert.True(t, ok)
+
+	// the dimensions of traces are managed attributes
+	attributes = generateTestAttributes("checkout-service", "GET /cart", "payment", "POST /pay", true)
+	assert.NoError(t, testRenamer.Process(attributes, attributes, true))
+	_, ok = attributes.Get(attr.AWSRemoteOperation)
+	assert.False(t, ok)
+	value, _ = attributes.Get("RemoteAction")
+	assert.Equal(t, "POST /pay", value.Str())
+
+	attributes = generateTestAttributes("cart-service", "GET /cart", "payment", "POST /pay", false)
+	assert.NoError(t, testRenamer.Process(attributes, attributes, false))
+	value, _ = attributes.Get(common.CWMetricAttributeRemoteOperation)
+	assert.Equal(t, "POST /pay", value.Str())
+	_, ok = attributes.Get(common.AttributeTmpReserved)
+	assert.False(t, ok)
+
+	assert.Equal(t, []RuleHits{{Rule: "rename01", Hits: 2}}, testRenamer.HitCounts())
+}
+
+func TestRenamerChain(t *testing.T) {
+	testRenamer := NewRenamer([]Rule{
+		{
+			Selectors: []Selector{{Dimension: "Service", Match: "*"}},
+			Renames: []Rename{
+				{SourceDimension: "Operation", TargetDimension: "RemoteOperation"},
+				{SourceDimension: "RemoteOperation", TargetDimension: "PeerOperation"},
+			},
+			Action: "rename",
+		},
+	}, false)
+	attributes := generateTestAttributes("service", "GET /cart", "payment", "POST /pay", false)
+	assert.NoError(t, testRenamer.Process(attributes, attributes, false))
+	assert.Equal(t, map[string]any{
+		"Service":         "service",
+		"RemoteService":   "payment",
+		"RemoteOperation": "GET /cart",
+		"PeerOperation":   "POST /pay",
+	}, attributes.AsRaw())
+	assert.Equal(t, []RuleHits{{Rule: "rename#0", Hits: 1}}, testRenamer.HitCounts())
+}
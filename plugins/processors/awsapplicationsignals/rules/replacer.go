@@ -6,6 +6,7 @@ package rules
 import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/capture"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
 )
 
@@ -31,17 +32,18 @@ func (r *ReplaceActions) Process(attributes, _ pcommon.Map, isTrace bool) error
 	finalRules := make(map[string]string)
 	for i := len(actions) - 1; i >= 0; i = i - 1 {
 		element := actions[i]
-		isMatched := matchesSelectors(attributes, element.SelectorMatchers, isTrace)
+		captures, isMatched := matchSelectors(attributes, element.SelectorMatchers, isTrace)
 		if !isMatched {
 			continue
 		}
+		element.hit()
 		for _, replacement := range element.Replacements {
 			targetDimension := replacement.TargetDimension
 
 			attr := convertToManagedAttributeKey(targetDimension, isTrace)
 			// every replacement in one specific dimension only will be performed once
 			if _, visited := finalRules[attr]; !visited {
-				finalRules[attr] = replacement.Value
+				finalRules[attr] = capture.Expand(replacement.Value, captures)
 			}
 		}
 	}
@@ -55,3 +57,8 @@ func (r *ReplaceActions) Process(attributes, _ pcommon.Map, isTrace bool) error
 	}
 	return nil
 }
+
+// HitCounts returns the number of data points or spans each replace rule matched.
+func (r *ReplaceActions) HitCounts() []RuleHits {
+	return hitCounts(r.Actions)
+}
@@ -352,3 +352,46 @@ func TestReplacerProcessWithEmptyConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestReplacerWithRegexCaptures(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors: []Selector{
+				{
+					Dimension:  "Operation",
+					MatchRegex: `(?P<method>[A-Z]+) /users/\d+/(?P<resource>\w+)`,
+				},
+			},
+			Replacements: []Replacement{
+				{
+					TargetDimension: "Operation",
+					Value:           "{{method}} /users/{id}/{{resource}}",
+				},
+				{
+					TargetDimension: "RemoteOperation",
+					Value:           "{{unknown}}",
+				},
+			},
+			Action:   "replace",
+			RuleName: "users",
+		},
+	}
+	testReplacer := NewReplacer(config, false)
+
+	for _, isTrace := range []bool{false, true} {
+		attributes := generateTestAttributes("customer", "GET /users/123/orders", "db", "SELECT", isTrace)
+		assert.NoError(t, testReplacer.Process(attributes, attributes, isTrace))
+		operation, _ := attributes.Get(convertToManagedAttributeKey("Operation", isTrace))
+		assert.Equal(t, "GET /users/{id}/orders", operation.Str())
+		remoteOperation, _ := attributes.Get(convertToManagedAttributeKey("RemoteOperation", isTrace))
+		assert.Equal(t, "{{unknown}}", remoteOperation.Str())
+	}
+
+	// the regular expression must match the whole value
+	attributes := generateTestAttributes("customer", "GET /users/123/orders/42", "db", "SELECT", false)
+	assert.NoError(t, testReplacer.Process(attributes, attributes, false))
+	operation, _ := attributes.Get("Operation")
+	assert.Equal(t, "GET /users/123/orders/42", operation.Str())
+
+	assert.Equal(t, []RuleHits{{Rule: "users", Hits: 2}}, testReplacer.HitCounts())
+}
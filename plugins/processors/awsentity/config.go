@@ -30,6 +30,14 @@ type Config struct {
 	EntityType string `mapstructure:"entity_type,omitempty"`
 	// TransformEntity contains configuration for overriding entity attributes
 	TransformEntity *entity.Transform `mapstructure:"transform_entity,omitempty"`
+	// EntityRules derive the service name and the environment of the Service
+	// entities that have no service name from the telemetry.
+	EntityRules []entity.Rule `mapstructure:"entity_rules,omitempty"`
+	// HostMetadata is set when the entity store reads the metadata of a host
+	// that is not an EC2 instance from the host metadata source in place of
+	// IMDS, so the Service entities get the account ID and the service name
+	// from the tags of the host.
+	HostMetadata bool `mapstructure:"host_metadata,omitempty"`
 }
 
 // Verify Config implements Processor interface.
@@ -57,5 +65,8 @@ func (cfg *Config) Validate() error {
 			}
 		}
 	}
+	if _, err := entity.NewRules(cfg.EntityRules); err != nil {
+		return err
+	}
 	return nil
 }
@@ -103,6 +103,46 @@ func TestUnmarshalConfig(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "TestValidEntityRules",
+			conf: confmap.NewFromStringMap(map[string]interface{}{
+				"entity_type": entityattributes.Service,
+				"entity_rules": []interface{}{
+					map[string]interface{}{
+						"source":       entity.RuleSourceLogGroup,
+						"pattern":      "/aws/(?P<app>.+)",
+						"service_name": "{{app}}",
+						"priority":     1,
+					},
+				},
+			}),
+			expected: &Config{
+				EntityType: entityattributes.Service,
+				EntityRules: []entity.Rule{
+					{
+						Source:      entity.RuleSourceLogGroup,
+						Pattern:     "/aws/(?P<app>.+)",
+						ServiceName: "{{app}}",
+						Priority:    1,
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "TestInvalidEntityRules",
+			conf: confmap.NewFromStringMap(map[string]interface{}{
+				"entity_type": entityattributes.Service,
+				"entity_rules": []interface{}{
+					map[string]interface{}{
+						"source":       entity.RuleSourceContainerLabel,
+						"pattern":      ".*",
+						"service_name": "app",
+					},
+				},
+			}),
+			expectError: true,
+		},
 		{
 			name: "TestMissingRequiredFieldEntityTransform",
 			conf: confmap.NewFromStringMap(map[string]interface{}{
@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 )
 
 const (
@@ -136,13 +137,40 @@ func IsAllowedAttribute(key string) bool {
 }
 
 func CreateCloudWatchEntityFromAttributes(resourceAttributes pcommon.Map) cloudwatch.Entity {
+	keyAttributesMap, attributeMap, ok := entityAttributeMaps(resourceAttributes)
+	if !ok {
+		return cloudwatch.Entity{}
+	}
+
+	// Remove entity fields from attributes and return the entity
+	removeEntityFields(resourceAttributes)
+	return cloudwatch.Entity{
+		KeyAttributes: keyAttributesMap,
+		Attributes:    attributeMap,
+	}
+}
+
+// CreateCloudWatchLogsEntityFromAttributes creates the entity sent with the PutLogEvents call. The resource
+// attributes are left as is. Returns nil if AwsAccountId is not found.
+func CreateCloudWatchLogsEntityFromAttributes(resourceAttributes pcommon.Map) *cloudwatchlogs.Entity {
+	keyAttributesMap, attributeMap, ok := entityAttributeMaps(resourceAttributes)
+	if !ok {
+		return nil
+	}
+	return &cloudwatchlogs.Entity{
+		KeyAttributes: keyAttributesMap,
+		Attributes:    attributeMap,
+	}
+}
+
+func entityAttributeMaps(resourceAttributes pcommon.Map) (map[string]*string, map[string]*string, bool) {
 	keyAttributesMap := map[string]*string{}
 	attributeMap := map[string]*string{}
 
 	// Process KeyAttributes and return empty entity if AwsAccountId is not found
 	processEntityAttributes(keyAttributeEntityToShortNameMap, keyAttributesMap, resourceAttributes)
 	if _, ok := keyAttributesMap[AwsAccountId]; !ok {
-		return cloudwatch.Entity{}
+		return nil, nil, false
 	}
 
 	// Process Attributes and add cluster attribute if on EKS/K8s
@@ -153,13 +181,7 @@ func CreateCloudWatchEntityFromAttributes(resourceAttributes pcommon.Map) cloudw
 			attributeMap[platformType] = aws.String(clusterNameValue.Str())
 		}
 	}
-
-	// Remove entity fields from attributes and return the entity
-	removeEntityFields(resourceAttributes)
-	return cloudwatch.Entity{
-		KeyAttributes: keyAttributesMap,
-		Attributes:    attributeMap,
-	}
+	return keyAttributesMap, attributeMap, true
 }
 
 // processEntityAttributes fetches the fields with entity prefix and creates an entity to be sent at the PutMetricData call.
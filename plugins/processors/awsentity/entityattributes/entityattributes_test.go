@@ -9,9 +9,11 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 )
 
 func TestProcessAndRemoveEntityAttributes(t *testing.T) {
@@ -196,6 +198,32 @@ func TestCreateCloudWatchEntityFromAttributes_WithAccountID(t *testing.T) {
 	assert.Equal(t, expectedEntity, entity)
 }
 
+func TestCreateCloudWatchLogsEntityFromAttributes(t *testing.T) {
+	resourceLogs := plog.NewResourceLogs()
+	resourceLogs.Resource().Attributes().PutStr(AttributeEntityType, "Service")
+	resourceLogs.Resource().Attributes().PutStr(AttributeEntityServiceName, "my-service")
+	resourceLogs.Resource().Attributes().PutStr(AttributeEntityDeploymentEnvironment, "ec2:default")
+	resourceLogs.Resource().Attributes().PutStr(AttributeEntityPlatformType, "AWS::EC2")
+	resourceLogs.Resource().Attributes().PutStr(AttributeEntityInstanceID, "i-123456789")
+	assert.Nil(t, CreateCloudWatchLogsEntityFromAttributes(resourceLogs.Resource().Attributes()))
+
+	resourceLogs.Resource().Attributes().PutStr(AttributeEntityAwsAccountId, "123456789")
+	expectedEntity := &cloudwatchlogs.Entity{
+		KeyAttributes: map[string]*string{
+			EntityType:            aws.String(Service),
+			ServiceName:           aws.String("my-service"),
+			DeploymentEnvironment: aws.String("ec2:default"),
+			AwsAccountId:          aws.String("123456789"),
+		},
+		Attributes: map[string]*string{
+			Platform:   aws.String("AWS::EC2"),
+			InstanceID: aws.String("i-123456789"),
+		},
+	}
+	assert.Equal(t, expectedEntity, CreateCloudWatchLogsEntityFromAttributes(resourceLogs.Resource().Attributes()))
+	assert.Equal(t, 6, resourceLogs.Resource().Attributes().Len())
+}
+
 func TestCreateCloudWatchEntityFromAttributesOnK8s(t *testing.T) {
 	entityMap := attributeEntityToShortNameMap
 	delete(entityMap, AttributeEntityCluster)
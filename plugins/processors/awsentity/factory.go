@@ -26,7 +26,8 @@ func NewFactory() processor.Factory {
 	return processor.NewFactory(
 		TypeStr,
 		createDefaultConfig,
-		processor.WithMetrics(createMetricsProcessor, stability))
+		processor.WithMetrics(createMetricsProcessor, stability),
+		processor.WithLogs(createLogsProcessor, stability))
 }
 
 func createDefaultConfig() component.Config {
@@ -53,3 +54,24 @@ func createMetricsProcessor(
 		metricsProcessor.processMetrics,
 		processorhelper.WithCapabilities(processorCapabilities))
 }
+
+func createLogsProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (processor.Logs, error) {
+	processorConfig, ok := cfg.(*Config)
+	if !ok {
+		return nil, errors.New("configuration parsing error")
+	}
+	logsProcessor := newAwsEntityProcessor(processorConfig, set.Logger)
+
+	return processorhelper.NewLogs(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		logsProcessor.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
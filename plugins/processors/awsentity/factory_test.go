@@ -41,6 +41,6 @@ func TestCreateProcessor(t *testing.T) {
 	assert.NotNil(t, mProcessor)
 
 	lProcessor, err := factory.CreateLogs(context.Background(), setting, cfg, consumertest.NewNop())
-	assert.Equal(t, err, pipeline.ErrSignalNotSupported)
-	assert.Nil(t, lProcessor)
+	assert.NoError(t, err)
+	assert.NotNil(t, lProcessor)
 }
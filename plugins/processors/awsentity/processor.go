@@ -11,6 +11,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
 	"go.uber.org/zap"
@@ -18,6 +19,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/extension/entitystore"
 	"github.com/aws/amazon-cloudwatch-agent/extension/k8smetadata"
 	"github.com/aws/amazon-cloudwatch-agent/internal/clientutil"
+	"github.com/aws/amazon-cloudwatch-agent/internal/entity"
 	"github.com/aws/amazon-cloudwatch-agent/internal/k8sCommon/k8sclient"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/entityattributes"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/internal/entitytransformer"
@@ -34,6 +36,10 @@ const (
 	attributeEC2TagAwsAutoscalingGroupName = "ec2.tag.aws:autoscaling:groupName"
 	EMPTY                                  = ""
 	unknownService                         = "unknown_service"
+	// The process name of the telegraf procstat plugin.
+	attributeProcstatProcessName = "process_name"
+	// The prefix of the labels of the container of the resource.
+	attributeContainerLabelPrefix = "container.label."
 )
 
 type scraper interface {
@@ -123,21 +129,25 @@ var getPodMeta = func(ctx context.Context) k8sclient.PodMetadata {
 	return podMeta
 }
 
-// awsEntityProcessor looks for metrics that have the aws.log.group.names and either the service.name or
+// awsEntityProcessor looks for metrics and logs that have the aws.log.group.names and either the service.name or
 // deployment.environment resource attributes set, then adds the association between the log group(s) and the
 // service/environment names to the entitystore extension.
 type awsEntityProcessor struct {
 	config            *Config
 	k8sscraper        scraper
 	entityTransformer *entitytransformer.EntityTransformer
+	entityRules       *entity.Rules
 	logger            *zap.Logger
 }
 
 func newAwsEntityProcessor(config *Config, logger *zap.Logger) *awsEntityProcessor {
+	// the rules are validated with the config
+	entityRules, _ := entity.NewRules(config.EntityRules)
 	return &awsEntityProcessor{
 		config:            config,
 		k8sscraper:        k8sattributescraper.NewK8sAttributeScraper(config.ClusterName),
 		entityTransformer: entitytransformer.NewEntityTransformer(config.TransformEntity, logger),
+		entityRules:       entityRules,
 		logger:            logger,
 	}
 }
@@ -147,193 +157,243 @@ func (p *awsEntityProcessor) processMetrics(ctx context.Context, md pmetric.Metr
 
 	rm := md.ResourceMetrics()
 	for i := 0; i < rm.Len(); i++ {
-		var logGroupNames, serviceName, environmentName string
-		var entityServiceNameSource, entityPlatformType string
-		var ec2Info entitystore.EC2Info
-		resourceAttrs := rm.At(i).Resource().Attributes()
-		switch p.config.EntityType {
-		case entityattributes.Resource:
-			if p.config.KubernetesMode != "" {
-				switch p.config.KubernetesMode {
-				case config.ModeEKS:
-					resourceAttrs.PutStr(entityattributes.AttributeEntityPlatformType, entityattributes.AttributeEntityEKSPlatform)
-				default:
-					resourceAttrs.PutStr(entityattributes.AttributeEntityPlatformType, entityattributes.AttributeEntityK8sPlatform)
-				}
-			} else if p.config.Platform == config.ModeEC2 {
-				// ec2tagger processor may have picked up the ASG name from an ec2:DescribeTags call
-				if getAutoScalingGroupFromEntityStore() == EMPTY && p.config.ScrapeDatapointAttribute {
-					if autoScalingGroup := p.scrapeResourceEntityAttribute(rm.At(i).ScopeMetrics()); autoScalingGroup != EMPTY {
-						setAutoScalingGroup(autoScalingGroup)
-					}
-				}
-				ec2Info = getEC2InfoFromEntityStore()
-				if ec2Info.GetInstanceID() != EMPTY {
-					resourceAttrs.PutStr(entityattributes.AttributeEntityType, entityattributes.AttributeEntityAWSResource)
-					resourceAttrs.PutStr(entityattributes.AttributeEntityResourceType, entityattributes.AttributeEntityEC2InstanceResource)
-					resourceAttrs.PutStr(entityattributes.AttributeEntityIdentifier, ec2Info.GetInstanceID())
-				}
-				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityAwsAccountId, ec2Info.GetAccountID())
+		p.processResource(ctx, rm.At(i).Resource(), rm.At(i).ScopeMetrics(), p.config.ScrapeDatapointAttribute)
+	}
+	return md, nil
+}
+
+// processLogs adds the entity attributes to the resource of each log record.
+// Log records carry no datapoint attributes, so there is nothing to scrape.
+func (p *awsEntityProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	rl := ld.ResourceLogs()
+	for i := 0; i < rl.Len(); i++ {
+		p.processResource(ctx, rl.At(i).Resource(), pmetric.NewScopeMetricsSlice(), false)
+	}
+	return ld, nil
+}
+
+// processResource adds the entity attributes to the resource. The datapoints
+// in scopeMetrics are only scraped for attributes when scrapeDatapoints is set.
+func (p *awsEntityProcessor) processResource(ctx context.Context, resource pcommon.Resource, scopeMetrics pmetric.ScopeMetricsSlice, scrapeDatapoints bool) {
+	var logGroupNames, serviceName, environmentName string
+	var entityServiceNameSource, entityPlatformType string
+	var ec2Info entitystore.EC2Info
+	resourceAttrs := resource.Attributes()
+	switch p.config.EntityType {
+	case entityattributes.Resource:
+		if p.config.KubernetesMode != "" {
+			switch p.config.KubernetesMode {
+			case config.ModeEKS:
+				resourceAttrs.PutStr(entityattributes.AttributeEntityPlatformType, entityattributes.AttributeEntityEKSPlatform)
+			default:
+				resourceAttrs.PutStr(entityattributes.AttributeEntityPlatformType, entityattributes.AttributeEntityK8sPlatform)
 			}
-		case entityattributes.Service:
-			if logGroupNamesAttr, ok := resourceAttrs.Get(attributeAwsLogGroupNames); ok {
-				logGroupNames = logGroupNamesAttr.Str()
+		} else if p.config.Platform == config.ModeEC2 {
+			// ec2tagger processor may have picked up the ASG name from an ec2:DescribeTags call
+			if getAutoScalingGroupFromEntityStore() == EMPTY && scrapeDatapoints {
+				if autoScalingGroup := p.scrapeResourceEntityAttribute(scopeMetrics); autoScalingGroup != EMPTY {
+					setAutoScalingGroup(autoScalingGroup)
+				}
 			}
-			if serviceNameAttr, ok := resourceAttrs.Get(attributeServiceName); ok {
-				serviceName = serviceNameAttr.Str()
+			ec2Info = getEC2InfoFromEntityStore()
+			if ec2Info.GetInstanceID() != EMPTY {
+				resourceAttrs.PutStr(entityattributes.AttributeEntityType, entityattributes.AttributeEntityAWSResource)
+				resourceAttrs.PutStr(entityattributes.AttributeEntityResourceType, entityattributes.AttributeEntityEC2InstanceResource)
+				resourceAttrs.PutStr(entityattributes.AttributeEntityIdentifier, ec2Info.GetInstanceID())
 			}
-			if environmentNameAttr, ok := resourceAttrs.Get(attributeDeploymentEnvironment); ok {
-				environmentName = environmentNameAttr.Str()
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityAwsAccountId, ec2Info.GetAccountID())
+		}
+	case entityattributes.Service:
+		if logGroupNamesAttr, ok := resourceAttrs.Get(attributeAwsLogGroupNames); ok {
+			logGroupNames = logGroupNamesAttr.Str()
+		}
+		if serviceNameAttr, ok := resourceAttrs.Get(attributeServiceName); ok {
+			serviceName = serviceNameAttr.Str()
+		}
+		if environmentNameAttr, ok := resourceAttrs.Get(attributeDeploymentEnvironment); ok {
+			environmentName = environmentNameAttr.Str()
+		}
+		if serviceNameSource, sourceExists := resourceAttrs.Get(entityattributes.AttributeEntityServiceNameSource); sourceExists {
+			entityServiceNameSource = serviceNameSource.Str()
+		}
+		// resourcedetection processor may have picked up the ASG name from an ec2:DescribeTags call
+		if autoScalingGroupNameAttr, ok := resourceAttrs.Get(attributeEC2TagAwsAutoscalingGroupName); ok {
+			setAutoScalingGroup(autoScalingGroupNameAttr.Str())
+		}
+
+		entityServiceName := getServiceAttributes(resourceAttrs)
+		entityEnvironmentName := environmentName
+		if (entityServiceName == EMPTY || entityEnvironmentName == EMPTY) && scrapeDatapoints {
+			entityServiceName, entityEnvironmentName, entityServiceNameSource = p.scrapeServiceAttribute(scopeMetrics)
+			// If the entityServiceNameSource is empty here, that means it was not configured via instrumentation
+			// If entityServiceName is a datapoint attribute, that means the service name is coming from the UserConfiguration source
+			if entityServiceNameSource == entityattributes.AttributeServiceNameSourceUserConfig && entityServiceName != EMPTY {
+				entityServiceNameSource = entityattributes.AttributeServiceNameSourceUserConfig
 			}
-			if serviceNameSource, sourceExists := resourceAttrs.Get(entityattributes.AttributeEntityServiceNameSource); sourceExists {
-				entityServiceNameSource = serviceNameSource.Str()
+		}
+		// The entity rules rank below the telemetry attributes, and above the fallbacks of the platform. Each
+		// value is set by the first source that has one, the same precedence the entity store uses for the
+		// log files, see the README.
+		if p.entityRules.Len() > 0 && (shouldUseFallbackServiceName(entityServiceName) || entityEnvironmentName == EMPTY) {
+			if match, ok := p.entityRules.Match(entityRuleValues(resourceAttrs, logGroupNames, scopeMetrics, scrapeDatapoints)); ok {
+				if match.ServiceName != EMPTY && shouldUseFallbackServiceName(entityServiceName) {
+					entityServiceName = match.ServiceName
+					entityServiceNameSource = entitystore.ServiceNameSourceEntityRule
+				}
+				if match.Environment != EMPTY && entityEnvironmentName == EMPTY {
+					entityEnvironmentName = match.Environment
+				}
+				p.logger.Debug("entity rule matched", zap.String("rule", match.Rule), zap.String("service", entityServiceName), zap.String("environment", entityEnvironmentName))
 			}
-			// resourcedetection processor may have picked up the ASG name from an ec2:DescribeTags call
-			if autoScalingGroupNameAttr, ok := resourceAttrs.Get(attributeEC2TagAwsAutoscalingGroupName); ok {
-				setAutoScalingGroup(autoScalingGroupNameAttr.Str())
+		}
+		if p.config.KubernetesMode != "" {
+			p.k8sscraper.Scrape(resource, getPodMeta(ctx))
+			if p.config.Platform == config.ModeEC2 {
+				ec2Info = getEC2InfoFromEntityStore()
 			}
 
-			entityServiceName := getServiceAttributes(resourceAttrs)
-			entityEnvironmentName := environmentName
-			if (entityServiceName == EMPTY || entityEnvironmentName == EMPTY) && p.config.ScrapeDatapointAttribute {
-				entityServiceName, entityEnvironmentName, entityServiceNameSource = p.scrapeServiceAttribute(rm.At(i).ScopeMetrics())
-				// If the entityServiceNameSource is empty here, that means it was not configured via instrumentation
-				// If entityServiceName is a datapoint attribute, that means the service name is coming from the UserConfiguration source
-				if entityServiceNameSource == entityattributes.AttributeServiceNameSourceUserConfig && entityServiceName != EMPTY {
-					entityServiceNameSource = entityattributes.AttributeServiceNameSourceUserConfig
-				}
+			if p.config.KubernetesMode == config.ModeEKS {
+				entityPlatformType = entityattributes.AttributeEntityEKSPlatform
+			} else {
+				entityPlatformType = entityattributes.AttributeEntityK8sPlatform
 			}
-			if p.config.KubernetesMode != "" {
-				p.k8sscraper.Scrape(rm.At(i).Resource(), getPodMeta(ctx))
-				if p.config.Platform == config.ModeEC2 {
-					ec2Info = getEC2InfoFromEntityStore()
-				}
 
+			podInfo, ok := p.k8sscraper.(*k8sattributescraper.K8sAttributeScraper)
+			// Perform fallback mechanism for service name if it is empty
+			// or has prefix unknown_service ( unknown_service will be set by OTEL SDK if the service name is empty on application pod)
+			// https://opentelemetry.io/docs/specs/semconv/attributes-registry/service/
+			if shouldUseFallbackServiceName(entityServiceName) && ok && podInfo != nil && podInfo.Workload != EMPTY {
+				entityServiceName = podInfo.Workload
+				entityServiceNameSource = entitystore.ServiceNameSourceK8sWorkload
+			}
+			// Set the service name source to Instrumentation if the operator doesn't set it
+			if entityServiceName != EMPTY && entityServiceNameSource == EMPTY && getTelemetrySDKEnabledAttribute(resourceAttrs) {
+				entityServiceNameSource = entitystore.ServiceNameSourceInstrumentation
+			}
+			// Perform fallback mechanism for environment if it is empty
+			if entityEnvironmentName == EMPTY && ok && podInfo.Cluster != EMPTY && podInfo.Namespace != EMPTY {
 				if p.config.KubernetesMode == config.ModeEKS {
-					entityPlatformType = entityattributes.AttributeEntityEKSPlatform
-				} else {
-					entityPlatformType = entityattributes.AttributeEntityK8sPlatform
-				}
-
-				podInfo, ok := p.k8sscraper.(*k8sattributescraper.K8sAttributeScraper)
-				// Perform fallback mechanism for service name if it is empty
-				// or has prefix unknown_service ( unknown_service will be set by OTEL SDK if the service name is empty on application pod)
-				// https://opentelemetry.io/docs/specs/semconv/attributes-registry/service/
-				if shouldUseFallbackServiceName(entityServiceName) && ok && podInfo != nil && podInfo.Workload != EMPTY {
-					entityServiceName = podInfo.Workload
-					entityServiceNameSource = entitystore.ServiceNameSourceK8sWorkload
-				}
-				// Set the service name source to Instrumentation if the operator doesn't set it
-				if entityServiceName != EMPTY && entityServiceNameSource == EMPTY && getTelemetrySDKEnabledAttribute(resourceAttrs) {
-					entityServiceNameSource = entitystore.ServiceNameSourceInstrumentation
-				}
-				// Perform fallback mechanism for environment if it is empty
-				if entityEnvironmentName == EMPTY && ok && podInfo.Cluster != EMPTY && podInfo.Namespace != EMPTY {
-					if p.config.KubernetesMode == config.ModeEKS {
-						entityEnvironmentName = "eks:" + p.config.ClusterName + "/" + podInfo.Namespace
-					} else if p.config.KubernetesMode == config.ModeK8sEC2 || p.config.KubernetesMode == config.ModeK8sOnPrem {
-						entityEnvironmentName = "k8s:" + p.config.ClusterName + "/" + podInfo.Namespace
-					}
+					entityEnvironmentName = "eks:" + p.config.ClusterName + "/" + podInfo.Namespace
+				} else if p.config.KubernetesMode == config.ModeK8sEC2 || p.config.KubernetesMode == config.ModeK8sOnPrem {
+					entityEnvironmentName = "k8s:" + p.config.ClusterName + "/" + podInfo.Namespace
 				}
+			}
 
-				// Add service information for a pod to the pod association map
-				// so that agent can host this information in a server
-				fullPodName := scrapeK8sPodName(resourceAttrs)
-				if fullPodName != EMPTY && entityServiceName != EMPTY && entityServiceNameSource != EMPTY {
-					addPodToServiceEnvironmentMap(fullPodName, entityServiceName, entityEnvironmentName, entityServiceNameSource)
-				} else if fullPodName != EMPTY && entityServiceName != EMPTY && entityServiceNameSource == EMPTY {
-					addPodToServiceEnvironmentMap(fullPodName, entityServiceName, entityEnvironmentName, entitystore.ServiceNameSourceUnknown)
-				}
-				eksAttributes := K8sServiceAttributes{
-					Cluster:           podInfo.Cluster,
-					Namespace:         podInfo.Namespace,
-					Workload:          podInfo.Workload,
-					Node:              podInfo.Node,
-					InstanceId:        ec2Info.GetInstanceID(),
-					ServiceNameSource: entityServiceNameSource,
-				}
-				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityType, entityattributes.Service)
-				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityServiceName, entityServiceName)
-				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityDeploymentEnvironment, entityEnvironmentName)
-
-				if err := validate.Struct(eksAttributes); err == nil {
-					resourceAttrs.PutStr(entityattributes.AttributeEntityPlatformType, entityPlatformType)
-					resourceAttrs.PutStr(entityattributes.AttributeEntityCluster, eksAttributes.Cluster)
-					resourceAttrs.PutStr(entityattributes.AttributeEntityNamespace, eksAttributes.Namespace)
-					resourceAttrs.PutStr(entityattributes.AttributeEntityWorkload, eksAttributes.Workload)
-					resourceAttrs.PutStr(entityattributes.AttributeEntityNode, eksAttributes.Node)
-					//Add Instance id attribute only if the application node is same as agent node
-					if eksAttributes.Node == os.Getenv("K8S_NODE_NAME") {
-						AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityInstanceID, eksAttributes.InstanceId)
-					}
-					AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityAwsAccountId, ec2Info.GetAccountID())
-					AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityServiceNameSource, entityServiceNameSource)
-				}
-				p.k8sscraper.Reset()
-			} else if p.config.Platform == config.ModeEC2 {
-				//If entityServiceNameSource is empty, it was not configured via the config. Get the source in descending priority
-				//  1. Incoming telemetry attributes
-				//  2. CWA config
-				//  3. instance tags - The tags attached to the EC2 instance. Only scrape for tag with the following key: service, application, app
-				//  4. IAM Role - The IAM role name retrieved through IMDS(Instance Metadata Service)
-				if shouldUseFallbackServiceName(entityServiceName) {
-					entityServiceName, entityServiceNameSource = getServiceNameSource()
-				} else if entityServiceName != EMPTY && entityServiceNameSource == EMPTY {
-					entityServiceNameSource = entitystore.ServiceNameSourceInstrumentation
+			// Add service information for a pod to the pod association map
+			// so that agent can host this information in a server
+			fullPodName := scrapeK8sPodName(resourceAttrs)
+			if fullPodName != EMPTY && entityServiceName != EMPTY && entityServiceNameSource != EMPTY {
+				addPodToServiceEnvironmentMap(fullPodName, entityServiceName, entityEnvironmentName, entityServiceNameSource)
+			} else if fullPodName != EMPTY && entityServiceName != EMPTY && entityServiceNameSource == EMPTY {
+				addPodToServiceEnvironmentMap(fullPodName, entityServiceName, entityEnvironmentName, entitystore.ServiceNameSourceUnknown)
+			}
+			eksAttributes := K8sServiceAttributes{
+				Cluster:           podInfo.Cluster,
+				Namespace:         podInfo.Namespace,
+				Workload:          podInfo.Workload,
+				Node:              podInfo.Node,
+				InstanceId:        ec2Info.GetInstanceID(),
+				ServiceNameSource: entityServiceNameSource,
+			}
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityType, entityattributes.Service)
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityServiceName, entityServiceName)
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityDeploymentEnvironment, entityEnvironmentName)
+
+			if err := validate.Struct(eksAttributes); err == nil {
+				resourceAttrs.PutStr(entityattributes.AttributeEntityPlatformType, entityPlatformType)
+				resourceAttrs.PutStr(entityattributes.AttributeEntityCluster, eksAttributes.Cluster)
+				resourceAttrs.PutStr(entityattributes.AttributeEntityNamespace, eksAttributes.Namespace)
+				resourceAttrs.PutStr(entityattributes.AttributeEntityWorkload, eksAttributes.Workload)
+				resourceAttrs.PutStr(entityattributes.AttributeEntityNode, eksAttributes.Node)
+				//Add Instance id attribute only if the application node is same as agent node
+				if eksAttributes.Node == os.Getenv("K8S_NODE_NAME") {
+					AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityInstanceID, eksAttributes.InstanceId)
 				}
+				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityAwsAccountId, ec2Info.GetAccountID())
+				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityServiceNameSource, entityServiceNameSource)
+			}
+			p.k8sscraper.Reset()
+		} else if p.config.Platform == config.ModeEC2 {
+			//If entityServiceNameSource is empty, it was not configured via the config. Get the source in descending priority
+			//  1. Incoming telemetry attributes
+			//  2. CWA config
+			//  3. entity rules - The user-defined rules, applied above
+			//  4. instance tags - The tags attached to the EC2 instance. Only scrape for tag with the following key: service, application, app
+			//  5. IAM Role - The IAM role name retrieved through IMDS(Instance Metadata Service)
+			if shouldUseFallbackServiceName(entityServiceName) {
+				entityServiceName, entityServiceNameSource = getServiceNameSource()
+			} else if entityServiceName != EMPTY && entityServiceNameSource == EMPTY {
+				entityServiceNameSource = entitystore.ServiceNameSourceInstrumentation
+			}
 
-				entityPlatformType = entityattributes.AttributeEntityEC2Platform
-				ec2Info = getEC2InfoFromEntityStore()
+			entityPlatformType = entityattributes.AttributeEntityEC2Platform
+			ec2Info = getEC2InfoFromEntityStore()
 
-				if entityEnvironmentName == EMPTY {
-					if getAutoScalingGroupFromEntityStore() != EMPTY {
-						entityEnvironmentName = entityattributes.DeploymentEnvironmentFallbackPrefix + getAutoScalingGroupFromEntityStore()
-					} else {
-						entityEnvironmentName = entityattributes.DeploymentEnvironmentDefault
-					}
+			if entityEnvironmentName == EMPTY {
+				if getAutoScalingGroupFromEntityStore() != EMPTY {
+					entityEnvironmentName = entityattributes.DeploymentEnvironmentFallbackPrefix + getAutoScalingGroupFromEntityStore()
+				} else {
+					entityEnvironmentName = entityattributes.DeploymentEnvironmentDefault
 				}
+			}
 
-				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityType, entityattributes.Service)
-				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityServiceName, entityServiceName)
-				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityDeploymentEnvironment, entityEnvironmentName)
-				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityAwsAccountId, ec2Info.GetAccountID())
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityType, entityattributes.Service)
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityServiceName, entityServiceName)
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityDeploymentEnvironment, entityEnvironmentName)
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityAwsAccountId, ec2Info.GetAccountID())
 
-				ec2Attributes := EC2ServiceAttributes{
-					InstanceId:        ec2Info.GetInstanceID(),
-					AutoScalingGroup:  getAutoScalingGroupFromEntityStore(),
-					ServiceNameSource: entityServiceNameSource,
+			ec2Attributes := EC2ServiceAttributes{
+				InstanceId:        ec2Info.GetInstanceID(),
+				AutoScalingGroup:  getAutoScalingGroupFromEntityStore(),
+				ServiceNameSource: entityServiceNameSource,
+			}
+			if err := validate.Struct(ec2Attributes); err == nil {
+				resourceAttrs.PutStr(entityattributes.AttributeEntityPlatformType, entityPlatformType)
+				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityInstanceID, ec2Attributes.InstanceId)
+				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityAutoScalingGroup, ec2Attributes.AutoScalingGroup)
+				AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityServiceNameSource, ec2Attributes.ServiceNameSource)
+				if ec2Attributes.ServiceNameSource != entitystore.ServiceNameSourceInstrumentation {
+					// Instrumentation Service Name Source has highest priority
+					// Therefore only apply when service name source is not
+					// Instrumentation. Service instrumented with "unknown_service" name
+					// will not be an issue since we have logics to modify it with propoer
+					// service name and source
+					p.entityTransformer.ApplyTransforms(resourceAttrs)
 				}
-				if err := validate.Struct(ec2Attributes); err == nil {
-					resourceAttrs.PutStr(entityattributes.AttributeEntityPlatformType, entityPlatformType)
-					AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityInstanceID, ec2Attributes.InstanceId)
-					AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityAutoScalingGroup, ec2Attributes.AutoScalingGroup)
-					AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityServiceNameSource, ec2Attributes.ServiceNameSource)
-					if ec2Attributes.ServiceNameSource != entitystore.ServiceNameSourceInstrumentation {
-						// Instrumentation Service Name Source has highest priority
-						// Therefore only apply when service name source is not
-						// Instrumentation. Service instrumented with "unknown_service" name
-						// will not be an issue since we have logics to modify it with propoer
-						// service name and source
-						p.entityTransformer.ApplyTransforms(resourceAttrs)
-					}
 
-				}
 			}
-			if logGroupNames == EMPTY || (serviceName == EMPTY && environmentName == EMPTY) {
-				continue
+		} else if p.config.HostMetadata {
+			// The host metadata stands in for IMDS, so the service name falls back to the tags of the host like
+			// on EC2. The host is not an EC2 instance, so there is no platform, instance ID or Auto Scaling group,
+			// the same attributes the entity store sets for the log files of the host.
+			if shouldUseFallbackServiceName(entityServiceName) {
+				entityServiceName, entityServiceNameSource = getServiceNameSource()
+			} else if entityServiceName != EMPTY && entityServiceNameSource == EMPTY {
+				entityServiceNameSource = entitystore.ServiceNameSourceInstrumentation
 			}
-
-			logGroupNamesSlice := strings.Split(logGroupNames, "&")
-			for _, logGroupName := range logGroupNamesSlice {
-				if logGroupName == EMPTY {
-					continue
-				}
-				addToEntityStore(entitystore.LogGroupName(logGroupName), serviceName, environmentName)
+			ec2Info = getEC2InfoFromEntityStore()
+
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityType, entityattributes.Service)
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityServiceName, entityServiceName)
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityDeploymentEnvironment, entityEnvironmentName)
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityAwsAccountId, ec2Info.GetAccountID())
+			AddAttributeIfNonEmpty(resourceAttrs, entityattributes.AttributeEntityServiceNameSource, entityServiceNameSource)
+			if entityServiceNameSource != entitystore.ServiceNameSourceInstrumentation {
+				p.entityTransformer.ApplyTransforms(resourceAttrs)
 			}
 		}
+		if logGroupNames == EMPTY || (serviceName == EMPTY && environmentName == EMPTY) {
+			return
+		}
 
+		logGroupNamesSlice := strings.Split(logGroupNames, "&")
+		for _, logGroupName := range logGroupNamesSlice {
+			if logGroupName == EMPTY {
+				continue
+			}
+			addToEntityStore(entitystore.LogGroupName(logGroupName), serviceName, environmentName)
+		}
 	}
-	return md, nil
 }
 
 // scrapeServiceAttribute expands the datapoint attributes and search for
@@ -537,6 +597,98 @@ func getServiceAttributes(p pcommon.Map) string {
 	return EMPTY
 }
 
+// entityRuleValues returns the values of the entity rule sources. The
+// attributes are looked up in the resource, and in the datapoints if they are
+// scraped. The entity is set on the resource, so a datapoint attribute is only
+// used if every datapoint of the resource has the same value, e.g. the process
+// name of a procstat metric, but not of a batch with several processes.
+func entityRuleValues(resourceAttrs pcommon.Map, logGroupNames string, scopeMetrics pmetric.ScopeMetricsSlice, scrapeDatapoints bool) func(source, key string) []string {
+	getAttribute := func(keys ...string) []string {
+		for _, key := range keys {
+			if value, ok := resourceAttrs.Get(key); ok && value.Str() != EMPTY {
+				return []string{value.Str()}
+			}
+		}
+		if scrapeDatapoints {
+			if value := scrapeDatapointAttribute(scopeMetrics, keys...); value != EMPTY {
+				return []string{value}
+			}
+		}
+		return nil
+	}
+	return func(source, key string) []string {
+		switch source {
+		case entity.RuleSourceLogGroup:
+			var values []string
+			for _, logGroupName := range strings.Split(logGroupNames, "&") {
+				if logGroupName != EMPTY {
+					values = append(values, logGroupName)
+				}
+			}
+			return values
+		case entity.RuleSourceProcessName:
+			return getAttribute(semconv.AttributeProcessExecutableName, attributeProcstatProcessName)
+		case entity.RuleSourceContainerLabel:
+			return getAttribute(attributeContainerLabelPrefix + key)
+		case entity.RuleSourceResourceAttribute:
+			return getAttribute(key)
+		}
+		return nil
+	}
+}
+
+// scrapeDatapointAttribute returns the value of the first key that is set on
+// the datapoints, if every datapoint has the same value.
+func scrapeDatapointAttribute(scopeMetrics pmetric.ScopeMetricsSlice, keys ...string) string {
+	shared := EMPTY
+	for i := 0; i < scopeMetrics.Len(); i++ {
+		metrics := scopeMetrics.At(i).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			for _, attributes := range datapointAttributes(metrics.At(j)) {
+				value := EMPTY
+				for _, key := range keys {
+					if v, ok := attributes.Get(key); ok && v.Str() != EMPTY {
+						value = v.Str()
+						break
+					}
+				}
+				if value == EMPTY || (shared != EMPTY && value != shared) {
+					return EMPTY
+				}
+				shared = value
+			}
+		}
+	}
+	return shared
+}
+
+func datapointAttributes(m pmetric.Metric) []pcommon.Map {
+	var attributes []pcommon.Map
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			attributes = append(attributes, m.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			attributes = append(attributes, m.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			attributes = append(attributes, m.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			attributes = append(attributes, m.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			attributes = append(attributes, m.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+	return attributes
+}
+
 func getTelemetrySDKEnabledAttribute(p pcommon.Map) bool {
 	if _, ok := p.Get(semconv.AttributeTelemetrySDKName); ok {
 		return true
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
 	"go.uber.org/zap"
@@ -653,6 +654,275 @@ func TestProcessMetricsResourceAttributeScraping(t *testing.T) {
 	}
 }
 
+func TestProcessMetricsEntityRules(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	ctx := context.Background()
+	rules := []entity.Rule{
+		{Source: entity.RuleSourceLogGroup, Pattern: "/aws/(?P<team>[^/]+)/(?P<app>[^/]+)", ServiceName: "{{app}}", Environment: "{{team}}"},
+		{Source: entity.RuleSourceResourceAttribute, Key: "host.type", Pattern: "t3\\..*", Environment: "burstable", Priority: 1},
+	}
+	tests := []struct {
+		name    string
+		metrics pmetric.Metrics
+		want    map[string]any
+	}{
+		{
+			name:    "LogGroupRule",
+			metrics: generateMetrics(attributeAwsLogGroupNames, "other&/aws/billing/invoices"),
+			want: map[string]any{
+				entityattributes.AttributeEntityType:                  "Service",
+				entityattributes.AttributeEntityServiceName:           "invoices",
+				entityattributes.AttributeEntityDeploymentEnvironment: "billing",
+				entityattributes.AttributeEntityPlatformType:          "AWS::EC2",
+				entityattributes.AttributeEntityInstanceID:            "i-123456789",
+				entityattributes.AttributeEntityAwsAccountId:          "0123456789012",
+				entityattributes.AttributeEntityServiceNameSource:     entitystore.ServiceNameSourceEntityRule,
+				attributeAwsLogGroupNames:                             "other&/aws/billing/invoices",
+			},
+		},
+		{
+			name:    "HigherPriorityRuleWithoutServiceName",
+			metrics: generateMetrics(attributeAwsLogGroupNames, "/aws/billing/invoices", "host.type", "t3.micro"),
+			want: map[string]any{
+				entityattributes.AttributeEntityType:                  "Service",
+				entityattributes.AttributeEntityServiceName:           "test-iam-role",
+				entityattributes.AttributeEntityDeploymentEnvironment: "burstable",
+				entityattributes.AttributeEntityPlatformType:          "AWS::EC2",
+				entityattributes.AttributeEntityInstanceID:            "i-123456789",
+				entityattributes.AttributeEntityAwsAccountId:          "0123456789012",
+				entityattributes.AttributeEntityServiceNameSource:     "ClientIamRole",
+				attributeAwsLogGroupNames:                             "/aws/billing/invoices",
+				"host.type":                                           "t3.micro",
+			},
+		},
+		{
+			name:    "TelemetryAttributesTakePrecedence",
+			metrics: generateMetrics(attributeAwsLogGroupNames, "/aws/billing/invoices", attributeServiceName, "test-service"),
+			want: map[string]any{
+				entityattributes.AttributeEntityType:                  "Service",
+				entityattributes.AttributeEntityServiceName:           "test-service",
+				entityattributes.AttributeEntityDeploymentEnvironment: "billing",
+				entityattributes.AttributeEntityPlatformType:          "AWS::EC2",
+				entityattributes.AttributeEntityInstanceID:            "i-123456789",
+				entityattributes.AttributeEntityAwsAccountId:          "0123456789012",
+				entityattributes.AttributeEntityServiceNameSource:     "Instrumentation",
+				attributeAwsLogGroupNames:                             "/aws/billing/invoices",
+				attributeServiceName:                                  "test-service",
+			},
+		},
+		{
+			name:    "NoMatch",
+			metrics: generateMetrics(attributeAwsLogGroupNames, "/other/log-group/name"),
+			want: map[string]any{
+				entityattributes.AttributeEntityType:                  "Service",
+				entityattributes.AttributeEntityServiceName:           "test-iam-role",
+				entityattributes.AttributeEntityDeploymentEnvironment: "ec2:default",
+				entityattributes.AttributeEntityPlatformType:          "AWS::EC2",
+				entityattributes.AttributeEntityInstanceID:            "i-123456789",
+				entityattributes.AttributeEntityAwsAccountId:          "0123456789012",
+				entityattributes.AttributeEntityServiceNameSource:     "ClientIamRole",
+				attributeAwsLogGroupNames:                             "/other/log-group/name",
+			},
+		},
+	}
+
+	resetServiceNameSource := getServiceNameSource
+	resetGetEC2InfoFromEntityStore := getEC2InfoFromEntityStore
+	resetGetAutoScalingGroupFromEntityStore := getAutoScalingGroupFromEntityStore
+	defer func() {
+		getServiceNameSource = resetServiceNameSource
+		getEC2InfoFromEntityStore = resetGetEC2InfoFromEntityStore
+		getAutoScalingGroupFromEntityStore = resetGetAutoScalingGroupFromEntityStore
+	}()
+	getServiceNameSource = newMockGetServiceNameAndSource("test-iam-role", "ClientIamRole")
+	getEC2InfoFromEntityStore = newMockGetEC2InfoFromEntityStore("i-123456789", "0123456789012")
+	getAutoScalingGroupFromEntityStore = newMockGetAutoScalingGroupFromEntityStore("")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newAwsEntityProcessor(&Config{EntityType: attributeService, EntityRules: rules}, logger)
+			p.config.Platform = config.ModeEC2
+			_, err := p.processMetrics(ctx, tt.metrics)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, tt.metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+		})
+	}
+}
+
+// The rules rank above the Auto Scaling group, as in the entity store.
+func TestProcessMetricsEntityRulesAboveAutoScalingGroup(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	rules := []entity.Rule{
+		{Source: entity.RuleSourceLogGroup, Pattern: "gr(?P<suffix>.+)", ServiceName: "test-service-from-rule", Environment: "{{suffix}}"},
+	}
+	resetServiceNameSource := getServiceNameSource
+	resetGetEC2InfoFromEntityStore := getEC2InfoFromEntityStore
+	resetGetAutoScalingGroupFromEntityStore := getAutoScalingGroupFromEntityStore
+	defer func() {
+		getServiceNameSource = resetServiceNameSource
+		getEC2InfoFromEntityStore = resetGetEC2InfoFromEntityStore
+		getAutoScalingGroupFromEntityStore = resetGetAutoScalingGroupFromEntityStore
+	}()
+	getServiceNameSource = newMockGetServiceNameAndSource("test-service-from-tags", entitystore.ServiceNameSourceResourceTags)
+	getEC2InfoFromEntityStore = newMockGetEC2InfoFromEntityStore("i-123456789", "0123456789012")
+	getAutoScalingGroupFromEntityStore = newMockGetAutoScalingGroupFromEntityStore("test-asg")
+
+	tests := []struct {
+		name            string
+		metrics         pmetric.Metrics
+		wantService     string
+		wantSource      string
+		wantEnvironment string
+	}{
+		{
+			name:            "RuleMatched",
+			metrics:         generateMetrics(attributeAwsLogGroupNames, "group"),
+			wantService:     "test-service-from-rule",
+			wantSource:      entitystore.ServiceNameSourceEntityRule,
+			wantEnvironment: "oup",
+		},
+		{
+			name:            "TelemetryServiceName",
+			metrics:         generateMetrics(attributeAwsLogGroupNames, "group", attributeServiceName, "test-service"),
+			wantService:     "test-service",
+			wantSource:      entitystore.ServiceNameSourceInstrumentation,
+			wantEnvironment: "oup",
+		},
+		{
+			name:            "NoMatch",
+			metrics:         generateMetrics(attributeAwsLogGroupNames, "other"),
+			wantService:     "test-service-from-tags",
+			wantSource:      entitystore.ServiceNameSourceResourceTags,
+			wantEnvironment: "ec2:test-asg",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newAwsEntityProcessor(&Config{EntityType: attributeService, Platform: config.ModeEC2, EntityRules: rules}, logger)
+			_, err := p.processMetrics(context.Background(), tt.metrics)
+			assert.NoError(t, err)
+			attrs := tt.metrics.ResourceMetrics().At(0).Resource().Attributes()
+			service, _ := attrs.Get(entityattributes.AttributeEntityServiceName)
+			assert.Equal(t, tt.wantService, service.Str())
+			source, _ := attrs.Get(entityattributes.AttributeEntityServiceNameSource)
+			assert.Equal(t, tt.wantSource, source.Str())
+			environment, _ := attrs.Get(entityattributes.AttributeEntityDeploymentEnvironment)
+			assert.Equal(t, tt.wantEnvironment, environment.Str())
+		})
+	}
+}
+
+func TestProcessMetricsHostMetadata(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	rules := []entity.Rule{
+		{Source: entity.RuleSourceLogGroup, Pattern: "/onprem/(?P<site>[^/]+)/.+", Environment: "{{site}}"},
+	}
+	resetServiceNameSource := getServiceNameSource
+	resetGetEC2InfoFromEntityStore := getEC2InfoFromEntityStore
+	defer func() {
+		getServiceNameSource = resetServiceNameSource
+		getEC2InfoFromEntityStore = resetGetEC2InfoFromEntityStore
+	}()
+	getServiceNameSource = newMockGetServiceNameAndSource("billing", entitystore.ServiceNameSourceResourceTags)
+	getEC2InfoFromEntityStore = newMockGetEC2InfoFromEntityStore("host-1", "123456789012")
+
+	tests := []struct {
+		name    string
+		config  *Config
+		metrics pmetric.Metrics
+		want    map[string]any
+	}{
+		{
+			name:    "ServiceNameFromHostTags",
+			config:  &Config{EntityType: attributeService, Platform: config.ModeOnPremise, HostMetadata: true, EntityRules: rules},
+			metrics: generateMetrics(attributeAwsLogGroupNames, "/onprem/dc-1/app"),
+			want: map[string]any{
+				entityattributes.AttributeEntityType:                  "Service",
+				entityattributes.AttributeEntityServiceName:           "billing",
+				entityattributes.AttributeEntityDeploymentEnvironment: "dc-1",
+				entityattributes.AttributeEntityAwsAccountId:          "123456789012",
+				entityattributes.AttributeEntityServiceNameSource:     entitystore.ServiceNameSourceResourceTags,
+				attributeAwsLogGroupNames:                             "/onprem/dc-1/app",
+			},
+		},
+		{
+			name:    "ServiceNameFromTelemetry",
+			config:  &Config{EntityType: attributeService, Platform: config.ModeOnPremise, HostMetadata: true},
+			metrics: generateMetrics(attributeServiceName, "test-service"),
+			want: map[string]any{
+				entityattributes.AttributeEntityType:              "Service",
+				entityattributes.AttributeEntityServiceName:       "test-service",
+				entityattributes.AttributeEntityAwsAccountId:      "123456789012",
+				entityattributes.AttributeEntityServiceNameSource: entitystore.ServiceNameSourceInstrumentation,
+				attributeServiceName:                              "test-service",
+			},
+		},
+		{
+			name:    "WithoutHostMetadata",
+			config:  &Config{EntityType: attributeService, Platform: config.ModeOnPremise},
+			metrics: generateMetrics(attributeServiceName, "test-service"),
+			want: map[string]any{
+				attributeServiceName: "test-service",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newAwsEntityProcessor(tt.config, logger)
+			_, err := p.processMetrics(context.Background(), tt.metrics)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, tt.metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+		})
+	}
+}
+
+func TestProcessMetricsEntityRulesProcessName(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	rules := []entity.Rule{
+		{Source: entity.RuleSourceProcessName, Pattern: "(?P<app>.+)-server", ServiceName: "{{app}}"},
+	}
+	resetServiceNameSource := getServiceNameSource
+	resetGetEC2InfoFromEntityStore := getEC2InfoFromEntityStore
+	resetGetAutoScalingGroupFromEntityStore := getAutoScalingGroupFromEntityStore
+	defer func() {
+		getServiceNameSource = resetServiceNameSource
+		getEC2InfoFromEntityStore = resetGetEC2InfoFromEntityStore
+		getAutoScalingGroupFromEntityStore = resetGetAutoScalingGroupFromEntityStore
+	}()
+	getServiceNameSource = newMockGetServiceNameAndSource("test-iam-role", "ClientIamRole")
+	getEC2InfoFromEntityStore = newMockGetEC2InfoFromEntityStore("i-123456789", "0123456789012")
+	getAutoScalingGroupFromEntityStore = newMockGetAutoScalingGroupFromEntityStore("")
+
+	md := pmetric.NewMetrics()
+	// a procstat metric of one process per resource
+	for _, processName := range []string{"cart-server", "billing-server"} {
+		metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		for _, name := range []string{"procstat_cpu_usage", "procstat_memory_rss"} {
+			dp := metrics.AppendEmpty()
+			dp.SetName(name)
+			dp.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr(attributeProcstatProcessName, processName)
+		}
+	}
+	// a batch of two processes in one resource
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+	dps.AppendEmpty().Attributes().PutStr(attributeProcstatProcessName, "cart-server")
+	dps.AppendEmpty().Attributes().PutStr(attributeProcstatProcessName, "billing-server")
+
+	p := newAwsEntityProcessor(&Config{EntityType: attributeService, Platform: config.ModeEC2, ScrapeDatapointAttribute: true, EntityRules: rules}, logger)
+	_, err := p.processMetrics(context.Background(), md)
+	assert.NoError(t, err)
+
+	wantServices := []string{"cart", "billing", "test-iam-role"}
+	wantSources := []string{entitystore.ServiceNameSourceEntityRule, entitystore.ServiceNameSourceEntityRule, "ClientIamRole"}
+	for i, want := range wantServices {
+		attrs := md.ResourceMetrics().At(i).Resource().Attributes()
+		service, _ := attrs.Get(entityattributes.AttributeEntityServiceName)
+		assert.Equal(t, want, service.Str())
+		source, _ := attrs.Get(entityattributes.AttributeEntityServiceNameSource)
+		assert.Equal(t, wantSources[i], source.Str())
+	}
+}
+
 func TestProcessMetricsResourceEntityProcessing(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	ctx := context.Background()
@@ -725,6 +995,38 @@ func TestProcessMetricsResourceEntityProcessing(t *testing.T) {
 	}
 }
 
+func TestProcessLogs(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	resetServiceNameSource := getServiceNameSource
+	defer func() { getServiceNameSource = resetServiceNameSource }()
+	getServiceNameSource = newMockGetServiceNameAndSource("test-service-name", "ClientIamRole")
+	getEC2InfoFromEntityStore = newMockGetEC2InfoFromEntityStore("i-123456789", "0123456789012")
+	getAutoScalingGroupFromEntityStore = newMockGetAutoScalingGroupFromEntityStore("")
+	rs := newMockEntityStore()
+	addToEntityStore = newAddToMockEntityStore(rs)
+
+	ld := plog.NewLogs()
+	resourceAttrs := ld.ResourceLogs().AppendEmpty().Resource().Attributes()
+	resourceAttrs.PutStr(attributeServiceName, "test-service")
+	resourceAttrs.PutStr(attributeAwsLogGroupNames, "test-log-group")
+	// the service name is only looked up from the datapoints of metrics
+	p := newAwsEntityProcessor(&Config{EntityType: attributeService, Platform: config.ModeEC2, ScrapeDatapointAttribute: true}, logger)
+	_, err := p.processLogs(context.Background(), ld)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		entityattributes.AttributeEntityType:                  "Service",
+		entityattributes.AttributeEntityServiceName:           "test-service",
+		entityattributes.AttributeEntityDeploymentEnvironment: "ec2:default",
+		entityattributes.AttributeEntityPlatformType:          "AWS::EC2",
+		entityattributes.AttributeEntityInstanceID:            "i-123456789",
+		entityattributes.AttributeEntityAwsAccountId:          "0123456789012",
+		entityattributes.AttributeEntityServiceNameSource:     "Instrumentation",
+		attributeServiceName:                                  "test-service",
+		attributeAwsLogGroupNames:                             "test-log-group",
+	}, resourceAttrs.AsRaw())
+	assert.Equal(t, []entityStoreEntry{{logGroupName: "test-log-group", serviceName: "test-service"}}, rs.entries)
+}
+
 func TestAWSEntityProcessorNoSensitiveInfoInLogs(t *testing.T) {
 	// Create a buffer to capture log output
 	var buf bytes.Buffer
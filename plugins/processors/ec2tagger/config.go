@@ -4,9 +4,13 @@
 package ec2tagger
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/gobwas/glob"
 	"go.opentelemetry.io/collector/component"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 )
 
 var SupportedAppendDimensions = map[string]string{
@@ -28,6 +32,15 @@ type Config struct {
 	EC2InstanceTagKeys     []string      `mapstructure:"ec2_instance_tag_keys"`
 	EBSDeviceKeys          []string      `mapstructure:"ebs_device_keys,omitempty"`
 
+	// TagChangeLogGroup is the log group the tag change events are published
+	// to, in a log stream named after the instance. The events are always
+	// written to the agent log.
+	TagChangeLogGroup string `mapstructure:"tag_change_log_group,omitempty"`
+
+	// HostMetadata replaces IMDS and the EC2 tags with the metadata of a host
+	// that is not an EC2 instance. EBS volumes are not supported.
+	HostMetadata *hostmetadata.Config `mapstructure:"host_metadata,omitempty"`
+
 	//The tag key in the metrics for disk device
 	DiskDeviceTagKey string `mapstructure:"disk_device_tag_key,omitempty"`
 
@@ -49,5 +62,15 @@ var _ component.Config = (*Config)(nil)
 // Validate does not check for unsupported dimension key-value pairs, because those
 // get silently dropped and ignored during translation.
 func (cfg *Config) Validate() error {
+	for _, key := range cfg.EC2InstanceTagKeys {
+		if isTagKeyPattern(key) {
+			if _, err := glob.Compile(key); err != nil {
+				return fmt.Errorf("invalid ec2_instance_tag_keys pattern %q: %w", key, err)
+			}
+		}
+	}
+	if cfg.HostMetadata != nil {
+		return cfg.HostMetadata.Validate()
+	}
 	return nil
 }
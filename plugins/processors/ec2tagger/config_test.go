@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 )
 
 func TestUnmarshalDefaultConfig(t *testing.T) {
@@ -50,3 +52,19 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTagKeyPatterns(t *testing.T) {
+	cfg := &Config{EC2InstanceTagKeys: []string{"Name", "team:*", "env-?"}}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.EC2InstanceTagKeys = []string{"team:[*"}
+	assert.ErrorContains(t, cfg.Validate(), `invalid ec2_instance_tag_keys pattern "team:[*"`)
+}
+
+func TestValidateHostMetadata(t *testing.T) {
+	cfg := &Config{HostMetadata: &hostmetadata.Config{File: "/etc/host-metadata.yaml"}}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.HostMetadata = &hostmetadata.Config{}
+	assert.Error(t, cfg.Validate())
+}
@@ -30,6 +30,7 @@ const sampleConfig = `
   ## Add tags retrieved from the EC2 Instance Tags associated with this instance.
   ## If this configuration is not provided, or has an empty list, no EC2 Instance Tags are applied.
   ## If this configuration contains one entry and its value is "*", then ALL EC2 Instance Tags for the instance are applied.
+  ## Keys can be glob patterns, e.g. "team:*" applies all EC2 Instance Tags with the "team:" prefix.
   ## Note: This plugin renames the "aws:autoscaling:groupName" EC2 Instance Tag key to be spelled "AutoScalingGroupName".
   ## This aligns it with the AutoScaling dimension-name seen in AWS CloudWatch.
   # ec2_instance_tag_keys = ["aws:autoscaling:groupName", "Name"]
@@ -43,6 +44,10 @@ const sampleConfig = `
   ## Specify which tag to use to get the specified disk device name from input Metric
   # disk_device_tag_key = "device"
   ##
+  ## Publish an event to this log group when the EC2 Instance Tags change on refresh.
+  ## The events are always written to the agent log.
+  # tag_change_log_group = "/aws/ec2/tag-changes"
+  ##
   ## Amazon Credentials
   ## Credentials are loaded in the following order
   ## 1) Assumed credentials via STS if role_arn is specified
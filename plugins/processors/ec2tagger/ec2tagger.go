@@ -7,6 +7,7 @@ import (
 	"context"
 	"hash/fnv"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/gobwas/glob"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -21,7 +23,10 @@ import (
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
 	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger/internal/volume"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs/cloudwatchlogsiface"
 	translatorCtx "github.com/aws/amazon-cloudwatch-agent/translator/context"
 )
 
@@ -40,20 +45,28 @@ type ec2MetadataRespondType struct {
 
 type ec2ProviderType func(*configaws.CredentialConfig) ec2iface.EC2API
 
+type cloudWatchLogsProviderType func(*configaws.CredentialConfig) cloudwatchlogsiface.CloudWatchLogsAPI
+
 type Tagger struct {
 	*Config
 
 	logger           *zap.Logger
 	cancelFunc       context.CancelFunc
 	metadataProvider ec2metadataprovider.MetadataProvider
+	hostMetadata     *hostmetadata.Provider
 	ec2Provider      ec2ProviderType
+	cwlProvider      cloudWatchLogsProviderType
 
 	shutdownC          chan bool
 	ec2TagCache        map[string]string
+	tagsRetrieved      bool
 	started            bool
 	ec2MetadataLookup  ec2MetadataLookupType
 	ec2MetadataRespond ec2MetadataRespondType
 	tagFilters         []*ec2.Filter
+	tagKeys            map[string]bool
+	tagKeyPatterns     []glob.Glob
+	tagChangePublisher *tagChangePublisher
 	ec2API             ec2iface.EC2API
 	volumeSerialCache  volume.Cache
 
@@ -64,12 +77,10 @@ type Tagger struct {
 // newTagger returns a new EC2 Tagger processor.
 func newTagger(config *Config, logger *zap.Logger) *Tagger {
 	_, cancel := context.WithCancel(context.Background())
-	mdCredentialConfig := &configaws.CredentialConfig{}
 	p := &Tagger{
-		Config:           config,
-		logger:           logger,
-		cancelFunc:       cancel,
-		metadataProvider: ec2metadataprovider.NewMetadataProvider(mdCredentialConfig.Credentials(), config.IMDSRetries),
+		Config:     config,
+		logger:     logger,
+		cancelFunc: cancel,
 		ec2Provider: func(ec2CredentialConfig *configaws.CredentialConfig) ec2iface.EC2API {
 			return ec2.New(
 				ec2CredentialConfig.Credentials(),
@@ -78,10 +89,57 @@ func newTagger(config *Config, logger *zap.Logger) *Tagger {
 					Logger:   configaws.SDKLogger{},
 				})
 		},
+		cwlProvider: func(cwlCredentialConfig *configaws.CredentialConfig) cloudwatchlogsiface.CloudWatchLogsAPI {
+			return cloudwatchlogs.New(
+				cwlCredentialConfig.Credentials(),
+				&aws.Config{
+					LogLevel: configaws.SDKLogLevel(),
+					Logger:   configaws.SDKLogger{},
+				})
+		},
+	}
+	if config.HostMetadata != nil {
+		p.hostMetadata = hostmetadata.NewProvider(*config.HostMetadata)
+		p.metadataProvider = p.hostMetadata
+	} else {
+		mdCredentialConfig := &configaws.CredentialConfig{}
+		p.metadataProvider = ec2metadataprovider.NewMetadataProvider(mdCredentialConfig.Credentials(), config.IMDSRetries)
 	}
 	return p
 }
 
+// isTagKeyPattern returns whether the key of ec2_instance_tag_keys is a glob
+// pattern, e.g. "team:*", that selects every tag with a matching key.
+func isTagKeyPattern(key string) bool {
+	return strings.ContainsAny(key, "*?")
+}
+
+// onlyTagKeyPatterns returns whether every configured tag key is a pattern,
+// in which case there is no way to check if all tags are retrieved.
+func (t *Tagger) onlyTagKeyPatterns() bool {
+	for _, key := range t.EC2InstanceTagKeys {
+		if !isTagKeyPattern(key) {
+			return false
+		}
+	}
+	return len(t.EC2InstanceTagKeys) > 0
+}
+
+// selectTagKey returns whether the tag is configured. EC2 already filters the
+// tags by the same keys and patterns, but the host metadata has every tag of
+// the host.
+func (t *Tagger) selectTagKey(key string) bool {
+	if t.tagKeys == nil || t.tagKeys[key] {
+		return true
+	}
+	for _, pattern := range t.tagKeyPatterns {
+		if pattern.Match(key) {
+			return true
+		}
+	}
+	return false
+}
+
 func getOtelAttributes(m pmetric.Metric) []pcommon.Map {
 	attributes := []pcommon.Map{}
 	switch m.Type() {
@@ -174,8 +232,20 @@ func (t *Tagger) updateOtelAttributes(attributes []pcommon.Map) {
 	}
 }
 
-// updateTags calls EC2 Describe Tags and replaces the Tagger's tagCache with the newly retrieved values
-func (t *Tagger) updateTags() error {
+// addTag adds the tag to the tags if it is configured.
+func (t *Tagger) addTag(tags map[string]string, key, value string) {
+	if !t.selectTagKey(key) {
+		return
+	}
+	if Ec2InstanceTagKeyASG == key {
+		// rename to match CW dimension as applied by AutoScaling service, not the EC2 tag
+		key = CWDimensionASG
+	}
+	tags[key] = value
+}
+
+// describeTags calls EC2 Describe Tags for the tags of the instance.
+func (t *Tagger) describeTags() (map[string]string, error) {
 	tags := make(map[string]string)
 	input := &ec2.DescribeTagsInput{
 		Filters: t.tagFilters,
@@ -184,24 +254,62 @@ func (t *Tagger) updateTags() error {
 	for {
 		result, err := t.ec2API.DescribeTags(input)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		for _, tag := range result.Tags {
-			key := *tag.Key
-			if Ec2InstanceTagKeyASG == key {
-				// rename to match CW dimension as applied by AutoScaling service, not the EC2 tag
-				key = CWDimensionASG
-			}
-			tags[key] = *tag.Value
+			t.addTag(tags, *tag.Key, *tag.Value)
 		}
 		if result.NextToken == nil {
 			break
 		}
 		input.SetNextToken(*result.NextToken)
 	}
+	return tags, nil
+}
+
+// hostMetadataTags reloads the host metadata for the tags of the host.
+func (t *Tagger) hostMetadataTags() (map[string]string, error) {
+	metadata, err := t.hostMetadata.Refresh(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string)
+	for key, value := range metadata.Tags {
+		if CWDimensionASG == key {
+			key = Ec2InstanceTagKeyASG
+		}
+		t.addTag(tags, key, value)
+	}
+	return tags, nil
+}
+
+// updateTags retrieves the tags and replaces the Tagger's tagCache with the newly retrieved values.
+// If the tags changed since the last retrieval, a tag change event is emitted.
+func (t *Tagger) updateTags() error {
+	var tags map[string]string
+	var err error
+	if t.hostMetadata != nil {
+		tags, err = t.hostMetadataTags()
+	} else {
+		tags, err = t.describeTags()
+	}
+	if err != nil {
+		return err
+	}
 	t.Lock()
-	defer t.Unlock()
+	oldTags := t.ec2TagCache
+	tagsRetrieved := t.tagsRetrieved
 	t.ec2TagCache = tags
+	t.tagsRetrieved = true
+	t.Unlock()
+
+	// the initial retrieval is not a change
+	if tagsRetrieved {
+		if event := diffTags(t.ec2MetadataRespond.instanceId, oldTags, tags); !event.empty() {
+			event.Timestamp = time.Now()
+			t.emitTagChange(event)
+		}
+	}
 	return nil
 }
 
@@ -288,7 +396,7 @@ func (t *Tagger) ec2TagsRetrieved() bool {
 			if key == Ec2InstanceTagKeyASG {
 				key = CWDimensionASG
 			}
-			if key == "*" {
+			if isTagKeyPattern(key) {
 				continue
 			}
 			if _, ok := t.ec2TagCache[key]; !ok {
@@ -349,6 +457,25 @@ func (t *Tagger) Start(ctx context.Context, host component.Host) error {
 			Name:   aws.String("key"),
 			Values: aws.StringSlice(t.EC2InstanceTagKeys),
 		})
+
+		// EC2 supports the same wildcards in the filter values
+		t.tagKeys = map[string]bool{}
+		t.tagKeyPatterns = nil
+		for _, key := range t.EC2InstanceTagKeys {
+			if !isTagKeyPattern(key) {
+				t.tagKeys[key] = true
+				continue
+			}
+			pattern, err := glob.Compile(key)
+			if err != nil {
+				return err
+			}
+			t.tagKeyPatterns = append(t.tagKeyPatterns, pattern)
+		}
+	}
+	if t.hostMetadata != nil && len(t.EBSDeviceKeys) > 0 {
+		t.logger.Warn("ec2tagger: EBS volumes are not available from the host metadata, ignoring the EBS device keys.")
+		t.EBSDeviceKeys = nil
 	}
 	if len(t.EC2InstanceTagKeys) > 0 || len(t.EBSDeviceKeys) > 0 {
 		ec2CredentialConfig := &configaws.CredentialConfig{
@@ -360,11 +487,27 @@ func (t *Tagger) Start(ctx context.Context, host component.Host) error {
 			Token:     t.Token,
 			Region:    t.ec2MetadataRespond.region,
 		}
-		t.ec2API = t.ec2Provider(ec2CredentialConfig)
+		if t.hostMetadata == nil {
+			t.ec2API = t.ec2Provider(ec2CredentialConfig)
 
-		if client, ok := t.ec2API.(*ec2.EC2); ok {
-			if t.Config.MiddlewareID != nil {
-				awsmiddleware.TryConfigure(t.logger, host, *t.Config.MiddlewareID, awsmiddleware.SDKv1(&client.Handlers))
+			if client, ok := t.ec2API.(*ec2.EC2); ok {
+				if t.Config.MiddlewareID != nil {
+					awsmiddleware.TryConfigure(t.logger, host, *t.Config.MiddlewareID, awsmiddleware.SDKv1(&client.Handlers))
+				}
+			}
+		}
+
+		if t.TagChangeLogGroup != "" && len(t.EC2InstanceTagKeys) > 0 {
+			cwlAPI := t.cwlProvider(ec2CredentialConfig)
+			if client, ok := cwlAPI.(*cloudwatchlogs.CloudWatchLogs); ok {
+				if t.Config.MiddlewareID != nil {
+					awsmiddleware.TryConfigure(t.logger, host, *t.Config.MiddlewareID, awsmiddleware.SDKv1(&client.Handlers))
+				}
+			}
+			t.tagChangePublisher = &tagChangePublisher{
+				client:    cwlAPI,
+				logGroup:  t.TagChangeLogGroup,
+				logStream: t.ec2MetadataRespond.instanceId,
 			}
 		}
 
@@ -391,9 +534,9 @@ func (t *Tagger) refreshLoopToUpdateTags() {
 		//update tags values once they are retrieved successfully. In this case,
 		//we still want to do refresh to make sure all the specified keys for tags/volumes
 		//are fetched successfully because initial retrieval might not get all of them.
-		//When the specified key is "*", there is no way for us to check if all
+		//When the specified keys are "*" or other patterns, there is no way for us to check if all
 		//tags are fetched. So there is no need to do refresh in this case.
-		needRefresh = !(len(t.EC2InstanceTagKeys) == 1 && t.EC2InstanceTagKeys[0] == "*")
+		needRefresh = !t.onlyTagKeyPatterns()
 
 		stopAfterFirstSuccess = true
 		refreshInterval = defaultRefreshInterval
@@ -481,6 +624,10 @@ func (t *Tagger) deriveEC2MetadataFromIMDS(ctx context.Context) error {
 
 	t.logger.Info("ec2tagger: Check EC2 Metadata.")
 	doc, err := t.metadataProvider.Get(ctx)
+	if err != nil && t.hostMetadata != nil {
+		t.logger.Error("ec2tagger: Unable to load the host metadata.", zap.Error(err))
+		return err
+	}
 	if err != nil {
 		t.logger.Error("ec2tagger: Unable to retrieve EC2 Metadata. This plugin must only be used on an EC2 instance.")
 		if translatorCtx.CurrentContext().RunInContainer() {
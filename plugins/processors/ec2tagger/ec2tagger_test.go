@@ -5,7 +5,10 @@ package ec2tagger
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -20,9 +23,13 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 	"golang.org/x/exp/maps"
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs/cloudwatchlogsiface"
 )
 
 type mockEC2Client struct {
@@ -736,3 +743,144 @@ func TestTaggerStartsWithoutTagOrVolume(t *testing.T) {
 	assert.Equal(t, tagger.started, true)
 	close(inited)
 }
+
+// run Start() with a tag key pattern and check only the matching tags are saved
+func TestStartSuccessWithTagKeyPattern(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RefreshTagsInterval = 0 * time.Second
+	cfg.EC2InstanceTagKeys = []string{"tagKey*"}
+	_, cancel := context.WithCancel(context.Background())
+	ec2Client := &mockEC2Client{
+		tagsCallCount:    0,
+		tagsFailLimit:    -1,
+		tagsPartialLimit: -1,
+		UseUpdatedTags:   false,
+	}
+	ec2Provider := func(*configaws.CredentialConfig) ec2iface.EC2API {
+		return ec2Client
+	}
+	BackoffSleepArray = []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}
+	defaultRefreshInterval = 50 * time.Millisecond
+	tagger := &Tagger{
+		Config:           cfg,
+		logger:           processortest.NewNopSettings(component.MustNewType("ec2tagger")).Logger,
+		cancelFunc:       cancel,
+		metadataProvider: &mockMetadataProvider{InstanceIdentityDocument: mockedInstanceIdentityDoc},
+		ec2Provider:      ec2Provider,
+	}
+	err := tagger.Start(context.Background(), componenttest.NewNopHost())
+	assert.Nil(t, err)
+	time.Sleep(time.Second)
+	//there is no way to check if all tags matching a pattern are retrieved, so there is no refresh
+	assert.Equal(t, 1, ec2Client.tagsCallCount)
+	expectedTags := map[string]string{tagKey1: tagVal1, tagKey2: tagVal2}
+	tagger.RLock()
+	defer tagger.RUnlock()
+	assert.Equal(t, expectedTags, tagger.ec2TagCache)
+}
+
+// run Start() with a refresh interval and check the tag changes are logged and published
+func TestTagChangeEvents(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RefreshTagsInterval = 20 * time.Millisecond
+	cfg.EC2InstanceTagKeys = []string{tagKey1, tagKey2}
+	cfg.TagChangeLogGroup = "/aws/ec2/tag-changes"
+	_, cancel := context.WithCancel(context.Background())
+	ec2Client := &mockEC2Client{
+		tagsCallCount:    0,
+		tagsFailLimit:    -1,
+		tagsPartialLimit: -1,
+		UseUpdatedTags:   false,
+	}
+	cwlClient := &mockCloudWatchLogsClient{}
+	core, logs := observer.New(zap.InfoLevel)
+	BackoffSleepArray = []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}
+	tagger := &Tagger{
+		Config:           cfg,
+		logger:           zap.New(core),
+		cancelFunc:       cancel,
+		metadataProvider: &mockMetadataProvider{InstanceIdentityDocument: mockedInstanceIdentityDoc},
+		ec2Provider: func(*configaws.CredentialConfig) ec2iface.EC2API {
+			return ec2Client
+		},
+		cwlProvider: func(*configaws.CredentialConfig) cloudwatchlogsiface.CloudWatchLogsAPI {
+			return cwlClient
+		},
+	}
+	err := tagger.Start(context.Background(), componenttest.NewNopHost())
+	assert.Nil(t, err)
+	time.Sleep(500 * time.Millisecond)
+	//the initial retrieval and refreshes without changes do not emit events
+	assert.Zero(t, logs.FilterMessage("ec2tagger: EC2 instance tags changed").Len())
+	assert.Empty(t, cwlClient.getMessages())
+
+	ec2Client.UseUpdatedTags = true
+	time.Sleep(500 * time.Millisecond)
+	assert.NoError(t, tagger.Shutdown(context.Background()))
+
+	changes := logs.FilterMessage("ec2tagger: EC2 instance tags changed").All()
+	require.Len(t, changes, 1)
+	assert.Equal(t, map[string]any{
+		"instanceId": "i-01d2417c27a396e44",
+		"changed":    map[string]TagValueChange{tagKey2: {Old: tagVal2, New: updatedTagVal2}},
+	}, changes[0].ContextMap())
+
+	messages := cwlClient.getMessages()
+	require.Len(t, messages, 1)
+	var event TagChangeEvent
+	require.NoError(t, json.Unmarshal([]byte(messages[0]), &event))
+	assert.Equal(t, "i-01d2417c27a396e44", event.InstanceID)
+	assert.Equal(t, map[string]TagValueChange{tagKey2: {Old: tagVal2, New: updatedTagVal2}}, event.Changed)
+	assert.Empty(t, event.Added)
+	assert.Empty(t, event.Removed)
+}
+
+// run Start() with host metadata and check the tags and the metadata come from the file
+func TestStartWithHostMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "host-metadata.yaml")
+	writeHostMetadata := func(environment string) {
+		content := "host_id: mi-0123456789abcdef0\ninstance_type: r740\ntags:\n  Name: web\n  Environment: " + environment + "\n  AutoScalingGroupName: web-fleet\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	writeHostMetadata("blue")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.RefreshTagsInterval = 20 * time.Millisecond
+	cfg.EC2MetadataTags = []string{MdKeyInstanceID, MdKeyInstanceType}
+	cfg.EC2InstanceTagKeys = []string{"Environment", CWDimensionASG}
+	cfg.EBSDeviceKeys = []string{"*"}
+	cfg.HostMetadata = &hostmetadata.Config{File: path}
+	core, logs := observer.New(zap.InfoLevel)
+	BackoffSleepArray = []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}
+	tagger := newTagger(cfg, zap.New(core))
+	tagger.ec2Provider = func(*configaws.CredentialConfig) ec2iface.EC2API {
+		assert.Fail(t, "EC2 must not be called with host metadata")
+		return nil
+	}
+	require.NoError(t, tagger.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Empty(t, tagger.EBSDeviceKeys)
+	time.Sleep(200 * time.Millisecond)
+
+	md := createTestMetrics([]map[string]string{{"host": "on-premise"}})
+	output, err := tagger.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	checkAttributes(t, createTestMetrics([]map[string]string{{
+		"Environment":     "blue",
+		CWDimensionASG:    "web-fleet",
+		MdKeyInstanceID:   "mi-0123456789abcdef0",
+		MdKeyInstanceType: "r740",
+	}}), output)
+
+	writeHostMetadata("green")
+	time.Sleep(200 * time.Millisecond)
+	assert.NoError(t, tagger.Shutdown(context.Background()))
+	tagger.RLock()
+	assert.Equal(t, map[string]string{"Environment": "green", CWDimensionASG: "web-fleet"}, tagger.ec2TagCache)
+	tagger.RUnlock()
+	changes := logs.FilterMessage("ec2tagger: EC2 instance tags changed").All()
+	require.Len(t, changes, 1)
+	assert.Equal(t, map[string]any{
+		"instanceId": "mi-0123456789abcdef0",
+		"changed":    map[string]TagValueChange{"Environment": {Old: "blue", New: "green"}},
+	}, changes[0].ContextMap())
+}
@@ -0,0 +1,144 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package ec2tagger
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs/cloudwatchlogsiface"
+)
+
+// TagValueChange is the old and the new value of a tag.
+type TagValueChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// TagChangeEvent describes how the tags of the instance changed between two
+// refreshes. The metrics switch to the new dimension values after the event.
+type TagChangeEvent struct {
+	Timestamp  time.Time                 `json:"timestamp"`
+	InstanceID string                    `json:"instance_id"`
+	Added      map[string]string         `json:"added,omitempty"`
+	Removed    map[string]string         `json:"removed,omitempty"`
+	Changed    map[string]TagValueChange `json:"changed,omitempty"`
+}
+
+func (e TagChangeEvent) empty() bool {
+	return len(e.Added) == 0 && len(e.Removed) == 0 && len(e.Changed) == 0
+}
+
+// diffTags returns the change event from the old to the new tags.
+func diffTags(instanceID string, oldTags, newTags map[string]string) TagChangeEvent {
+	event := TagChangeEvent{InstanceID: instanceID}
+	for key, value := range newTags {
+		oldValue, ok := oldTags[key]
+		switch {
+		case !ok:
+			if event.Added == nil {
+				event.Added = map[string]string{}
+			}
+			event.Added[key] = value
+		case oldValue != value:
+			if event.Changed == nil {
+				event.Changed = map[string]TagValueChange{}
+			}
+			event.Changed[key] = TagValueChange{Old: oldValue, New: value}
+		}
+	}
+	for key, value := range oldTags {
+		if _, ok := newTags[key]; !ok {
+			if event.Removed == nil {
+				event.Removed = map[string]string{}
+			}
+			event.Removed[key] = value
+		}
+	}
+	return event
+}
+
+// tagChangePublisher publishes the tag change events to a log stream named
+// after the instance. The log group and the log stream are created if they do
+// not exist.
+type tagChangePublisher struct {
+	client    cloudwatchlogsiface.CloudWatchLogsAPI
+	logGroup  string
+	logStream string
+}
+
+func (p *tagChangePublisher) publish(event TagChangeEvent) error {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	input := &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String(p.logGroup),
+		LogStreamName: aws.String(p.logStream),
+		LogEvents: []*cloudwatchlogs.InputLogEvent{{
+			Message:   aws.String(string(message)),
+			Timestamp: aws.Int64(event.Timestamp.UnixMilli()),
+		}},
+	}
+	_, err = p.client.PutLogEvents(input)
+	if isResourceNotFound(err) {
+		if err = p.createLogStream(); err != nil {
+			return err
+		}
+		_, err = p.client.PutLogEvents(input)
+	}
+	return err
+}
+
+func (p *tagChangePublisher) createLogStream() error {
+	_, err := p.client.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{LogGroupName: aws.String(p.logGroup)})
+	if err != nil && !isResourceAlreadyExists(err) {
+		return err
+	}
+	_, err = p.client.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  aws.String(p.logGroup),
+		LogStreamName: aws.String(p.logStream),
+	})
+	if err != nil && !isResourceAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func isResourceNotFound(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException
+}
+
+func isResourceAlreadyExists(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == cloudwatchlogs.ErrCodeResourceAlreadyExistsException
+}
+
+// emitTagChange writes the event to the agent log, and publishes it to
+// CloudWatch Logs if a log group is configured.
+func (t *Tagger) emitTagChange(event TagChangeEvent) {
+	fields := []zap.Field{zap.String("instanceId", event.InstanceID)}
+	if len(event.Added) > 0 {
+		fields = append(fields, zap.Any("added", event.Added))
+	}
+	if len(event.Removed) > 0 {
+		fields = append(fields, zap.Any("removed", event.Removed))
+	}
+	if len(event.Changed) > 0 {
+		fields = append(fields, zap.Any("changed", event.Changed))
+	}
+	t.logger.Info("ec2tagger: EC2 instance tags changed", fields...)
+	if t.tagChangePublisher != nil {
+		if err := t.tagChangePublisher.publish(event); err != nil {
+			t.logger.Warn("ec2tagger: Unable to publish the tag change event", zap.String("logGroup", t.TagChangeLogGroup), zap.Error(err))
+		}
+	}
+}
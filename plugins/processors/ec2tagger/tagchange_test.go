@@ -0,0 +1,121 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package ec2tagger
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs/cloudwatchlogsiface"
+)
+
+type mockCloudWatchLogsClient struct {
+	cloudwatchlogsiface.CloudWatchLogsAPI
+	sync.Mutex
+	streamExists bool
+	groupExists  bool
+	createdGroup bool
+	createdLogs  bool
+	messages     []string
+	putErr       error
+}
+
+func (m *mockCloudWatchLogsClient) PutLogEvents(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	m.Lock()
+	defer m.Unlock()
+	if m.putErr != nil {
+		return nil, m.putErr
+	}
+	if !m.streamExists {
+		return nil, awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "The specified log stream does not exist.", nil)
+	}
+	for _, event := range input.LogEvents {
+		m.messages = append(m.messages, *event.Message)
+	}
+	return &cloudwatchlogs.PutLogEventsOutput{}, nil
+}
+
+func (m *mockCloudWatchLogsClient) CreateLogGroup(*cloudwatchlogs.CreateLogGroupInput) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	m.Lock()
+	defer m.Unlock()
+	if m.groupExists {
+		return nil, awserr.New(cloudwatchlogs.ErrCodeResourceAlreadyExistsException, "The specified log group already exists", nil)
+	}
+	m.createdGroup = true
+	return &cloudwatchlogs.CreateLogGroupOutput{}, nil
+}
+
+func (m *mockCloudWatchLogsClient) CreateLogStream(*cloudwatchlogs.CreateLogStreamInput) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.createdLogs = true
+	m.streamExists = true
+	return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+}
+
+func (m *mockCloudWatchLogsClient) getMessages() []string {
+	m.Lock()
+	defer m.Unlock()
+	return append([]string(nil), m.messages...)
+}
+
+func TestDiffTags(t *testing.T) {
+	event := diffTags("i-123",
+		map[string]string{"Name": "web", "Environment": "blue", "team:owner": "alice"},
+		map[string]string{"Name": "web", "Environment": "green", "team:cost-center": "42"},
+	)
+	assert.Equal(t, TagChangeEvent{
+		InstanceID: "i-123",
+		Added:      map[string]string{"team:cost-center": "42"},
+		Removed:    map[string]string{"team:owner": "alice"},
+		Changed:    map[string]TagValueChange{"Environment": {Old: "blue", New: "green"}},
+	}, event)
+	assert.False(t, event.empty())
+
+	assert.True(t, diffTags("i-123", map[string]string{"Name": "web"}, map[string]string{"Name": "web"}).empty())
+	assert.True(t, diffTags("i-123", nil, map[string]string{}).empty())
+}
+
+func TestTagChangePublisher(t *testing.T) {
+	client := &mockCloudWatchLogsClient{groupExists: true}
+	publisher := &tagChangePublisher{client: client, logGroup: "/aws/ec2/tag-changes", logStream: "i-123"}
+	event := TagChangeEvent{
+		Timestamp:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		InstanceID: "i-123",
+		Changed:    map[string]TagValueChange{"Environment": {Old: "blue", New: "green"}},
+	}
+
+	// the log stream is created on the first event
+	require.NoError(t, publisher.publish(event))
+	assert.False(t, client.createdGroup)
+	assert.True(t, client.createdLogs)
+	require.Len(t, client.getMessages(), 1)
+	var got TagChangeEvent
+	require.NoError(t, json.Unmarshal([]byte(client.getMessages()[0]), &got))
+	assert.Equal(t, event, got)
+	assert.JSONEq(t, `{"timestamp":"2024-01-01T00:00:00Z","instance_id":"i-123","changed":{"Environment":{"old":"blue","new":"green"}}}`, client.getMessages()[0])
+
+	require.NoError(t, publisher.publish(event))
+	assert.Len(t, client.getMessages(), 2)
+
+	client.putErr = errors.New("throttled")
+	assert.Error(t, publisher.publish(event))
+}
+
+func TestTagChangePublisherCreatesLogGroup(t *testing.T) {
+	client := &mockCloudWatchLogsClient{}
+	publisher := &tagChangePublisher{client: client, logGroup: "/aws/ec2/tag-changes", logStream: "i-123"}
+	require.NoError(t, publisher.publish(TagChangeEvent{InstanceID: "i-123", Added: map[string]string{"Name": "web"}}))
+	assert.True(t, client.createdGroup)
+	assert.True(t, client.createdLogs)
+	assert.Len(t, client.getMessages(), 1)
+}
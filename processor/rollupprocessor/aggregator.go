@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package rollupprocessor
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// aggregator merges the data points with the same rollup attributes.
+type aggregator[T any] interface {
+	// Merge merges the data point into the rollup data point, which starts as
+	// a copy of the first data point.
+	Merge(rollup T, dp T)
+	// Finish is called once all count data points are merged.
+	Finish(rollup T, count int)
+}
+
+// numberAggregator aggregates the values of gauge and sum data points. The
+// rollup data point has the latest timestamp and the earliest start timestamp.
+type numberAggregator struct {
+	aggregation string
+}
+
+var _ aggregator[pmetric.NumberDataPoint] = (*numberAggregator)(nil)
+
+func newNumberAggregator(aggregation string) aggregator[pmetric.NumberDataPoint] {
+	if aggregation == "" {
+		return nil
+	}
+	return &numberAggregator{aggregation: aggregation}
+}
+
+func (a *numberAggregator) Merge(rollup pmetric.NumberDataPoint, dp pmetric.NumberDataPoint) {
+	if dp.Timestamp() > rollup.Timestamp() {
+		rollup.SetTimestamp(dp.Timestamp())
+	}
+	if start := dp.StartTimestamp(); start != 0 && (rollup.StartTimestamp() == 0 || start < rollup.StartTimestamp()) {
+		rollup.SetStartTimestamp(start)
+	}
+	switch a.aggregation {
+	case AggregationSum, AggregationAvg:
+		if rollup.ValueType() == pmetric.NumberDataPointValueTypeInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			rollup.SetIntValue(rollup.IntValue() + dp.IntValue())
+		} else {
+			rollup.SetDoubleValue(numberValue(rollup) + numberValue(dp))
+		}
+	case AggregationMin:
+		if numberValue(dp) < numberValue(rollup) {
+			setNumberValue(rollup, dp)
+		}
+	case AggregationMax:
+		if numberValue(dp) > numberValue(rollup) {
+			setNumberValue(rollup, dp)
+		}
+	}
+}
+
+func (a *numberAggregator) Finish(rollup pmetric.NumberDataPoint, count int) {
+	if a.aggregation == AggregationAvg {
+		rollup.SetDoubleValue(numberValue(rollup) / float64(count))
+	}
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+func setNumberValue(dest pmetric.NumberDataPoint, src pmetric.NumberDataPoint) {
+	if src.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		dest.SetIntValue(src.IntValue())
+	} else {
+		dest.SetDoubleValue(src.DoubleValue())
+	}
+}
@@ -29,6 +29,11 @@ type ttlRollupCache struct {
 var _ rollupCache = (*ttlRollupCache)(nil)
 
 func (c *ttlRollupCache) Key(attrs pcommon.Map) string {
+	return attributesKey(attrs)
+}
+
+// attributesKey returns the sorted attribute pairs joined into a string.
+func attributesKey(attrs pcommon.Map) string {
 	pairs := make([]string, 0, attrs.Len())
 	attrs.Range(func(k string, v pcommon.Value) bool {
 		pairs = append(pairs, k+":"+v.AsString())
@@ -3,6 +3,22 @@
 
 package rollupprocessor
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	AggregationSum = "sum"
+	AggregationAvg = "avg"
+	AggregationMin = "min"
+	AggregationMax = "max"
+)
+
 type Config struct {
 	// AttributeGroups are the groups of attribute names that will be used
 	// to create rollup data points with. The number of distinct groups will
@@ -11,9 +27,104 @@ type Config struct {
 	AttributeGroups [][]string `mapstructure:"attribute_groups,omitempty"`
 	// DropOriginal is the names of metrics where the original data points should
 	// be dropped. This is used with the AttributeGroups to reduce the number of
-	// data points sent to the exporter.
+	// data points sent to the exporter. Names with * or ? are glob patterns.
 	DropOriginal []string `mapstructure:"drop_original,omitempty"`
+	// Rules roll up the metrics with matching names. The first matching rule
+	// is used instead of the AttributeGroups and DropOriginal.
+	Rules []Rule `mapstructure:"rules,omitempty"`
 	// CacheSize is used to store built rollup attribute groups using the base
 	// attributes as keys. Can disable by setting <= 0.
 	CacheSize int `mapstructure:"cache_size"`
 }
+
+// Rule is the rollup of the metrics with matching names.
+type Rule struct {
+	// MetricNames are the names of the metrics the rule applies to. Names with
+	// * or ? are glob patterns. The rule applies to every metric if empty.
+	MetricNames []string `mapstructure:"metric_names,omitempty"`
+	// AttributeGroups are the groups of attribute names that the rollup data
+	// points keep.
+	AttributeGroups [][]string `mapstructure:"attribute_groups,omitempty"`
+	// ExcludeAttributeGroups are the groups of attribute names that the rollup
+	// data points drop, keeping every other attribute.
+	ExcludeAttributeGroups [][]string `mapstructure:"exclude_attribute_groups,omitempty"`
+	// Aggregation combines the gauge and sum data points of a batch that have
+	// the same rollup attributes into one data point with the sum, average,
+	// minimum or maximum of their values. The rollup data points are copies
+	// of the original data points if empty.
+	Aggregation string `mapstructure:"aggregation,omitempty"`
+	// DropOriginal drops the original data points of the matching metrics.
+	DropOriginal bool `mapstructure:"drop_original,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if _, err := newNameMatcher(cfg.DropOriginal); err != nil {
+		return fmt.Errorf("invalid drop_original: %w", err)
+	}
+	for i, rule := range cfg.Rules {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("invalid rule %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) validate() error {
+	if _, err := newNameMatcher(r.MetricNames); err != nil {
+		return fmt.Errorf("invalid metric_names: %w", err)
+	}
+	if len(r.AttributeGroups) == 0 && len(r.ExcludeAttributeGroups) == 0 && !r.DropOriginal {
+		return errors.New("attribute_groups, exclude_attribute_groups or drop_original must be set")
+	}
+	switch r.Aggregation {
+	case "", AggregationSum, AggregationAvg, AggregationMin, AggregationMax:
+	default:
+		return fmt.Errorf("unsupported aggregation %q", r.Aggregation)
+	}
+	return nil
+}
+
+// nameMatcher matches metric names by name or by glob pattern.
+type nameMatcher struct {
+	names    map[string]bool
+	patterns []glob.Glob
+}
+
+func newNameMatcher(names []string) (*nameMatcher, error) {
+	m := &nameMatcher{names: map[string]bool{}}
+	for _, name := range names {
+		if !isNamePattern(name) {
+			m.names[name] = true
+			continue
+		}
+		pattern, err := glob.Compile(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", name, err)
+		}
+		m.patterns = append(m.patterns, pattern)
+	}
+	return m, nil
+}
+
+func (m *nameMatcher) empty() bool {
+	return len(m.names) == 0 && len(m.patterns) == 0
+}
+
+func (m *nameMatcher) Match(name string) bool {
+	if m.names[name] {
+		return true
+	}
+	for _, pattern := range m.patterns {
+		if pattern.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNamePattern returns whether the name is a glob pattern, e.g. "jvm_*".
+func isNamePattern(name string) bool {
+	return strings.ContainsAny(name, "*?")
+}
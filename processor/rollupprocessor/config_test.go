@@ -39,6 +39,22 @@ func TestLoadConfig(t *testing.T) {
 			id:   component.NewIDWithName(component.MustNewType(typeStr), "4"),
 			want: &Config{CacheSize: -1},
 		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "5"),
+			want: &Config{
+				DropOriginal: []string{"jvm_*"},
+				Rules: []Rule{
+					{
+						MetricNames:            []string{"http_server_*"},
+						ExcludeAttributeGroups: [][]string{{"pod", "instance"}},
+						Aggregation:            AggregationSum,
+						DropOriginal:           true,
+					},
+					{AttributeGroups: [][]string{{"Attr1"}}},
+				},
+				CacheSize: defaultCacheSize,
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
@@ -52,3 +68,32 @@ func TestLoadConfig(t *testing.T) {
 		assert.Equal(t, testCase.want, cfg)
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	testCases := map[string]struct {
+		cfg     *Config
+		wantErr string
+	}{
+		"InvalidDropOriginalPattern": {
+			cfg:     &Config{DropOriginal: []string{"jvm_[*"}},
+			wantErr: "invalid drop_original",
+		},
+		"InvalidMetricNamesPattern": {
+			cfg:     &Config{Rules: []Rule{{MetricNames: []string{"jvm_[*"}, DropOriginal: true}}},
+			wantErr: "invalid rule 0: invalid metric_names",
+		},
+		"EmptyRule": {
+			cfg:     &Config{Rules: []Rule{{MetricNames: []string{"jvm_*"}}}},
+			wantErr: "invalid rule 0: attribute_groups, exclude_attribute_groups or drop_original must be set",
+		},
+		"UnsupportedAggregation": {
+			cfg:     &Config{Rules: []Rule{{AttributeGroups: [][]string{{"Attr1"}}, Aggregation: "p99"}}},
+			wantErr: `invalid rule 0: unsupported aggregation "p99"`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorContains(t, testCase.cfg.Validate(), testCase.wantErr)
+		})
+	}
+}
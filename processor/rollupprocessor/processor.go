@@ -6,6 +6,7 @@ package rollupprocessor
 import (
 	"context"
 	"sort"
+	"strconv"
 
 	"github.com/jellydator/ttlcache/v3"
 	"go.opentelemetry.io/collector/component"
@@ -18,22 +19,59 @@ import (
 )
 
 type rollupProcessor struct {
-	attributeGroups [][]string
-	dropOriginal    collections.Set[string]
-	cache           rollupCache
+	rules       []*rollupRule
+	defaultRule *rollupRule
+	cache       rollupCache
+}
+
+// rollupRule is the rollup of the metrics matched by a Rule, or of the other
+// metrics for the default rule.
+type rollupRule struct {
+	// cacheKeyPrefix separates the cached rollups of the rules.
+	cacheKeyPrefix string
+	metricNames    *nameMatcher
+	groups         []attributeGroup
+	aggregation    string
+	dropAll        bool
+	dropNames      *nameMatcher
+}
+
+// attributeGroup is the attributes the rollup data points keep, or drop if
+// exclude is set.
+type attributeGroup struct {
+	keys    []string
+	exclude bool
 }
 
 func newProcessor(cfg *Config) *rollupProcessor {
+	// the names are validated with the config
+	dropNames, _ := newNameMatcher(cfg.DropOriginal)
+	p := &rollupProcessor{
+		defaultRule: &rollupRule{
+			groups:    newAttributeGroups(cfg.AttributeGroups, nil),
+			dropNames: dropNames,
+		},
+	}
+	hasGroups := len(p.defaultRule.groups) > 0
+	for i, rule := range cfg.Rules {
+		metricNames, _ := newNameMatcher(rule.MetricNames)
+		r := &rollupRule{
+			cacheKeyPrefix: strconv.Itoa(i) + "#",
+			metricNames:    metricNames,
+			groups:         newAttributeGroups(rule.AttributeGroups, rule.ExcludeAttributeGroups),
+			aggregation:    rule.Aggregation,
+			dropAll:        rule.DropOriginal,
+		}
+		hasGroups = hasGroups || len(r.groups) > 0
+		p.rules = append(p.rules, r)
+	}
 	cacheSize := cfg.CacheSize
 	// use no-op cache if no attribute groups
-	if len(cfg.AttributeGroups) == 0 {
+	if !hasGroups {
 		cacheSize = 0
 	}
-	return &rollupProcessor{
-		attributeGroups: uniqueGroups(cfg.AttributeGroups),
-		dropOriginal:    collections.NewSet(cfg.DropOriginal...),
-		cache:           buildRollupCache(cacheSize),
-	}
+	p.cache = buildRollupCache(cacheSize)
+	return p
 }
 
 func (p *rollupProcessor) start(context.Context, component.Host) error {
@@ -47,131 +85,240 @@ func (p *rollupProcessor) stop(context.Context) error {
 }
 
 func (p *rollupProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
-	if len(p.attributeGroups) > 0 || len(p.dropOriginal) > 0 {
-		metric.RangeMetrics(md, p.processMetric)
+	if len(p.rules) > 0 || len(p.defaultRule.groups) > 0 || !p.defaultRule.dropNames.empty() {
+		// the adapter puts every telegraf metric in its own resource metrics,
+		// so the aggregates span the whole batch
+		aggregates := map[string]*aggregate[pmetric.NumberDataPoint]{}
+		metric.RangeMetrics(md, func(m pmetric.Metric) {
+			p.processMetric(m, aggregates)
+		})
+		for _, a := range aggregates {
+			a.aggregator.Finish(a.dataPoint, a.count)
+		}
 	}
 	return md, nil
 }
 
-func (p *rollupProcessor) processMetric(m pmetric.Metric) {
+// ruleFor returns the first rule that matches the metric name, or the default
+// rule.
+func (p *rollupProcessor) ruleFor(metricName string) *rollupRule {
+	for _, rule := range p.rules {
+		if rule.metricNames.empty() || rule.metricNames.Match(metricName) {
+			return rule
+		}
+	}
+	return p.defaultRule
+}
+
+// processMetric replaces the data points of the metric with the original and
+// rollup data points. The rollup data points of gauges and sums with an
+// aggregation are merged into the aggregates of the batch.
+func (p *rollupProcessor) processMetric(m pmetric.Metric, aggregates map[string]*aggregate[pmetric.NumberDataPoint]) {
+	rule := p.ruleFor(m.Name())
+	dropOriginal := rule.dropAll || (rule.dropNames != nil && rule.dropNames.Match(m.Name()))
+	if len(rule.groups) == 0 && !dropOriginal {
+		return
+	}
+	// the rollup data points are added to the metric, so that the aggregates
+	// of later metrics can be merged into them
+	keyPrefix := m.Name() + "\x00" + m.Type().String() + "\x00" + m.Unit() + "\x00"
 	switch m.Type() {
 	case pmetric.MetricTypeGauge:
-		newDataPoints := pmetric.NewNumberDataPointSlice()
+		origDataPoints := pmetric.NewNumberDataPointSlice()
+		m.Gauge().DataPoints().MoveAndAppendTo(origDataPoints)
 		rollupDataPoints[pmetric.NumberDataPoint](
 			p.cache,
-			p.attributeGroups,
-			p.dropOriginal,
-			m.Name(),
+			rule,
+			dropOriginal,
+			origDataPoints,
 			m.Gauge().DataPoints(),
-			newDataPoints,
+			newNumberAggregator(rule.aggregation),
+			keyPrefix,
+			aggregates,
 		)
-		newDataPoints.CopyTo(m.Gauge().DataPoints())
 	case pmetric.MetricTypeSum:
-		newDataPoints := pmetric.NewNumberDataPointSlice()
+		origDataPoints := pmetric.NewNumberDataPointSlice()
+		m.Sum().DataPoints().MoveAndAppendTo(origDataPoints)
 		rollupDataPoints[pmetric.NumberDataPoint](
 			p.cache,
-			p.attributeGroups,
-			p.dropOriginal,
-			m.Name(),
+			rule,
+			dropOriginal,
+			origDataPoints,
 			m.Sum().DataPoints(),
-			newDataPoints,
+			newNumberAggregator(rule.aggregation),
+			keyPrefix,
+			aggregates,
 		)
-		newDataPoints.CopyTo(m.Sum().DataPoints())
 	case pmetric.MetricTypeHistogram:
-		newDataPoints := pmetric.NewHistogramDataPointSlice()
+		origDataPoints := pmetric.NewHistogramDataPointSlice()
+		m.Histogram().DataPoints().MoveAndAppendTo(origDataPoints)
 		rollupDataPoints[pmetric.HistogramDataPoint](
 			p.cache,
-			p.attributeGroups,
-			p.dropOriginal,
-			m.Name(),
+			rule,
+			dropOriginal,
+			origDataPoints,
 			m.Histogram().DataPoints(),
-			newDataPoints,
+			nil,
+			keyPrefix,
+			nil,
 		)
-		newDataPoints.CopyTo(m.Histogram().DataPoints())
 	case pmetric.MetricTypeExponentialHistogram:
-		newDataPoints := pmetric.NewExponentialHistogramDataPointSlice()
+		origDataPoints := pmetric.NewExponentialHistogramDataPointSlice()
+		m.ExponentialHistogram().DataPoints().MoveAndAppendTo(origDataPoints)
 		rollupDataPoints[pmetric.ExponentialHistogramDataPoint](
 			p.cache,
-			p.attributeGroups,
-			p.dropOriginal,
-			m.Name(),
+			rule,
+			dropOriginal,
+			origDataPoints,
 			m.ExponentialHistogram().DataPoints(),
-			newDataPoints,
+			nil,
+			keyPrefix,
+			nil,
 		)
-		newDataPoints.CopyTo(m.ExponentialHistogram().DataPoints())
 	case pmetric.MetricTypeSummary:
-		newDataPoints := pmetric.NewSummaryDataPointSlice()
+		origDataPoints := pmetric.NewSummaryDataPointSlice()
+		m.Summary().DataPoints().MoveAndAppendTo(origDataPoints)
 		rollupDataPoints[pmetric.SummaryDataPoint](
 			p.cache,
-			p.attributeGroups,
-			p.dropOriginal,
-			m.Name(),
+			rule,
+			dropOriginal,
+			origDataPoints,
 			m.Summary().DataPoints(),
-			newDataPoints,
+			nil,
+			keyPrefix,
+			nil,
 		)
-		newDataPoints.CopyTo(m.Summary().DataPoints())
 	}
 }
 
+// aggregate is a rollup data point that the data points of the batch with the
+// same metric name, type, unit and rollup attributes are merged into.
+type aggregate[T any] struct {
+	dataPoint  T
+	aggregator aggregator[T]
+	count      int
+}
+
 // rollupDataPoints makes copies of the original data points for each rollup
-// attribute group. If the metric name is in the drop original set, the original
-// data points are dropped.
+// attribute group. If the aggregator is set, the copies with the same key
+// prefix and attributes are merged into one data point of the aggregates
+// instead. If dropOriginal is set, the original data points are dropped.
 func rollupDataPoints[T metric.DataPoint[T]](
 	cache rollupCache,
-	attributeGroups [][]string,
-	dropOriginal collections.Set[string],
-	metricName string,
+	rule *rollupRule,
+	dropOriginal bool,
 	orig metric.DataPoints[T],
 	dest metric.DataPoints[T],
+	aggregator aggregator[T],
+	keyPrefix string,
+	aggregates map[string]*aggregate[T],
 ) {
 	metric.RangeDataPoints(orig, func(origDataPoint T) {
-		if !dropOriginal.Contains(metricName) {
+		if !dropOriginal {
 			origDataPoint.CopyTo(dest.AppendEmpty())
 		}
-		if len(attributeGroups) == 0 {
+		if len(rule.groups) == 0 {
 			return
 		}
-		key := cache.Key(origDataPoint.Attributes())
+		key := rule.cacheKeyPrefix + cache.Key(origDataPoint.Attributes())
 		item := cache.Get(key)
 		var rollup []pcommon.Map
 		if item == nil {
-			rollup = buildRollup(attributeGroups, origDataPoint.Attributes())
+			rollup = buildRollup(rule.groups, origDataPoint.Attributes())
 			cache.Set(key, rollup, ttlcache.DefaultTTL)
 		} else {
 			rollup = item.Value()
 		}
 		for _, attrs := range rollup {
+			var a *aggregate[T]
+			if aggregator != nil {
+				attrsKey := keyPrefix + attributesKey(attrs)
+				if existing, ok := aggregates[attrsKey]; ok {
+					existing.aggregator.Merge(existing.dataPoint, origDataPoint)
+					existing.count++
+					continue
+				}
+				a = &aggregate[T]{aggregator: aggregator, count: 1}
+				aggregates[attrsKey] = a
+			}
 			destDataPoint := dest.AppendEmpty()
 			origDataPoint.CopyTo(destDataPoint)
 			attrs.CopyTo(destDataPoint.Attributes())
+			if a != nil {
+				a.dataPoint = destDataPoint
+			}
 		}
 	})
 }
 
-func buildRollup(attributeGroups [][]string, baseAttributes pcommon.Map) []pcommon.Map {
+func buildRollup(attributeGroups []attributeGroup, baseAttributes pcommon.Map) []pcommon.Map {
 	var results []pcommon.Map
+	// the include and exclude groups can result in the same attributes
+	seen := collections.NewSet[string]()
 	for _, rollupGroup := range attributeGroups {
-		// skip if target dimensions count is same or more than the original metric.
-		// cannot have dimensions that do not exist in the original metric.
-		if len(rollupGroup) >= baseAttributes.Len() {
-			continue
+		var attributes pcommon.Map
+		var ok bool
+		if rollupGroup.exclude {
+			attributes, ok = excludeAttributes(rollupGroup.keys, baseAttributes)
+		} else {
+			attributes, ok = includeAttributes(rollupGroup.keys, baseAttributes)
 		}
-		attributes := pcommon.NewMap()
-		attributes.EnsureCapacity(len(rollupGroup))
-		for _, key := range rollupGroup {
-			value, ok := baseAttributes.Get(key)
-			if !ok {
-				break
-			}
-			value.CopyTo(attributes.PutEmpty(key))
+		if !ok {
+			continue
 		}
-		if attributes.Len() == len(rollupGroup) {
+		key := attributesKey(attributes)
+		if !seen.Contains(key) {
+			seen.Add(key)
 			results = append(results, attributes)
 		}
 	}
 	return results
 }
 
+// includeAttributes returns the attributes with the keys, if the base
+// attributes have all of them and more.
+func includeAttributes(keys []string, baseAttributes pcommon.Map) (pcommon.Map, bool) {
+	// skip if target dimensions count is same or more than the original metric.
+	// cannot have dimensions that do not exist in the original metric.
+	if len(keys) >= baseAttributes.Len() {
+		return pcommon.Map{}, false
+	}
+	attributes := pcommon.NewMap()
+	attributes.EnsureCapacity(len(keys))
+	for _, key := range keys {
+		value, ok := baseAttributes.Get(key)
+		if !ok {
+			return pcommon.Map{}, false
+		}
+		value.CopyTo(attributes.PutEmpty(key))
+	}
+	return attributes, true
+}
+
+// excludeAttributes returns the base attributes without the keys, if the base
+// attributes have any of them.
+func excludeAttributes(keys []string, baseAttributes pcommon.Map) (pcommon.Map, bool) {
+	attributes := pcommon.NewMap()
+	baseAttributes.CopyTo(attributes)
+	for _, key := range keys {
+		attributes.Remove(key)
+	}
+	return attributes, attributes.Len() < baseAttributes.Len()
+}
+
+// newAttributeGroups returns the unique include groups followed by the unique
+// exclude groups.
+func newAttributeGroups(include [][]string, exclude [][]string) []attributeGroup {
+	var groups []attributeGroup
+	for _, keys := range uniqueGroups(include) {
+		groups = append(groups, attributeGroup{keys: keys})
+	}
+	for _, keys := range uniqueGroups(exclude) {
+		groups = append(groups, attributeGroup{keys: keys, exclude: true})
+	}
+	return groups
+}
+
 // uniqueGroups filters out duplicate attributes within the sets and filters
 // duplicate sets.
 func uniqueGroups(groups [][]string) [][]string {
@@ -378,3 +378,237 @@ func buildTestDataPoints[T metric.DataPoint[T]](
 		assert.NoError(t, dp.Attributes().FromRaw(rawAttribute))
 	}
 }
+
+func TestProcessorRules(t *testing.T) {
+	cfg := &Config{
+		AttributeGroups: [][]string{{"d1"}},
+		DropOriginal:    []string{"jvm_*"},
+		Rules: []Rule{
+			{
+				MetricNames:            []string{"http_*"},
+				AttributeGroups:        [][]string{{"d1"}},
+				ExcludeAttributeGroups: [][]string{{"pod"}, {"pod", "instance"}},
+			},
+		},
+		CacheSize: 5,
+	}
+	testCases := map[string]struct {
+		metricName     string
+		rawAttributes  []map[string]any
+		wantAttributes []map[string]any
+	}{
+		"DefaultRule/DropOriginalPattern": {
+			metricName:     "jvm_memory_used",
+			rawAttributes:  []map[string]any{{"d1": "v1", "d2": "v2"}},
+			wantAttributes: []map[string]any{{"d1": "v1"}},
+		},
+		"DefaultRule/NotMatchingRule": {
+			metricName:     "cpu_usage",
+			rawAttributes:  []map[string]any{{"d1": "v1", "pod": "p1"}},
+			wantAttributes: []map[string]any{{"d1": "v1", "pod": "p1"}, {"d1": "v1"}},
+		},
+		"Rule/ExcludeGroups": {
+			metricName:    "http_requests",
+			rawAttributes: []map[string]any{{"d1": "v1", "pod": "p1", "instance": "i1"}},
+			wantAttributes: []map[string]any{
+				{"d1": "v1", "pod": "p1", "instance": "i1"},
+				{"d1": "v1"},
+				{"d1": "v1", "instance": "i1"},
+				// the rollup without pod and instance is the same as d1
+			},
+		},
+		"Rule/NoExcludedAttributes": {
+			metricName:     "http_requests",
+			rawAttributes:  []map[string]any{{"d1": "v1"}},
+			wantAttributes: []map[string]any{{"d1": "v1"}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newProcessor(cfg)
+			orig := pmetric.NewMetrics()
+			ms := orig.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+			buildTestMetric(t, ms.AppendEmpty(), testCase.metricName, pmetric.MetricTypeGauge, testCase.rawAttributes)
+			got, err := p.processMetrics(context.Background(), orig)
+			require.NoError(t, err)
+			var gotAttributes []map[string]any
+			metric.RangeMetrics(got, func(m pmetric.Metric) {
+				metric.RangeDataPointAttributes(m, func(attrs pcommon.Map) {
+					gotAttributes = append(gotAttributes, attrs.AsRaw())
+				})
+			})
+			assert.Equal(t, testCase.wantAttributes, gotAttributes)
+		})
+	}
+}
+
+func TestProcessorAggregation(t *testing.T) {
+	type dataPoint struct {
+		attributes map[string]any
+		value      any
+		start      pcommon.Timestamp
+		timestamp  pcommon.Timestamp
+	}
+	dataPoints := []dataPoint{
+		{attributes: map[string]any{"service": "a", "pod": "p1"}, value: int64(1), start: 5, timestamp: 10},
+		{attributes: map[string]any{"service": "a", "pod": "p2"}, value: int64(3), start: 1, timestamp: 20},
+		{attributes: map[string]any{"service": "b", "pod": "p3"}, value: 2.5, timestamp: 30},
+	}
+	testCases := map[string]struct {
+		aggregation string
+		metricType  pmetric.MetricType
+		want        []dataPoint
+	}{
+		"Sum": {
+			aggregation: AggregationSum,
+			metricType:  pmetric.MetricTypeSum,
+			want: []dataPoint{
+				{attributes: map[string]any{"service": "a"}, value: int64(4), start: 1, timestamp: 20},
+				{attributes: map[string]any{"service": "b"}, value: 2.5, timestamp: 30},
+			},
+		},
+		"Avg": {
+			aggregation: AggregationAvg,
+			metricType:  pmetric.MetricTypeGauge,
+			want: []dataPoint{
+				{attributes: map[string]any{"service": "a"}, value: 2.0, start: 1, timestamp: 20},
+				{attributes: map[string]any{"service": "b"}, value: 2.5, timestamp: 30},
+			},
+		},
+		"Min": {
+			aggregation: AggregationMin,
+			metricType:  pmetric.MetricTypeGauge,
+			want: []dataPoint{
+				{attributes: map[string]any{"service": "a"}, value: int64(1), start: 1, timestamp: 20},
+				{attributes: map[string]any{"service": "b"}, value: 2.5, timestamp: 30},
+			},
+		},
+		"Max": {
+			aggregation: AggregationMax,
+			metricType:  pmetric.MetricTypeGauge,
+			want: []dataPoint{
+				{attributes: map[string]any{"service": "a"}, value: int64(3), start: 1, timestamp: 20},
+				{attributes: map[string]any{"service": "b"}, value: 2.5, timestamp: 30},
+			},
+		},
+		"Copies": {
+			metricType: pmetric.MetricTypeGauge,
+			want: []dataPoint{
+				{attributes: map[string]any{"service": "a"}, value: int64(1), start: 5, timestamp: 10},
+				{attributes: map[string]any{"service": "a"}, value: int64(3), start: 1, timestamp: 20},
+				{attributes: map[string]any{"service": "b"}, value: 2.5, timestamp: 30},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newProcessor(&Config{
+				Rules: []Rule{{
+					ExcludeAttributeGroups: [][]string{{"pod"}},
+					Aggregation:            testCase.aggregation,
+					DropOriginal:           true,
+				}},
+				CacheSize: 5,
+			})
+			orig := pmetric.NewMetrics()
+			m := orig.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			rawAttributes := make([]map[string]any, len(dataPoints))
+			for i, dp := range dataPoints {
+				rawAttributes[i] = dp.attributes
+			}
+			buildTestMetric(t, m, "http_requests", testCase.metricType, rawAttributes)
+			var dps pmetric.NumberDataPointSlice
+			if testCase.metricType == pmetric.MetricTypeSum {
+				dps = m.Sum().DataPoints()
+			} else {
+				dps = m.Gauge().DataPoints()
+			}
+			for i, dp := range dataPoints {
+				switch v := dp.value.(type) {
+				case int64:
+					dps.At(i).SetIntValue(v)
+				case float64:
+					dps.At(i).SetDoubleValue(v)
+				}
+				dps.At(i).SetStartTimestamp(dp.start)
+				dps.At(i).SetTimestamp(dp.timestamp)
+			}
+
+			_, err := p.processMetrics(context.Background(), orig)
+			require.NoError(t, err)
+			var got []dataPoint
+			for i := 0; i < dps.Len(); i++ {
+				dp := dps.At(i)
+				var value any = dp.DoubleValue()
+				if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+					value = dp.IntValue()
+				}
+				got = append(got, dataPoint{attributes: dp.Attributes().AsRaw(), value: value, start: dp.StartTimestamp(), timestamp: dp.Timestamp()})
+			}
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestProcessorAggregationAcrossResources(t *testing.T) {
+	p := newProcessor(&Config{
+		Rules: []Rule{{
+			MetricNames:            []string{"disk_used_percent"},
+			ExcludeAttributeGroups: [][]string{{"path"}},
+			Aggregation:            AggregationMax,
+			DropOriginal:           true,
+		}},
+	})
+	// the adapter puts every telegraf metric in its own resource metrics
+	orig := pmetric.NewMetrics()
+	for _, dp := range []struct {
+		path  string
+		unit  string
+		value float64
+	}{
+		{path: "/", unit: "Percent", value: 40},
+		{path: "/data", unit: "Percent", value: 75},
+		{path: "/boot", unit: "Count", value: 90},
+	} {
+		m := orig.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("disk_used_percent")
+		m.SetUnit(dp.unit)
+		gdp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		gdp.SetDoubleValue(dp.value)
+		gdp.Attributes().PutStr("host", "h1")
+		gdp.Attributes().PutStr("path", dp.path)
+	}
+
+	got, err := p.processMetrics(context.Background(), orig)
+	require.NoError(t, err)
+	require.Equal(t, 3, got.ResourceMetrics().Len())
+	gauge := func(i int) pmetric.NumberDataPointSlice {
+		return got.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints()
+	}
+	// the data points with the same name, type, unit and rollup attributes are
+	// merged into the first one
+	require.Equal(t, 1, gauge(0).Len())
+	assert.Equal(t, map[string]any{"host": "h1"}, gauge(0).At(0).Attributes().AsRaw())
+	assert.Equal(t, 75.0, gauge(0).At(0).DoubleValue())
+	assert.Equal(t, 0, gauge(1).Len())
+	// a different unit is a different metric
+	require.Equal(t, 1, gauge(2).Len())
+	assert.Equal(t, map[string]any{"host": "h1"}, gauge(2).At(0).Attributes().AsRaw())
+	assert.Equal(t, 90.0, gauge(2).At(0).DoubleValue())
+}
+
+func TestProcessorAggregationSkipsHistograms(t *testing.T) {
+	p := newProcessor(&Config{
+		Rules: []Rule{{ExcludeAttributeGroups: [][]string{{"pod"}}, Aggregation: AggregationSum, DropOriginal: true}},
+	})
+	orig := pmetric.NewMetrics()
+	ms := orig.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	buildTestMetric(t, ms.AppendEmpty(), "latency", pmetric.MetricTypeHistogram, []map[string]any{
+		{"service": "a", "pod": "p1"},
+		{"service": "a", "pod": "p2"},
+	})
+	got, err := p.processMetrics(context.Background(), orig)
+	require.NoError(t, err)
+	// the histograms are copied, not merged
+	assert.Equal(t, 2, got.DataPointCount())
+}
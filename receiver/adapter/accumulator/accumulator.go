@@ -110,6 +110,7 @@ func (o *otelAccumulator) addMetric(
 	metricType telegraf.ValueType,
 	t ...time.Time,
 ) {
+	addDerivedFields(measurement, fields)
 	m := metric.New(measurement, tags, fields, o.getTime(t), metricType)
 	o.convertToOtelMetricsAndAddMetric(m)
 }
@@ -394,3 +394,31 @@ func Test_Accumulator_AddError(t *testing.T) {
 	// {"level":"error","msg":"Error with adapter","error":"bar"}
 	// {"level":"error","msg":"Error with adapter","error":"baz"}
 }
+
+func Test_Accumulator_AddDerivedFields(t *testing.T) {
+	as := assert.New(t)
+	cfg := &models.InputConfig{
+		Filter: models.Filter{
+			FieldPass: []string{"inodes_used_percent"},
+		},
+	}
+	acc := newOtelAccumulatorWithConfig(as, nil, false, cfg)
+
+	acc.AddGauge("disk", map[string]interface{}{
+		"inodes_total": uint64(200),
+		"inodes_used":  uint64(50),
+		"inodes_free":  uint64(150),
+	}, map[string]string{"path": "/"}, time.Now())
+	acc.AddGauge("disk", map[string]interface{}{
+		"inodes_total": uint64(0),
+		"inodes_used":  uint64(0),
+	}, map[string]string{"path": "/proc"}, time.Now())
+
+	otelMetrics := acc.GetOtelMetrics()
+	as.Equal(1, otelMetrics.ResourceMetrics().Len())
+	metrics := otelMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	as.Equal(1, metrics.Len())
+	as.Equal("disk_inodes_used_percent", metrics.At(0).Name())
+	as.Equal("Percent", metrics.At(0).Unit())
+	as.Equal(25.0, metrics.At(0).Gauge().DataPoints().At(0).DoubleValue())
+}
@@ -41,14 +41,74 @@ var defaultUnits = map[string]map[string]string{
 		"usage_user":       "Percent",
 	},
 	"disk": {
-		"free":         "Bytes",
-		"total":        "Bytes",
-		"used":         "Bytes",
-		"inodes_free":  "Count",
-		"inodes_total": "Count",
-		"inodes_used":  "Count",
+		"free":                "Bytes",
+		"total":               "Bytes",
+		"used":                "Bytes",
+		"inodes_free":         "Count",
+		"inodes_total":        "Count",
+		"inodes_used":         "Count",
+		"inodes_used_percent": "Percent",
+		"used_percent":        "Percent",
+	},
+	"connections": {
+		"established":            "Count",
+		"syn_recv":               "Count",
+		"fin_wait1":              "Count",
+		"fin_wait2":              "Count",
+		"time_wait":              "Count",
+		"close_wait":             "Count",
+		"last_ack":               "Count",
+		"listen":                 "Count",
+		"closing":                "Count",
+		"conntrack_count":        "Count",
+		"conntrack_max":          "Count",
+		"conntrack_used_percent": "Percent",
+	},
+	"probes": {
+		"up":               "None",
+		"response_time":    "Milliseconds",
+		"status_code":      "None",
+		"cert_expiry_days": "None",
+	},
+	"edac": {
+		"ce_count":        "Count",
+		"ue_count":        "Count",
+		"ce_noinfo_count": "Count",
+		"ue_noinfo_count": "Count",
+	},
+	"kernel_events": {
+		"oom_kill":  "Count",
+		"hung_task": "Count",
+		"mce":       "Count",
+	},
+	"exec": {
+		"success":        "None",
+		"timed_out":      "None",
+		"parse_error":    "None",
+		"exit_code":      "None",
+		"execution_time": "Milliseconds",
+	},
+	"file_handles": {
+		"allocated":    "Count",
+		"unused":       "Count",
+		"used":         "Count",
+		"max":          "Count",
 		"used_percent": "Percent",
 	},
+	"nfs": {
+		"ops":          "Count",
+		"retransmits":  "Count",
+		"timeouts":     "Count",
+		"bytes_sent":   "Bytes",
+		"bytes_recv":   "Bytes",
+		"rtt":          "Milliseconds",
+		"execute_time": "Milliseconds",
+		"queue_time":   "Milliseconds",
+		"read_ops":     "Count",
+		"read_rtt":     "Milliseconds",
+		"write_ops":    "Count",
+		"write_rtt":    "Milliseconds",
+	},
 	"diskio": {
 		"iops_in_progress": "Count",
 		"io_time":          "Milliseconds",
@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package accumulator
+
+// Fields computed from the other fields of the telegraf metrics based on the
+// measurement. They are added before the metrics are filtered, so they can be
+// selected in the measurement list like any other field.
+var derivedFields = map[string]func(fields map[string]interface{}){
+	"disk": addInodesUsedPercent,
+}
+
+// addInodesUsedPercent adds the percentage of used inodes of the file system,
+// which is what runs out first on file systems with many small files.
+func addInodesUsedPercent(fields map[string]interface{}) {
+	used, ok := toFloat(fields["inodes_used"])
+	if !ok {
+		return
+	}
+	total, ok := toFloat(fields["inodes_total"])
+	if !ok || total == 0 {
+		return
+	}
+	fields["inodes_used_percent"] = used / total * 100
+}
+
+func addDerivedFields(measurement string, fields map[string]interface{}) {
+	if derive, ok := derivedFields[measurement]; ok {
+		derive(fields)
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case uint64:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -76,6 +76,7 @@ func TestComponents(t *testing.T) {
 		"awscloudwatchlogs",
 		"awsemf",
 		"awscloudwatch",
+		"awscloudwatchlogs_otlp",
 		"awsxray",
 		"debug",
 		"nop",
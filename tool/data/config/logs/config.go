@@ -5,6 +5,11 @@ package logs
 
 import "github.com/aws/amazon-cloudwatch-agent/tool/runtime"
 
+type LogFilter struct {
+	Type       string `json:"type"`
+	Expression string `json:"expression"`
+}
+
 type Config struct {
 	FilePath              string `file_path`
 	LogGroup              string `log_group_name`
@@ -15,6 +20,7 @@ type Config struct {
 	MultiLineStartPattern string `multi_line_start_pattern`
 	Encoding              string `encoding`
 	Retention             int    `retention_in_days`
+	Filters               []*LogFilter
 }
 
 func (config *Config) ToMap(ctx *runtime.Context) (string, map[string]interface{}) {
@@ -42,5 +48,15 @@ func (config *Config) ToMap(ctx *runtime.Context) (string, map[string]interface{
 	if config.LogGroupClass != "" {
 		resultMap["log_group_class"] = config.LogGroupClass
 	}
+	if len(config.Filters) > 0 {
+		filters := make([]map[string]interface{}, len(config.Filters))
+		for i, filter := range config.Filters {
+			filters[i] = map[string]interface{}{
+				"type":       filter.Type,
+				"expression": filter.Expression,
+			}
+		}
+		resultMap["filters"] = filters
+	}
 	return "", resultMap
 }
@@ -34,3 +34,25 @@ func TestConfig_ToMap(t *testing.T) {
 	},
 		value)
 }
+
+func TestConfig_ToMapWithFilters(t *testing.T) {
+	conf := &Config{
+		FilePath: "/var/log/app.log",
+		LogGroup: "app",
+		Filters: []*LogFilter{
+			{Type: "include", Expression: "ERROR"},
+			{Type: "exclude", Expression: "healthcheck"},
+		},
+	}
+	ctx := &runtime.Context{}
+	key, value := conf.ToMap(ctx)
+	assert.Equal(t, "", key)
+	assert.Equal(t, map[string]interface{}{
+		"file_path":      "/var/log/app.log",
+		"log_group_name": "app",
+		"filters": []map[string]interface{}{
+			{"type": "include", "expression": "ERROR"},
+			{"type": "exclude", "expression": "healthcheck"},
+		},
+	}, value)
+}
@@ -53,3 +53,7 @@ func (config *Files) AddLogFile(filePath, logGroupName, logStreamName string, ti
 	}
 	config.FileConfigs = append(config.FileConfigs, singleFile)
 }
+
+func (config *Files) AddLogFileConfig(singleFile *Config) {
+	config.FileConfigs = append(config.FileConfigs, singleFile)
+}
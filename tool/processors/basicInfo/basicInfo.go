@@ -56,8 +56,9 @@ func whichOS(ctx *runtime.Context) {
 
 func isEC2(ctx *runtime.Context, conf *data.Config) {
 	defaultOption := 1
-	defaultRegion := util.DefaultEC2Region()
-	if defaultRegion == "" {
+	// The answers file decides instead, and skipping IMDS keeps the wizard
+	// fast and reproducible in image pipelines.
+	if !util.HasAnswers() && util.DefaultEC2Region() == "" {
 		defaultOption = 2
 	}
 	answer := util.Choice("Are you using EC2 or On-Premises hosts?",
@@ -0,0 +1,199 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package fluent
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data"
+	"github.com/aws/amazon-cloudwatch-agent/tool/data/config"
+	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/logs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
+	questionlogs "github.com/aws/amazon-cloudwatch-agent/tool/processors/question/logs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+	"github.com/aws/amazon-cloudwatch-agent/tool/wizard/flags"
+)
+
+const (
+	anyExistingFluentConfigQuestion = "Do you have any existing Fluent Bit or Fluentd configuration file to import for migration?"
+	filePathFluentConfigQuestion    = "What is the file path for the existing Fluent Bit or Fluentd configuration file?"
+
+	filterTypeInclude = "include"
+	filterTypeExclude = "exclude"
+)
+
+var Processor processors.Processor = &processor{}
+
+type processor struct{}
+
+func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
+	if ctx.HasExistingFluentConfig || util.No(anyExistingFluentConfigQuestion) {
+		filePath := ctx.ConfigFilePath
+		if filePath == "" {
+			filePath = util.AskWithDefault(filePathFluentConfigQuestion, flags.DefaultFilePathFluentBitConfiguration)
+		}
+		processConfigFromFluentFile(filePath, config.LogsConf())
+	}
+}
+
+func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) interface{} {
+	return questionlogs.Processor
+}
+
+func processConfigFromFluentFile(filePath string, logsConfig *config.Logs) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		log.Panicf("E! Error in reading fluent config from file %s: %v", filePath, err)
+	}
+	result, err := Migrate(string(content))
+	if err != nil {
+		log.Panicf("E! Error in parsing fluent config from file %s: %v", filePath, err)
+	}
+	for _, unsupported := range result.Unsupported {
+		fmt.Printf("Warning: %s in file %s is not supported and was skipped.\n", unsupported, filePath)
+	}
+	for _, fileConfig := range result.FileConfigs {
+		logsConfig.AddLogFileConfig(fileConfig)
+	}
+}
+
+// MigrationResult holds the collect_list entries produced from a fluent
+// config, along with every directive that could not be expressed.
+type MigrationResult struct {
+	FileConfigs []*logs.Config
+	Unsupported []string
+}
+
+// Migrate detects whether the content is a Fluentd or a classic Fluent Bit
+// config and converts its tail inputs into collect_list entries.
+func Migrate(content string) (*MigrationResult, error) {
+	var p *pipeline
+	var err error
+	if isFluentdConfig(content) {
+		p, err = parseFluentdConfig(content)
+	} else {
+		p, err = parseFluentBitConfig(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p.migrate(), nil
+}
+
+// pipeline is the format independent view of a fluent config. Sources are
+// routed to outputs by tag, picking up any grep filters along the way.
+type pipeline struct {
+	sources []*source
+	filters []*filter
+	outputs []*output
+	// firstMatchOnly is true for Fluentd, which only routes an event to the
+	// first matching output. Fluent Bit copies it to every matching output.
+	firstMatchOnly bool
+	unsupported    []string
+}
+
+type source struct {
+	paths                 []string
+	tag                   string
+	multiLineStartPattern string
+}
+
+type filter struct {
+	match *regexp.Regexp
+	rules []*logs.LogFilter
+}
+
+type output struct {
+	match         *regexp.Regexp
+	logGroup      string
+	logStream     string
+	logGroupClass string
+	retention     int
+	// skipped outputs still take part in routing, they just do not produce
+	// any collect_list entries.
+	skipped bool
+}
+
+func (p *pipeline) reportUnsupported(format string, args ...interface{}) {
+	p.unsupported = append(p.unsupported, fmt.Sprintf(format, args...))
+}
+
+func (p *pipeline) migrate() *MigrationResult {
+	result := &MigrationResult{}
+	for _, src := range p.sources {
+		for _, path := range src.paths {
+			tag := expandTag(src.tag, path)
+			var rules []*logs.LogFilter
+			for _, f := range p.filters {
+				if f.match.MatchString(tag) {
+					rules = append(rules, f.rules...)
+				}
+			}
+			matched := false
+			for _, out := range p.outputs {
+				if !out.match.MatchString(tag) {
+					continue
+				}
+				if out.skipped {
+					if p.firstMatchOnly {
+						break
+					}
+					continue
+				}
+				matched = true
+				result.FileConfigs = append(result.FileConfigs, &logs.Config{
+					FilePath:              path,
+					LogGroup:              out.logGroup,
+					LogStream:             out.logStream,
+					LogGroupClass:         out.logGroupClass,
+					MultiLineStartPattern: src.multiLineStartPattern,
+					Retention:             out.retention,
+					Filters:               rules,
+				})
+				if p.firstMatchOnly {
+					break
+				}
+			}
+			if !matched {
+				p.reportUnsupported("input %s with tag %s (no cloudwatch_logs output matches)", path, tag)
+			}
+		}
+	}
+	result.Unsupported = p.unsupported
+	return result
+}
+
+// expandTag mirrors the tail plugins, which replace a "*" in the tag with
+// the path of the file being read, using "." as the separator.
+func expandTag(tag, path string) string {
+	if !strings.Contains(tag, "*") {
+		return tag
+	}
+	expanded := strings.Trim(strings.ReplaceAll(path, "/", "."), ".")
+	return strings.Replace(tag, "*", expanded, 1)
+}
+
+// lineFilterKeys are the record keys that hold the whole log line, which is
+// the only thing the agent's include/exclude filters can match against.
+var lineFilterKeys = map[string]bool{
+	"log":     true,
+	"message": true,
+}
+
+func (p *pipeline) newFilterRule(filterType, key, expression string) *logs.LogFilter {
+	if !lineFilterKeys[key] {
+		p.reportUnsupported("grep %s on record key %q", filterType, key)
+		return nil
+	}
+	if _, err := regexp.Compile(expression); err != nil {
+		p.reportUnsupported("grep %s expression %q (%v)", filterType, expression, err)
+		return nil
+	}
+	return &logs.LogFilter{Type: filterType, Expression: expression}
+}
@@ -0,0 +1,174 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package fluent
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data"
+	"github.com/aws/amazon-cloudwatch-agent/tool/data/config/logs"
+	questionlogs "github.com/aws/amazon-cloudwatch-agent/tool/processors/question/logs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+func TestProcessor_Process(t *testing.T) {
+	inputChan := testutil.SetUpTestInputStream()
+	ctx := new(runtime.Context)
+	conf := new(data.Config)
+
+	testutil.Type(inputChan, "1", "testdata/fluent-bit.conf")
+
+	Processor.Process(ctx, conf)
+	_, resultMap := conf.ToMap(ctx)
+	collectList := resultMap["logs"].(map[string]interface{})["logs_collected"].(map[string]interface{})["files"].(map[string]interface{})["collect_list"].([]map[string]interface{})
+	assert.Len(t, collectList, 3)
+	assert.Equal(t, map[string]interface{}{
+		"file_path":       "/var/log/messages",
+		"log_group_name":  "/system/messages",
+		"log_stream_name": "{hostname}",
+		"log_group_class": util.InfrequentAccessLogGroupClass,
+	}, collectList[2])
+}
+
+func TestProcessor_ProcessNonInteractive(t *testing.T) {
+	ctx := &runtime.Context{HasExistingFluentConfig: true, ConfigFilePath: "testdata/fluentd.conf"}
+	conf := new(data.Config)
+
+	Processor.Process(ctx, conf)
+	assert.Len(t, conf.LogsConf().LogsCollect.Files.FileConfigs, 1)
+}
+
+func TestProcessor_ProcessMissingFile(t *testing.T) {
+	ctx := &runtime.Context{HasExistingFluentConfig: true, ConfigFilePath: "testdata/missing.conf"}
+	assert.Panics(t, func() { Processor.Process(ctx, new(data.Config)) })
+}
+
+func TestProcessor_NextProcessor(t *testing.T) {
+	assert.Equal(t, questionlogs.Processor, Processor.NextProcessor(nil, nil))
+}
+
+func TestMigrateFluentBit(t *testing.T) {
+	content, err := os.ReadFile("testdata/fluent-bit.conf")
+	require.NoError(t, err)
+	result, err := Migrate(string(content))
+	require.NoError(t, err)
+
+	appFilters := []*logs.LogFilter{
+		{Type: "include", Expression: "ERROR|WARN"},
+		{Type: "exclude", Expression: "healthcheck"},
+	}
+	assert.Equal(t, []*logs.Config{
+		{
+			FilePath:  "/var/log/app/*.log",
+			LogGroup:  "/app/logs",
+			LogStream: "web-{instance_id}",
+			Retention: 14,
+			Filters:   appFilters,
+		},
+		{
+			FilePath:  "/var/log/app/audit.log",
+			LogGroup:  "/app/logs",
+			LogStream: "web-{instance_id}",
+			Retention: 14,
+			Filters:   appFilters,
+		},
+		{
+			FilePath:      "/var/log/messages",
+			LogGroup:      "/system/messages",
+			LogStream:     "{hostname}",
+			LogGroupClass: util.InfrequentAccessLogGroupClass,
+		},
+	}, result.FileConfigs)
+	assert.Equal(t, []string{
+		`directive "@INCLUDE extra-inputs.conf"`,
+		`tail option "multiline.parser" at line 8`,
+		`input plugin "cpu" at line 21`,
+		`grep exclude on record key "level"`,
+		`cloudwatch_logs option "log_group_template" at line 49`,
+		`cloudwatch_logs output without a static log_group_name at line 49`,
+	}, result.Unsupported)
+}
+
+func TestMigrateFluentd(t *testing.T) {
+	content, err := os.ReadFile("testdata/fluentd.conf")
+	require.NoError(t, err)
+	result, err := Migrate(string(content))
+	require.NoError(t, err)
+
+	assert.Equal(t, []*logs.Config{
+		{
+			FilePath:              "/var/log/app/app.log",
+			LogGroup:              "/app/logs",
+			LogStream:             "app-stream",
+			MultiLineStartPattern: `^\d{4}-\d{2}-\d{2}`,
+			Retention:             30,
+			Filters: []*logs.LogFilter{
+				{Type: "include", Expression: "ERROR"},
+				{Type: "exclude", Expression: "healthcheck"},
+			},
+		},
+	}, result.FileConfigs)
+	assert.Equal(t, []string{
+		`directive "@include conf.d/*.conf"`,
+		`parser "nginx" at line 23`,
+		`source plugin "forward" at line 28`,
+		`output plugin "s3" at line 45`,
+		`input /var/log/nginx/access.log with tag nginx.access (no cloudwatch_logs output matches)`,
+	}, result.Unsupported)
+}
+
+func TestMigrateInvalid(t *testing.T) {
+	_, err := Migrate("Name tail\n")
+	assert.Error(t, err)
+	_, err = Migrate("<source>\n  @type tail\n")
+	assert.Error(t, err)
+	_, err = Migrate("<source>\n</match>\n")
+	assert.Error(t, err)
+}
+
+func TestFluentdMatcher(t *testing.T) {
+	testCases := map[string]struct {
+		pattern string
+		matches []string
+		misses  []string
+	}{
+		"Single": {
+			pattern: "a.*",
+			matches: []string{"a.b"},
+			misses:  []string{"a", "a.b.c"},
+		},
+		"Double": {
+			pattern: "a.**",
+			matches: []string{"a", "a.b", "a.b.c"},
+			misses:  []string{"b.a"},
+		},
+		"Alternatives": {
+			pattern: "{a,b}.c x.y",
+			matches: []string{"a.c", "b.c", "x.y"},
+			misses:  []string{"c.c", "x.z"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			matcher := fluentdMatcher(testCase.pattern)
+			for _, tag := range testCase.matches {
+				assert.True(t, matcher.MatchString(tag), tag)
+			}
+			for _, tag := range testCase.misses {
+				assert.False(t, matcher.MatchString(tag), tag)
+			}
+		})
+	}
+}
+
+func TestExpandTag(t *testing.T) {
+	assert.Equal(t, "app.var.log.app.log", expandTag("app.*", "/var/log/app.log"))
+	assert.Equal(t, "system", expandTag("system", "/var/log/messages"))
+}
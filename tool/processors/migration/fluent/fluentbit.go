@@ -0,0 +1,264 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package fluent
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	fluentBitSectionInput   = "input"
+	fluentBitSectionFilter  = "filter"
+	fluentBitSectionOutput  = "output"
+	fluentBitSectionService = "service"
+	fluentBitSectionParser  = "parser"
+)
+
+// fluentBitIgnoredTailKeys have no agent equivalent but do not change which
+// lines end up in CloudWatch Logs, so they are dropped without a warning.
+var fluentBitIgnoredTailKeys = map[string]bool{
+	"name":              true,
+	"db":                true,
+	"db.sync":           true,
+	"db.locking":        true,
+	"db.journal_mode":   true,
+	"mem_buf_limit":     true,
+	"buffer_chunk_size": true,
+	"buffer_max_size":   true,
+	"refresh_interval":  true,
+	"rotate_wait":       true,
+	"read_from_head":    true,
+	"skip_long_lines":   true,
+	"skip_empty_lines":  true,
+	"inotify_watcher":   true,
+	"storage.type":      true,
+}
+
+var fluentBitIgnoredOutputKeys = map[string]bool{
+	"name":              true,
+	"match":             true,
+	"region":            true,
+	"auto_create_group": true,
+	"workers":           true,
+	"retry_limit":       true,
+	"role_arn":          true,
+	"endpoint":          true,
+	"sts_endpoint":      true,
+	"log_key":           true,
+	"net.keepalive":     true,
+}
+
+type fluentBitEntry struct {
+	key   string
+	value string
+}
+
+type fluentBitSection struct {
+	name    string
+	line    int
+	entries []fluentBitEntry
+}
+
+func (s *fluentBitSection) get(key string) string {
+	for _, entry := range s.entries {
+		if entry.key == key {
+			return entry.value
+		}
+	}
+	return ""
+}
+
+// parseFluentBitConfig reads the classic (INI-like) Fluent Bit format. The
+// YAML format is not supported.
+func parseFluentBitConfig(content string) (*pipeline, error) {
+	sections, directives, err := splitFluentBitSections(content)
+	if err != nil {
+		return nil, err
+	}
+	p := &pipeline{}
+	for _, directive := range directives {
+		p.reportUnsupported("directive %q", directive)
+	}
+	for _, section := range sections {
+		switch section.name {
+		case fluentBitSectionInput:
+			p.addFluentBitInput(section)
+		case fluentBitSectionFilter:
+			p.addFluentBitFilter(section)
+		case fluentBitSectionOutput:
+			p.addFluentBitOutput(section)
+		case fluentBitSectionService, fluentBitSectionParser:
+		default:
+			p.reportUnsupported("section [%s] at line %d", strings.ToUpper(section.name), section.line)
+		}
+	}
+	return p, nil
+}
+
+func splitFluentBitSections(content string) ([]*fluentBitSection, []string, error) {
+	var sections []*fluentBitSection
+	var directives []string
+	var current *fluentBitSection
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "@") {
+			directives = append(directives, line)
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, nil, fmt.Errorf("invalid section header %q at line %d", line, lineNumber)
+			}
+			current = &fluentBitSection{
+				name: strings.ToLower(strings.TrimSpace(line[1 : len(line)-1])),
+				line: lineNumber,
+			}
+			sections = append(sections, current)
+			continue
+		}
+		if current == nil {
+			return nil, nil, fmt.Errorf("entry %q at line %d is outside of a section", line, lineNumber)
+		}
+		fields := strings.Fields(line)
+		entry := fluentBitEntry{key: strings.ToLower(fields[0])}
+		if len(fields) > 1 {
+			entry.value = strings.TrimSpace(line[len(fields[0]):])
+		}
+		current.entries = append(current.entries, entry)
+	}
+	return sections, directives, scanner.Err()
+}
+
+func (p *pipeline) addFluentBitInput(section *fluentBitSection) {
+	name := section.get("name")
+	if name != "tail" {
+		p.reportUnsupported("input plugin %q at line %d", name, section.line)
+		return
+	}
+	src := &source{tag: section.get("tag")}
+	if src.tag == "" {
+		src.tag = "tail.*"
+	}
+	for _, path := range strings.Split(section.get("path"), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			src.paths = append(src.paths, path)
+		}
+	}
+	if len(src.paths) == 0 {
+		p.reportUnsupported("tail input without a path at line %d", section.line)
+		return
+	}
+	for _, entry := range section.entries {
+		switch {
+		case entry.key == "tag" || entry.key == "path":
+		case fluentBitIgnoredTailKeys[entry.key]:
+		default:
+			p.reportUnsupported("tail option %q at line %d", entry.key, section.line)
+		}
+	}
+	p.sources = append(p.sources, src)
+}
+
+func (p *pipeline) addFluentBitFilter(section *fluentBitSection) {
+	name := section.get("name")
+	if name != "grep" {
+		p.reportUnsupported("filter plugin %q at line %d", name, section.line)
+		return
+	}
+	f := &filter{match: fluentBitMatcher(section)}
+	for _, entry := range section.entries {
+		var filterType string
+		switch entry.key {
+		case "regex":
+			filterType = filterTypeInclude
+		case "exclude":
+			filterType = filterTypeExclude
+		case "name", "match", "match_regex":
+			continue
+		default:
+			p.reportUnsupported("grep option %q at line %d", entry.key, section.line)
+			continue
+		}
+		fields := strings.Fields(entry.value)
+		if len(fields) < 2 {
+			p.reportUnsupported("grep %s %q at line %d", filterType, entry.value, section.line)
+			continue
+		}
+		expression := strings.TrimSpace(entry.value[len(fields[0]):])
+		if rule := p.newFilterRule(filterType, fields[0], expression); rule != nil {
+			f.rules = append(f.rules, rule)
+		}
+	}
+	p.filters = append(p.filters, f)
+}
+
+func (p *pipeline) addFluentBitOutput(section *fluentBitSection) {
+	name := section.get("name")
+	if name != "cloudwatch_logs" && name != "cloudwatch" {
+		p.reportUnsupported("output plugin %q at line %d", name, section.line)
+		return
+	}
+	out := &output{
+		match:         fluentBitMatcher(section),
+		logGroup:      section.get("log_group_name"),
+		logStream:     section.get("log_stream_name"),
+		logGroupClass: section.get("log_group_class"),
+	}
+	if prefix := section.get("log_stream_prefix"); prefix != "" && out.logStream == "" {
+		// The prefix is followed by the tag in Fluent Bit. The agent has no
+		// tag, so use the instance ID to keep streams unique per host.
+		out.logStream = prefix + "{instance_id}"
+	}
+	for _, entry := range section.entries {
+		switch entry.key {
+		case "log_group_name", "log_stream_name", "log_stream_prefix", "log_group_class":
+		case "log_retention_days":
+			retention, err := strconv.Atoi(entry.value)
+			if err != nil {
+				p.reportUnsupported("log_retention_days %q at line %d", entry.value, section.line)
+				continue
+			}
+			out.retention = retention
+		default:
+			if !fluentBitIgnoredOutputKeys[entry.key] {
+				p.reportUnsupported("cloudwatch_logs option %q at line %d", entry.key, section.line)
+			}
+		}
+	}
+	if out.logGroup == "" {
+		p.reportUnsupported("cloudwatch_logs output without a static log_group_name at line %d", section.line)
+		return
+	}
+	p.outputs = append(p.outputs, out)
+}
+
+// fluentBitMatcher builds the tag matcher for a filter or output. Match uses
+// "*" as a wildcard for any sequence of characters.
+func fluentBitMatcher(section *fluentBitSection) *regexp.Regexp {
+	if pattern := section.get("match_regex"); pattern != "" {
+		if re, err := regexp.Compile(pattern); err == nil {
+			return re
+		}
+	}
+	match := section.get("match")
+	if match == "" {
+		// Fluent Bit does not route anything to a section without Match.
+		return regexp.MustCompile(`$^`)
+	}
+	parts := strings.Split(match, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
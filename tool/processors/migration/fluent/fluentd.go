@@ -0,0 +1,339 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package fluent
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	fluentdDirectiveSource = "source"
+	fluentdDirectiveFilter = "filter"
+	fluentdDirectiveMatch  = "match"
+	fluentdDirectiveSystem = "system"
+)
+
+var fluentdIgnoredTailParams = map[string]bool{
+	"@type":                   true,
+	"@id":                     true,
+	"@log_level":              true,
+	"pos_file":                true,
+	"read_from_head":          true,
+	"refresh_interval":        true,
+	"rotate_wait":             true,
+	"enable_watch_timer":      true,
+	"enable_stat_watcher":     true,
+	"follow_inodes":           true,
+	"limit_recently_modified": true,
+}
+
+var fluentdIgnoredOutputParams = map[string]bool{
+	"@type":              true,
+	"@id":                true,
+	"@log_level":         true,
+	"region":             true,
+	"auto_create_stream": true,
+	"aws_key_id":         true,
+	"aws_sec_key":        true,
+	"aws_use_sts":        true,
+	"aws_sts_role_arn":   true,
+	"endpoint":           true,
+	"message_keys":       true,
+}
+
+var fluentdLegacyGrepParam = regexp.MustCompile(`^(regexp|exclude)[0-9]+$`)
+
+type fluentdParam struct {
+	key   string
+	value string
+}
+
+type fluentdElement struct {
+	name     string
+	arg      string
+	line     int
+	params   []fluentdParam
+	children []*fluentdElement
+}
+
+func (e *fluentdElement) get(key string) string {
+	for _, param := range e.params {
+		if param.key == key {
+			return param.value
+		}
+	}
+	return ""
+}
+
+func isFluentdConfig(content string) bool {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "<source") || strings.HasPrefix(line, "<match") {
+			return true
+		}
+	}
+	return false
+}
+
+func parseFluentdConfig(content string) (*pipeline, error) {
+	root, err := parseFluentdElements(content)
+	if err != nil {
+		return nil, err
+	}
+	p := &pipeline{firstMatchOnly: true}
+	for _, param := range root.params {
+		p.reportUnsupported("directive %q", strings.TrimSpace(param.key+" "+param.value))
+	}
+	for _, element := range root.children {
+		switch element.name {
+		case fluentdDirectiveSource:
+			p.addFluentdSource(element)
+		case fluentdDirectiveFilter:
+			p.addFluentdFilter(element)
+		case fluentdDirectiveMatch:
+			p.addFluentdMatch(element)
+		case fluentdDirectiveSystem:
+		default:
+			p.reportUnsupported("<%s> at line %d", element.name, element.line)
+		}
+	}
+	return p, nil
+}
+
+func parseFluentdElements(content string) (*fluentdElement, error) {
+	root := &fluentdElement{}
+	stack := []*fluentdElement{root}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		current := stack[len(stack)-1]
+		switch {
+		case strings.HasPrefix(line, "</"):
+			name := strings.TrimSuffix(strings.TrimPrefix(line, "</"), ">")
+			if len(stack) == 1 || current.name != name {
+				return nil, fmt.Errorf("unexpected closing tag %q at line %d", line, lineNumber)
+			}
+			stack = stack[:len(stack)-1]
+		case strings.HasPrefix(line, "<"):
+			if !strings.HasSuffix(line, ">") {
+				return nil, fmt.Errorf("invalid directive %q at line %d", line, lineNumber)
+			}
+			fields := strings.SplitN(strings.TrimSpace(line[1:len(line)-1]), " ", 2)
+			element := &fluentdElement{name: fields[0], line: lineNumber}
+			if len(fields) > 1 {
+				element.arg = strings.TrimSpace(fields[1])
+			}
+			current.children = append(current.children, element)
+			stack = append(stack, element)
+		default:
+			fields := strings.Fields(line)
+			param := fluentdParam{key: fields[0]}
+			if len(fields) > 1 {
+				param.value = unquoteFluentdValue(strings.TrimSpace(line[len(fields[0]):]))
+			}
+			current.params = append(current.params, param)
+		}
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("unclosed directive <%s> at line %d", stack[len(stack)-1].name, stack[len(stack)-1].line)
+	}
+	return root, scanner.Err()
+}
+
+func unquoteFluentdValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// unwrapFluentdRegexp strips the /.../ delimiters used for regexp values.
+func unwrapFluentdRegexp(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func (p *pipeline) addFluentdSource(element *fluentdElement) {
+	pluginType := element.get("@type")
+	if pluginType != "tail" {
+		p.reportUnsupported("source plugin %q at line %d", pluginType, element.line)
+		return
+	}
+	src := &source{tag: element.get("tag")}
+	for _, path := range strings.Split(element.get("path"), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			src.paths = append(src.paths, path)
+		}
+	}
+	if len(src.paths) == 0 {
+		p.reportUnsupported("tail source without a path at line %d", element.line)
+		return
+	}
+	for _, param := range element.params {
+		if param.key != "tag" && param.key != "path" && !fluentdIgnoredTailParams[param.key] {
+			p.reportUnsupported("tail parameter %q at line %d", param.key, element.line)
+		}
+	}
+	for _, child := range element.children {
+		if child.name != "parse" {
+			p.reportUnsupported("<%s> in tail source at line %d", child.name, child.line)
+			continue
+		}
+		switch parseType := child.get("@type"); parseType {
+		case "none", "":
+		case "multiline":
+			src.multiLineStartPattern = unwrapFluentdRegexp(child.get("format_firstline"))
+		default:
+			// The agent ships the raw line, so structured parsing is lost.
+			p.reportUnsupported("parser %q at line %d", parseType, child.line)
+		}
+	}
+	p.sources = append(p.sources, src)
+}
+
+func (p *pipeline) addFluentdFilter(element *fluentdElement) {
+	pluginType := element.get("@type")
+	if pluginType != "grep" {
+		p.reportUnsupported("filter plugin %q at line %d", pluginType, element.line)
+		return
+	}
+	f := &filter{match: fluentdMatcher(element.arg)}
+	for _, param := range element.params {
+		if param.key == "@type" {
+			continue
+		}
+		if !fluentdLegacyGrepParam.MatchString(param.key) {
+			p.reportUnsupported("grep parameter %q at line %d", param.key, element.line)
+			continue
+		}
+		filterType := filterTypeInclude
+		if strings.HasPrefix(param.key, "exclude") {
+			filterType = filterTypeExclude
+		}
+		fields := strings.Fields(param.value)
+		if len(fields) < 2 {
+			p.reportUnsupported("grep %s %q at line %d", filterType, param.value, element.line)
+			continue
+		}
+		expression := strings.TrimSpace(param.value[len(fields[0]):])
+		if rule := p.newFilterRule(filterType, fields[0], expression); rule != nil {
+			f.rules = append(f.rules, rule)
+		}
+	}
+	for _, child := range element.children {
+		var filterType string
+		switch child.name {
+		case "regexp":
+			filterType = filterTypeInclude
+		case "exclude":
+			filterType = filterTypeExclude
+		default:
+			// <and>/<or> groups cannot be expressed with flat agent filters.
+			p.reportUnsupported("<%s> in grep filter at line %d", child.name, child.line)
+			continue
+		}
+		if rule := p.newFilterRule(filterType, child.get("key"), unwrapFluentdRegexp(child.get("pattern"))); rule != nil {
+			f.rules = append(f.rules, rule)
+		}
+	}
+	p.filters = append(p.filters, f)
+}
+
+func (p *pipeline) addFluentdMatch(element *fluentdElement) {
+	pluginType := element.get("@type")
+	if pluginType != "cloudwatch_logs" {
+		p.reportUnsupported("output plugin %q at line %d", pluginType, element.line)
+		p.outputs = append(p.outputs, &output{match: fluentdMatcher(element.arg), skipped: true})
+		return
+	}
+	out := &output{
+		match:     fluentdMatcher(element.arg),
+		logGroup:  element.get("log_group_name"),
+		logStream: element.get("log_stream_name"),
+	}
+	for _, param := range element.params {
+		switch param.key {
+		case "log_group_name", "log_stream_name":
+		case "retention_in_days":
+			retention, err := strconv.Atoi(param.value)
+			if err != nil {
+				p.reportUnsupported("retention_in_days %q at line %d", param.value, element.line)
+				continue
+			}
+			out.retention = retention
+		default:
+			if !fluentdIgnoredOutputParams[param.key] {
+				p.reportUnsupported("cloudwatch_logs parameter %q at line %d", param.key, element.line)
+			}
+		}
+	}
+	for _, child := range element.children {
+		if child.name != "buffer" {
+			p.reportUnsupported("<%s> in cloudwatch_logs output at line %d", child.name, child.line)
+		}
+	}
+	if out.logGroup == "" {
+		p.reportUnsupported("cloudwatch_logs output without a static log_group_name at line %d", element.line)
+		return
+	}
+	p.outputs = append(p.outputs, out)
+}
+
+// fluentdMatcher converts a space separated list of Fluentd match patterns.
+// "*" matches a single tag part, "**" matches zero or more parts and {a,b}
+// matches either alternative.
+func fluentdMatcher(patterns string) *regexp.Regexp {
+	if patterns == "" {
+		patterns = "**"
+	}
+	var alternatives []string
+	for _, pattern := range strings.Fields(patterns) {
+		var sb strings.Builder
+		for i := 0; i < len(pattern); i++ {
+			switch c := pattern[i]; {
+			case c == '.' && pattern[i+1:] == "**":
+				// A trailing ".**" also matches the bare prefix.
+				i += 2
+				sb.WriteString(`(?:\..*)?`)
+			case c == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '.' {
+					// "**." may also match nothing at all.
+					i++
+					sb.WriteString(`(?:.*\.)?`)
+				} else {
+					sb.WriteString(`.*`)
+				}
+			case c == '*':
+				sb.WriteString(`[^.]+`)
+			case c == '{':
+				sb.WriteString(`(?:`)
+			case c == '}':
+				sb.WriteString(`)`)
+			case c == ',':
+				sb.WriteString(`|`)
+			default:
+				sb.WriteString(regexp.QuoteMeta(string(c)))
+			}
+		}
+		alternatives = append(alternatives, sb.String())
+	}
+	re, err := regexp.Compile("^(?:" + strings.Join(alternatives, "|") + ")$")
+	if err != nil {
+		return regexp.MustCompile(`$^`)
+	}
+	return re
+}
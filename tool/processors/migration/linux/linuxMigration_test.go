@@ -11,7 +11,7 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/data/config"
-	"github.com/aws/amazon-cloudwatch-agent/tool/processors/question/logs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/migration/fluent"
 	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
 	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
@@ -70,7 +70,7 @@ func TestProcessor_Process(t *testing.T) {
 }
 
 func TestProcessor_NextProcessor(t *testing.T) {
-	assert.Equal(t, logs.Processor, Processor.NextProcessor(nil, nil))
+	assert.Equal(t, fluent.Processor, Processor.NextProcessor(nil, nil))
 }
 
 func TestAnyExistingLogAgentConfigFileToImport(t *testing.T) {
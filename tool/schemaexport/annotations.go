@@ -0,0 +1,306 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package schemaexport
+
+// annotation documents one node of the validation schema. Descriptions only
+// fill in nodes that have none, while defaults are the values the translator
+// rules fall back to when the key is not set.
+type annotation struct {
+	description string
+	defaultVal  interface{}
+	examples    []interface{}
+}
+
+const (
+	agentPath        = "/definitions/agentDefinition/properties/"
+	metricsPath      = "/definitions/metricsDefinition/properties/"
+	collectedPath    = metricsPath + "metrics_collected/properties/"
+	metricDefsPath   = "/definitions/metricsDefinition/definitions/"
+	logsPath         = "/definitions/logsDefinition/properties/"
+	logsMetricsPath  = logsPath + "metrics_collected/properties/"
+	collectListPath  = "/definitions/logsDefinition/definitions/logsFilesDefinition/properties/collect_list/items/properties/"
+	eventsListPath   = "/definitions/logsDefinition/definitions/logsWindowsEventsDefinition/properties/collect_list/items/properties/"
+	otlpLogsPath     = "/definitions/logsDefinition/definitions/logsOtlpDefinition/properties/"
+	kernelEventsPath = "/definitions/logsDefinition/definitions/logsKernelEventsDefinition/properties/"
+	tracesPath       = "/definitions/tracesDefinition/properties/"
+	ecsSDPath        = "/definitions/ecsServiceDiscoveryDefinition/"
+
+	metricDeclarationPath = "/definitions/emfProcessorDefinition/definitions/metricDeclarationDefinition/properties/"
+)
+
+var annotations = map[string]annotation{
+	"/properties/agent":   {description: "Settings shared by every part of the agent."},
+	"/properties/metrics": {description: "Metrics collected by the agent and published to CloudWatch or Amazon Managed Service for Prometheus."},
+	"/properties/logs":    {description: "Log files and Windows events published to CloudWatch Logs, and metrics published as embedded metric format logs."},
+	"/properties/traces":  {description: "Traces received by the agent and published to AWS X-Ray."},
+
+	agentPath + "metrics_collection_interval": {defaultVal: 60, examples: []interface{}{10, 60}},
+	agentPath + "logfile":                     {examples: []interface{}{"/opt/aws/amazon-cloudwatch-agent/logs/amazon-cloudwatch-agent.log", ""}},
+	agentPath + "region":                      {examples: []interface{}{"us-east-1"}},
+	agentPath + "debug":                       {defaultVal: false},
+	agentPath + "omit_hostname":               {defaultVal: false},
+	agentPath + "log_format":                  {defaultVal: "text"},
+	agentPath + "component_log_levels":        {examples: []interface{}{map[string]interface{}{"inputs.cpu": "debug", "awsemf": "warn"}}},
+	agentPath + "status_endpoint":             {examples: []interface{}{"127.0.0.1:4312"}},
+	agentPath + "entity_rules":                {examples: []interface{}{[]interface{}{map[string]interface{}{"source": "log_group", "pattern": "/aws/(?P<team>[^/]+)/(?P<app>[^/]+)", "service_name": "{{app}}", "environment": "{{team}}"}}}},
+	agentPath + "host_metadata":               {examples: []interface{}{map[string]interface{}{"file": "/etc/amazon-cloudwatch-agent/host-metadata.yaml"}}},
+
+	metricsPath + "namespace":                                  {defaultVal: "CWAgent"},
+	metricsPath + "force_flush_interval":                       {defaultVal: 60},
+	metricsPath + "aggregation_dimensions":                     {examples: []interface{}{[]interface{}{[]interface{}{"InstanceId"}, []interface{}{}}}},
+	metricsPath + "rollup_rules":                               {examples: []interface{}{[]interface{}{map[string]interface{}{"metric_names": []interface{}{"disk_used_percent"}, "exclude_attribute_groups": []interface{}{[]interface{}{"path"}}, "aggregation": "max", "drop_original": true}}}},
+	metricsPath + "exponential_histogram_compression":          {examples: []interface{}{map[string]interface{}{"max_relative_error": 0.05, "max_buckets": 100}}},
+	metricsPath + "append_dimensions":                          {examples: []interface{}{map[string]interface{}{"InstanceId": "${aws:InstanceId}"}}},
+	metricsPath + "metrics_destinations":                       {description: "Where the metrics are published. Defaults to CloudWatch."},
+	metricsPath + "metrics_destinations/properties/cloudwatch": {description: "Publishes the metrics to CloudWatch."},
+	metricsPath + "metrics_destinations/properties/amp":        {description: "Publishes the metrics to an Amazon Managed Service for Prometheus workspace."},
+	metricsPath + "metrics_collected":                          {description: "The metrics to collect, by plugin."},
+	metricsPath + "service.name":                               {description: "Service name attached to the metrics. Overrides the value from the agent section."},
+	metricsPath + "deployment.environment":                     {description: "Deployment environment attached to the metrics. Overrides the value from the agent section."},
+
+	metricsPath + "ec2_instance_tags/properties/keys":                 {examples: []interface{}{[]interface{}{"Name", "team:*"}}},
+	metricsPath + "ec2_instance_tags/properties/refresh_interval":     {examples: []interface{}{300}},
+	metricsPath + "ec2_instance_tags/properties/tag_change_log_group": {examples: []interface{}{"/aws/ec2/tag-changes"}},
+
+	collectedPath + "collectd":      {description: "Receives metrics from a collectd daemon using its network protocol."},
+	collectedPath + "cpu":           {description: "CPU usage metrics."},
+	collectedPath + "disk":          {description: "Disk space metrics for mounted file systems."},
+	collectedPath + "diskio":        {description: "Disk I/O metrics for block devices."},
+	collectedPath + "statsd":        {description: "Receives metrics using the StatsD protocol."},
+	collectedPath + "swap":          {description: "Swap space metrics."},
+	collectedPath + "mem":           {description: "Memory metrics."},
+	collectedPath + "net":           {description: "Network interface metrics."},
+	collectedPath + "netstat":       {description: "TCP connection state and UDP socket metrics."},
+	collectedPath + "processes":     {description: "Process count metrics by state."},
+	collectedPath + "procstat":      {description: "Metrics of individual processes, selected by executable name, pattern or pid file."},
+	collectedPath + "ethtool":       {description: "Network driver statistics reported by ethtool."},
+	collectedPath + "nfs":           {description: "Operations, round trip times and retransmissions of NFS mounts, per collection interval."},
+	collectedPath + "connections":   {description: "TCP connection states of listening ports and the usage of the netfilter connection tracking table."},
+	collectedPath + "file_handles":  {description: "Usage of the kernel file handles from /proc/sys/fs/file-nr."},
+	collectedPath + "pressure":      {description: "Pressure stall information of the host and cpu, memory and io statistics of cgroup v2 cgroups."},
+	collectedPath + "probes":        {description: "Synthetic HTTP and TCP checks of endpoints: availability, response time and TLS certificate expiry."},
+	collectedPath + "systemd_units": {description: "State, restart count and resource accounting of systemd units, selected by name or pattern."},
+	collectedPath + "textfile":      {description: "Metrics read from the *.prom files of a directory in the Prometheus text exposition format, e.g. written by cron jobs."},
+	collectedPath + "exec":          {description: "Metrics parsed from the output of commands, with the exit code, duration and failures of every run."},
+	collectedPath + "edac":          {description: "Corrected and uncorrected memory errors counted by the EDAC drivers of the kernel, per memory controller and DIMM."},
+	collectedPath + "kernel_events": {description: "The oom-kills, hung tasks and machine check exceptions logged by the kernel, per collection interval."},
+	collectedPath + "nvidia_smi":    {description: "NVIDIA GPU metrics reported by nvidia-smi."},
+	collectedPath + "jmx":           {description: "Java Management Extensions (JMX) metrics."},
+	collectedPath + "otlp":          {description: "Receives OpenTelemetry Protocol (OTLP) metrics."},
+	collectedPath + "prometheus":    {description: "Scrapes Prometheus metrics."},
+
+	metricDefsPath + "basicMetricDefinition/properties/metrics_collection_interval": {description: "How often the metrics are collected, in seconds. Overrides the interval from the agent section."},
+	metricDefsPath + "basicMetricDefinition/properties/append_dimensions":           {description: "Additional dimensions added to the metrics of this plugin."},
+	metricDefsPath + "basicMetricDefinition/properties/measurement":                 {description: "The metrics to collect, by name or as objects that rename them or set their unit."},
+	metricDefsPath + "basicResourcesDefinition/properties/resources":                {description: "The resources to collect the metrics for, such as mount points, devices or interfaces. \"*\" collects all of them.", examples: []interface{}{[]interface{}{"*"}}},
+
+	metricDefsPath + "collectdDefinitions/properties/service_address":              {description: "The address the collectd receiver listens on.", defaultVal: "udp://127.0.0.1:25826"},
+	metricDefsPath + "collectdDefinitions/properties/name_prefix":                  {description: "Prefix added to the names of the collectd metrics.", defaultVal: "collectd_"},
+	metricDefsPath + "collectdDefinitions/properties/collectd_auth_file":           {description: "The collectd authentication file.", defaultVal: "/etc/collectd/auth_file"},
+	metricDefsPath + "collectdDefinitions/properties/collectd_security_level":      {description: "The collectd network security level.", defaultVal: "encrypt"},
+	metricDefsPath + "collectdDefinitions/properties/collectd_typesdb":             {description: "The collectd types.db files.", defaultVal: []interface{}{"/usr/share/collectd/types.db"}},
+	metricDefsPath + "collectdDefinitions/properties/metrics_aggregation_interval": {description: "How often the collectd metrics are aggregated, in seconds. 0 disables aggregation.", defaultVal: 60},
+	metricDefsPath + "collectdDefinitions/properties/drop_original_metrics":        {description: "Metrics that are only published aggregated."},
+	metricDefsPath + "collectdDefinitions/properties/service.name":                 {description: "Service name attached to the collectd metrics."},
+	metricDefsPath + "collectdDefinitions/properties/deployment.environment":       {description: "Deployment environment attached to the collectd metrics."},
+
+	metricDefsPath + "cpuDefinitions/allOf/1/properties/resources":                 {description: "Set to [\"*\"] to collect the metrics of every core as well."},
+	metricDefsPath + "cpuDefinitions/allOf/1/properties/totalcpu":                  {description: "Whether to collect the metrics aggregated across all cores.", defaultVal: true},
+	metricDefsPath + "diskDefinitions/allOf/2/properties/ignore_file_system_types": {description: "File system types that are not collected.", examples: []interface{}{[]interface{}{"sysfs", "tmpfs"}}},
+	metricDefsPath + "diskDefinitions/allOf/2/properties/drop_device":              {description: "Whether to drop the device dimension."},
+
+	metricDefsPath + "statsdDefinitions/properties/allowed_pending_messages":     {description: "How many StatsD messages can be queued before new ones are dropped."},
+	metricDefsPath + "statsdDefinitions/properties/service_address":              {description: "The address the StatsD receiver listens on.", defaultVal: ":8125"},
+	metricDefsPath + "statsdDefinitions/properties/metrics_collection_interval":  {description: "How often the StatsD metrics are flushed, in seconds.", defaultVal: 10},
+	metricDefsPath + "statsdDefinitions/properties/metrics_aggregation_interval": {description: "How often the StatsD metrics are aggregated, in seconds. 0 disables aggregation.", defaultVal: 60},
+	metricDefsPath + "statsdDefinitions/properties/metric_separator":             {description: "Separator used when joining the parts of a StatsD metric name."},
+	metricDefsPath + "statsdDefinitions/properties/parse_data_dog_tags":          {description: "Parses the tags of the DogStatsD format.", defaultVal: true},
+	metricDefsPath + "statsdDefinitions/properties/drop_original_metrics":        {description: "Metrics that are only published aggregated."},
+	metricDefsPath + "statsdDefinitions/properties/service.name":                 {description: "Service name attached to the StatsD metrics."},
+	metricDefsPath + "statsdDefinitions/properties/deployment.environment":       {description: "Deployment environment attached to the StatsD metrics."},
+
+	metricDefsPath + "ampDefinition/properties/workspace_id": {description: "The ID of the Amazon Managed Service for Prometheus workspace.", examples: []interface{}{"ws-12345678-1234-1234-1234-123456789012"}},
+
+	metricDefsPath + "procstatDefinitions/items/allOf/1/properties/pid_file":    {description: "Path of a file containing the pid of the process."},
+	metricDefsPath + "procstatDefinitions/items/allOf/1/properties/exe":         {description: "Regular expression matched against the executable name of the processes."},
+	metricDefsPath + "procstatDefinitions/items/allOf/1/properties/pattern":     {description: "Regular expression matched against the command line of the processes."},
+	metricDefsPath + "procstatDefinitions/items/allOf/1/properties/measurement": {description: "The process metrics to collect.", examples: []interface{}{[]interface{}{"cpu_usage", "memory_rss"}}},
+
+	metricDefsPath + "nfsDefinitions/allOf/2/properties/procfs_root":         {description: "Root of the proc file system, e.g. when the host file systems are mounted into a container.", defaultVal: "/proc"},
+	metricDefsPath + "connectionsDefinitions/allOf/1/properties/ports":       {description: "Local ports to report the TCP connection states for. No connection states are reported if empty.", examples: []interface{}{[]interface{}{80, 443}}},
+	metricDefsPath + "connectionsDefinitions/allOf/1/properties/procfs_root": {description: "Root of the proc file system, e.g. when the host file systems are mounted into a container.", defaultVal: "/proc"},
+	metricDefsPath + "fileHandlesDefinitions/allOf/1/properties/procfs_root": {description: "Root of the proc file system, e.g. when the host file systems are mounted into a container.", defaultVal: "/proc"},
+	metricDefsPath + "pressureDefinitions/allOf/1/properties/cgroups":        {description: "Glob patterns of the cgroups to collect, relative to the cgroup v2 mount point.", examples: []interface{}{[]interface{}{"system.slice/*.service"}}},
+	metricDefsPath + "pressureDefinitions/allOf/1/properties/procfs_root":    {description: "Root of the proc file system, e.g. when the host file systems are mounted into a container.", defaultVal: "/proc"},
+	metricDefsPath + "pressureDefinitions/allOf/1/properties/sysfs_root":     {description: "Root of the sys file system, e.g. when the host file systems are mounted into a container.", defaultVal: "/sys"},
+
+	metricDefsPath + "probesDefinitions/allOf/1/properties/timeout":                                    {description: "Timeout of the probes that do not set one, in seconds.", defaultVal: 5},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/http":                                       {description: "HTTP probes. A probe is up if the status code is expected, or below 400 if no status codes are set."},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/http/items/properties/url":                  {description: "The URL to request.", examples: []interface{}{"https://localhost:8443/health"}},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/http/items/properties/method":               {description: "The request method.", defaultVal: "GET"},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/http/items/properties/headers":              {description: "Request headers. A Host header sets the virtual host of the request."},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/http/items/properties/timeout":              {description: "Timeout of the request in seconds."},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/http/items/properties/insecure_skip_verify": {description: "Whether to accept certificates that cannot be verified. The expiry is still reported.", defaultVal: false},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/tcp":                                        {description: "TCP probes. A probe is up if the connection, and with tls the TLS handshake, succeeds."},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/tcp/items/properties/address":               {description: "The host and port to connect to.", examples: []interface{}{"localhost:5432"}},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/tcp/items/properties/timeout":               {description: "Timeout of the connection in seconds."},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/tcp/items/properties/tls":                   {description: "Whether to complete a TLS handshake and report the certificate expiry.", defaultVal: false},
+
+	metricDefsPath + "probesDefinitions/allOf/1/properties/http/items/properties/expected_status_codes": {description: "The status codes of a successful response."},
+	metricDefsPath + "probesDefinitions/allOf/1/properties/tcp/items/properties/insecure_skip_verify":   {description: "Whether to accept certificates that cannot be verified. The expiry is still reported."},
+
+	metricDefsPath + "textfileDefinitions/properties/directory": {description: "Directory of the *.prom files, which are read on every collection.", examples: []interface{}{"/var/lib/amazon-cloudwatch-agent/textfile"}},
+
+	metricDefsPath + "textfileDefinitions/properties/metrics_collection_interval": {description: "How often the files are read, in seconds."},
+	metricDefsPath + "textfileDefinitions/properties/append_dimensions":           {description: "Additional dimensions added to the metrics of the files."},
+
+	metricDefsPath + "edacDefinitions/allOf/1/properties/sysfs_root":        {description: "Root of the sys file system, e.g. when the host file systems are mounted into a container.", defaultVal: "/sys"},
+	metricDefsPath + "kernelEventsDefinitions/allOf/1/properties/kmsg_path": {description: "Path of the kernel ring buffer, e.g. when the host devices are mounted into a container.", defaultVal: "/dev/kmsg"},
+
+	metricDefsPath + "execDefinitions/items/properties/command":     {description: "The command and its arguments. The command is not run by a shell.", examples: []interface{}{[]interface{}{"/usr/local/bin/queue-depth.sh", "--json"}}},
+	metricDefsPath + "execDefinitions/items/properties/data_format": {description: "The format of the output of the command.", defaultVal: "influx"},
+	metricDefsPath + "execDefinitions/items/properties/tag_keys":    {description: "Fields of the json output that are reported as dimensions."},
+	metricDefsPath + "execDefinitions/items/properties/timeout":     {description: "Time in seconds after which the command is killed.", defaultVal: 30},
+	metricDefsPath + "execDefinitions/items/properties/run_as_user": {description: "The user the command is run as. The agent must run as root to switch users."},
+
+	metricDefsPath + "execDefinitions/items/properties/metrics_collection_interval": {description: "How often the command is run, in seconds."},
+	metricDefsPath + "execDefinitions/items/properties/append_dimensions":           {description: "Additional dimensions added to the metrics of the command."},
+
+	metricDefsPath + "systemdUnitsDefinitions/allOf/1/properties/units":     {description: "Names of the units to monitor. Names without a type suffix get the unit_type suffix.", examples: []interface{}{[]interface{}{"nginx.service", "sshd"}}},
+	metricDefsPath + "systemdUnitsDefinitions/allOf/1/properties/pattern":   {description: "Regular expression matched against the names of the units loaded by systemd.", examples: []interface{}{`^app-.*\.service$`}},
+	metricDefsPath + "systemdUnitsDefinitions/allOf/1/properties/unit_type": {description: "The type of the units matched by the pattern.", defaultVal: "service"},
+
+	metricDefsPath + "ethtoolDefinitions/properties/interface_include":     {description: "Interfaces to collect.", defaultVal: []interface{}{"*"}},
+	metricDefsPath + "ethtoolDefinitions/properties/interface_exclude":     {description: "Interfaces that are not collected."},
+	metricDefsPath + "ethtoolDefinitions/properties/metrics_include":       {description: "The ethtool statistics to collect.", examples: []interface{}{[]interface{}{"bw_in_allowance_exceeded", "pps_allowance_exceeded"}}},
+	metricDefsPath + "ethtoolDefinitions/properties/append_dimensions":     {description: "Additional dimensions added to the ethtool metrics."},
+	metricDefsPath + "ethtoolDefinitions/properties/drop_original_metrics": {description: "Metrics that are only published aggregated."},
+	metricDefsPath + "nvidiaGpuDefinitions/properties/measurement":         {description: "The GPU metrics to collect."},
+
+	metricDefsPath + "metricsMeasurementDefinition/items/oneOf/1/properties/name":   {description: "The name of the metric."},
+	metricDefsPath + "metricsMeasurementDefinition/items/oneOf/1/properties/rename": {description: "The name the metric is published as."},
+	metricDefsPath + "metricsMeasurementDefinition/items/oneOf/1/properties/unit":   {description: "The CloudWatch unit of the metric.", examples: []interface{}{"Percent", "Bytes"}},
+	metricDefsPath + "prometheusDefinitions/properties/prometheus_config_path":      {description: "Path of the Prometheus scrape configuration."},
+
+	logsPath + "logs_collected":                                                            {description: "The log files and Windows events to publish."},
+	logsPath + "logs_collected/properties/files":                                           {description: "Log files to publish."},
+	logsPath + "logs_collected/properties/windows_events":                                  {description: "Windows event logs to publish."},
+	logsPath + "logs_collected/properties/kernel_events":                                   {description: "The oom-kills, hung tasks and machine check exceptions logged by the kernel, as read from /dev/kmsg."},
+	logsPath + "logs_collected/properties/otlp":                                            {description: "Receives OpenTelemetry Protocol (OTLP) log records and publishes them to CloudWatch Logs."},
+	logsPath + "metrics_collected":                                                         {description: "Metrics published as embedded metric format logs."},
+	logsPath + "log_stream_name":                                                           {description: "Default log stream name of the collected logs. {ec2_tag:<key>} is replaced with the value of the instance tag when the configuration is translated.", examples: []interface{}{"{instance_id}", "{hostname}", "{ec2_tag:Name}"}},
+	logsPath + "force_flush_interval":                                                      {defaultVal: 5},
+	logsMetricsPath + "app_signals":                                                        {description: "Deprecated. Use application_signals."},
+	logsMetricsPath + "application_signals":                                                {description: "Application Signals metrics."},
+	logsMetricsPath + "application_signals/properties/hosted_in":                           {description: "The environment the applications run in, such as the name of the cluster."},
+	logsMetricsPath + "application_signals/properties/platform_endpoint":                   {examples: []interface{}{"unix:///var/run/docker.sock", "http://127.0.0.1:4646"}},
+	logsMetricsPath + "application_signals/properties/rules/items/properties/selectors":    {description: "Conditions a metric must match for the rule to apply."},
+	logsMetricsPath + "application_signals/properties/rules/items/properties/replacements": {description: "Dimension values replaced by a replace rule."},
+	logsMetricsPath + "application_signals/properties/rules/items/properties/renames":      {description: "Dimensions renamed by a rename rule."},
+	logsMetricsPath + "ecs":                                                                {description: "Container Insights metrics for Amazon ECS."},
+	logsMetricsPath + "ecs/properties/metrics_collection_interval":                         {description: "How often the Container Insights metrics are collected, in seconds."},
+	logsMetricsPath + "kubernetes":                                                         {description: "Container Insights metrics for Kubernetes."},
+	logsMetricsPath + "kubernetes/properties/cluster_name":                                 {description: "The name of the Kubernetes cluster."},
+	logsMetricsPath + "kubernetes/properties/metrics_collection_interval":                  {description: "How often the Container Insights metrics are collected, in seconds."},
+	logsMetricsPath + "prometheus":                                                         {description: "Prometheus metrics published as embedded metric format logs."},
+	logsMetricsPath + "prometheus/properties/cluster_name":                                 {description: "The name of the cluster the Prometheus targets run in."},
+	logsMetricsPath + "prometheus/properties/log_group_name":                               {description: "The log group the Prometheus metrics are published to."},
+	logsMetricsPath + "prometheus/properties/prometheus_config_path":                       {description: "Path of the Prometheus scrape configuration."},
+	logsMetricsPath + "prometheus/properties/delta_state_file":                             {description: "File the last value of each counter is saved to, so that the deltas continue across agent restarts."},
+	logsMetricsPath + "prometheus/properties/use_created_timestamps":                       {description: "Detects the counter resets, e.g. of restarted pods, by the created timestamps of the OpenMetrics targets, whose _created series are then no longer emitted."},
+	logsMetricsPath + "prometheus/properties/emf_processor":                                {description: "How the Prometheus metrics are turned into embedded metric format logs."},
+	logsMetricsPath + "prometheus/properties/ecs_service_discovery":                        {description: "Discovers Prometheus targets running on Amazon ECS."},
+	logsMetricsPath + "otlp":                                                               {description: "Receives OpenTelemetry Protocol (OTLP) metrics and publishes them as embedded metric format logs."},
+
+	logsMetricsPath + "app_signals/properties/hosted_in":                           {description: "The environment the applications run in, such as the name of the cluster."},
+	logsMetricsPath + "app_signals/properties/rules/items/properties/selectors":    {description: "Conditions a metric must match for the rule to apply."},
+	logsMetricsPath + "app_signals/properties/rules/items/properties/replacements": {description: "Dimension values replaced by a replace rule."},
+	logsMetricsPath + "app_signals/properties/rules/items/properties/renames":      {description: "Dimensions renamed by a rename rule."},
+
+	"/definitions/logsDefinition/definitions/logsFilesDefinition/properties/collect_list":         {description: "The log files to publish."},
+	"/definitions/logsDefinition/definitions/logsWindowsEventsDefinition/properties/collect_list": {description: "The Windows event logs to publish."},
+
+	collectListPath + "file_path":                {description: "Path of the log file. Supports glob patterns.", examples: []interface{}{"/var/log/messages", "/var/log/app/*.log"}},
+	collectListPath + "log_group_name":           {description: "The log group the file is published to. Defaults to the file path.", examples: []interface{}{"/app/{ec2_tag:Environment}/messages"}},
+	collectListPath + "log_stream_name":          {description: "The log stream the file is published to."},
+	collectListPath + "log_group_class":          {description: "The class of the log group when the agent creates it."},
+	collectListPath + "multi_line_start_pattern": {description: "Regular expression matching the first line of a multi-line log entry.", examples: []interface{}{"{timestamp_regex}", `^\d{4}-\d{2}-\d{2}`}},
+	collectListPath + "timestamp_format":         {description: "The format of the timestamps in the log entries.", examples: []interface{}{"%Y-%m-%d %H:%M:%S"}},
+	collectListPath + "timezone":                 {description: "The time zone of the timestamps in the log entries."},
+	collectListPath + "encoding":                 {description: "The encoding of the log file.", examples: []interface{}{"utf-8"}},
+	collectListPath + "auto_removal":             {description: "Whether to remove the log file once it has been published and a newer file matching the path exists."},
+	collectListPath + "blacklist":                {description: "Regular expression of file names that are not published."},
+	collectListPath + "publish_multi_logs":       {description: "Whether every file matching the path is published to its own log stream."},
+	collectListPath + "retention_in_days":        {description: "The retention of the log group, in days. -1 keeps the current retention.", defaultVal: -1},
+	collectListPath + "filters":                  {description: "Include and exclude filters applied to the log entries in order."},
+
+	eventsListPath + "event_name":        {description: "The name of the Windows event log.", examples: []interface{}{"System", "Application"}},
+	eventsListPath + "event_levels":      {description: "The levels of the events to publish."},
+	eventsListPath + "event_ids":         {description: "The IDs of the events to publish."},
+	eventsListPath + "filters":           {description: "Include and exclude filters applied to the events in order."},
+	eventsListPath + "log_stream_name":   {description: "The log stream the events are published to."},
+	eventsListPath + "log_group_name":    {description: "The log group the events are published to."},
+	eventsListPath + "log_group_class":   {description: "The class of the log group when the agent creates it."},
+	eventsListPath + "retention_in_days": {description: "The retention of the log group, in days. -1 keeps the current retention.", defaultVal: -1},
+	eventsListPath + "event_format":      {description: "Whether the events are published as text or XML."},
+
+	kernelEventsPath + "log_stream_name": {description: "The log stream the events are published to. Defaults to the log_stream_name of the logs section."},
+	kernelEventsPath + "kmsg_path":       {description: "Path of the kernel ring buffer, e.g. when the host devices are mounted into a container.", defaultVal: "/dev/kmsg"},
+
+	kernelEventsPath + "log_group_name":    {description: "The log group the events are published to."},
+	kernelEventsPath + "log_group_class":   {description: "The class of the log group when the agent creates it."},
+	kernelEventsPath + "retention_in_days": {description: "The retention of the log group, in days. -1 keeps the current retention."},
+
+	otlpLogsPath + "log_group_name":  {description: "The log group the log records are published to. Resource attributes can be used as placeholders.", examples: []interface{}{"/aws/otlp/{service.name}"}},
+	otlpLogsPath + "log_stream_name": {description: "The log stream the log records are published to. Resource attributes can be used as placeholders.", examples: []interface{}{"{service.instance.id}"}},
+	otlpLogsPath + "body_format":     {description: "Whether the log records are published as their raw body or as JSON objects.", defaultVal: "raw"},
+	otlpLogsPath + "severity_format": {description: "How the severity of the log records is published.", defaultVal: "text"},
+
+	otlpLogsPath + "log_group_class":   {description: "The class of the log group when the agent creates it."},
+	otlpLogsPath + "retention_in_days": {description: "The retention of the log group, in days. -1 keeps the current retention."},
+	otlpLogsPath + "tls":               {description: "The certificate and key of the receiver. The receiver does not use TLS if not set."},
+
+	tracesPath + "traces_collected":                                                                 {description: "The traces to receive."},
+	tracesPath + "traces_collected/properties/app_signals":                                          {description: "Deprecated. Use application_signals."},
+	tracesPath + "traces_collected/properties/application_signals":                                  {description: "Application Signals traces."},
+	tracesPath + "traces_collected/properties/application_signals/properties/generate_span_metrics": {description: "Derives the Application Signals latency, fault and error metrics from spans that do not come from an ADOT SDK."},
+	tracesPath + "traces_collected/properties/xray":                                                 {description: "Receives X-Ray segments."},
+	tracesPath + "traces_collected/properties/otlp":                                                 {description: "Receives OpenTelemetry Protocol (OTLP) traces."},
+
+	"/definitions/tracesDefinition/definitions/xrayDefinition/properties/bind_address": {defaultVal: "127.0.0.1:2000"},
+	"/definitions/tcpProxyDefinition/properties/bind_address":                          {defaultVal: "127.0.0.1:2000"},
+	"/definitions/otlpObjectDefinition/properties/grpc_endpoint":                       {examples: []interface{}{"127.0.0.1:4317"}},
+	"/definitions/otlpObjectDefinition/properties/http_endpoint":                       {examples: []interface{}{"127.0.0.1:4318"}},
+	"/definitions/otlpObjectDefinition/properties/tls":                                 {description: "TLS settings of the OTLP receiver."},
+
+	"/definitions/tlsDefinitions/properties/ca_file":   {description: "Path of the CA certificate used to verify clients."},
+	"/definitions/tlsDefinitions/properties/cert_file": {description: "Path of the server certificate."},
+	"/definitions/tlsDefinitions/properties/key_file":  {description: "Path of the server private key."},
+	"/definitions/tlsDefinitions/properties/insecure":  {description: "Whether to skip TLS verification."},
+
+	"/definitions/jmxObjectDefinition/properties/metrics_collection_interval": {description: "How often the JMX metrics are collected, in seconds."},
+	"/definitions/jmxObjectDefinition/properties/jvm":                         {description: "JVM metrics."},
+	"/definitions/jmxObjectDefinition/properties/kafka":                       {description: "Kafka broker metrics."},
+	"/definitions/jmxObjectDefinition/properties/kafka-consumer":              {description: "Kafka consumer metrics."},
+	"/definitions/jmxObjectDefinition/properties/kafka-producer":              {description: "Kafka producer metrics."},
+	"/definitions/jmxObjectDefinition/properties/tomcat":                      {description: "Tomcat metrics."},
+	"/definitions/jmxObjectDefinition/properties/append_dimensions":           {description: "Additional dimensions added to the JMX metrics."},
+	"/definitions/jmxTargetDefinition/properties/measurement":                 {description: "The metrics of the target to collect."},
+
+	ecsSDPath + "properties/docker_label":                                  {description: "Discovers targets by the Docker labels of their containers."},
+	ecsSDPath + "properties/task_definition_list":                          {description: "Discovers targets by their task definition."},
+	ecsSDPath + "properties/service_name_list_for_tasks":                   {description: "Discovers targets by the name of their ECS service."},
+	ecsSDPath + "properties/sd_frequency":                                  {defaultVal: "1m"},
+	ecsSDPath + "definitions/dockerLabel/properties/sd_job_name_label":     {defaultVal: "job"},
+	ecsSDPath + "definitions/dockerLabel/properties/sd_metrics_path_label": {defaultVal: "ECS_PROMETHEUS_METRICS_PATH"},
+	ecsSDPath + "definitions/dockerLabel/properties/sd_port_label":         {defaultVal: "ECS_PROMETHEUS_EXPORTER_PORT"},
+
+	"/definitions/emfProcessorDefinition/properties/metric_declaration": {description: "Which metrics are published, and with which dimensions."},
+
+	metricDeclarationPath + "source_labels":    {description: "The labels whose values, joined by the label_separator, are matched by the label_matcher."},
+	metricDeclarationPath + "label_matcher":    {description: "Regular expression matched against the values of the source_labels."},
+	metricDeclarationPath + "label_separator":  {description: "Separator of the source_labels values."},
+	metricDeclarationPath + "metric_selectors": {description: "Regular expressions of the names of the metrics to publish."},
+	metricDeclarationPath + "dimensions":       {description: "The dimension sets the metrics are published with."},
+}
@@ -0,0 +1,203 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package schemaexport
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// arrayItemSegment stands for any element of an array in a completion path.
+const arrayItemSegment = "[]"
+
+// CompletionData lists every key of the config in a form editors and
+// language servers can offer as completions without evaluating the schema.
+// SchemaID matches the "$schema" value of the configs it applies to.
+type CompletionData struct {
+	SchemaID string            `json:"schemaId"`
+	Title    string            `json:"title"`
+	Items    []*CompletionItem `json:"items"`
+}
+
+type CompletionItem struct {
+	// Path is the JSON pointer of the key, with "[]" for array elements,
+	// e.g. /metrics/metrics_collected/procstat/[]/exe.
+	Path        string        `json:"path"`
+	Label       string        `json:"label"`
+	Type        string        `json:"type,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Examples    []interface{} `json:"examples,omitempty"`
+	InsertText  string        `json:"insertText"`
+}
+
+type completionWalker struct {
+	root  map[string]interface{}
+	items []*CompletionItem
+	// visiting holds the refs being expanded, to stop on recursive schemas.
+	visiting map[string]bool
+}
+
+// Completions flattens an exported schema into completion items, one per
+// key path, sorted by path.
+func Completions(schema map[string]interface{}) *CompletionData {
+	w := &completionWalker{root: schema, visiting: map[string]bool{}}
+	w.walk(schema, "")
+	sort.SliceStable(w.items, func(i, j int) bool {
+		return w.items[i].Path < w.items[j].Path
+	})
+	id, _ := schema["id"].(string)
+	title, _ := schema["title"].(string)
+	return &CompletionData{SchemaID: id, Title: title, Items: w.items}
+}
+
+func (w *completionWalker) walk(node map[string]interface{}, path string) {
+	alternatives, refs := w.expand(node)
+	for _, ref := range refs {
+		if w.visiting[ref] {
+			return
+		}
+	}
+	for _, ref := range refs {
+		w.visiting[ref] = true
+		defer delete(w.visiting, ref)
+	}
+
+	properties := map[string]interface{}{}
+	var arrayItems []map[string]interface{}
+	for _, alternative := range alternatives {
+		if props, ok := alternative["properties"].(map[string]interface{}); ok {
+			for name, prop := range props {
+				if _, exists := properties[name]; !exists {
+					properties[name] = prop
+				}
+			}
+		}
+		if items, ok := alternative["items"].(map[string]interface{}); ok {
+			arrayItems = append(arrayItems, items)
+		}
+	}
+	for _, items := range arrayItems {
+		w.walk(items, path+"/"+arrayItemSegment)
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop, ok := properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		propPath := path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+		w.items = append(w.items, w.item(name, propPath, prop))
+		w.walk(prop, propPath)
+	}
+}
+
+func (w *completionWalker) item(name, path string, prop map[string]interface{}) *CompletionItem {
+	item := &CompletionItem{Path: path, Label: name}
+	// Keywords next to a $ref take precedence over the referenced node.
+	alternatives, _ := w.expand(prop)
+	for _, alternative := range alternatives {
+		if item.Description == "" {
+			item.Description, _ = alternative["description"].(string)
+		}
+		if item.Default == nil {
+			item.Default = alternative["default"]
+		}
+		if item.Enum == nil {
+			item.Enum, _ = alternative["enum"].([]interface{})
+		}
+		if item.Examples == nil {
+			item.Examples, _ = alternative["examples"].([]interface{})
+		}
+	}
+	item.Type = strings.Join(w.types(prop), "|")
+	item.InsertText = insertText(item)
+	return item
+}
+
+// expand returns the node followed by everything it references or combines
+// with allOf, anyOf and oneOf, along with the refs that were followed.
+func (w *completionWalker) expand(node map[string]interface{}) ([]map[string]interface{}, []string) {
+	result := []map[string]interface{}{node}
+	var refs []string
+	for i := 0; i < len(result); i++ {
+		current := result[i]
+		if ref, ok := current["$ref"].(string); ok && !contains(refs, ref) {
+			refs = append(refs, ref)
+			if target, ok := resolvePointer(w.root, strings.TrimPrefix(ref, "#")).(map[string]interface{}); ok {
+				result = append(result, target)
+			}
+		}
+		for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+			list, _ := current[keyword].([]interface{})
+			for _, entry := range list {
+				if m, ok := entry.(map[string]interface{}); ok {
+					result = append(result, m)
+				}
+			}
+		}
+	}
+	return result, refs
+}
+
+func contains(list []string, value string) bool {
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *completionWalker) types(node map[string]interface{}) []string {
+	var types []string
+	seen := map[string]bool{}
+	add := func(t string) {
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	alternatives, _ := w.expand(node)
+	for _, alternative := range alternatives {
+		switch t := alternative["type"].(type) {
+		case string:
+			add(t)
+		case []interface{}:
+			for _, entry := range t {
+				if s, ok := entry.(string); ok {
+					add(s)
+				}
+			}
+		}
+	}
+	return types
+}
+
+// insertText is the text inserted when a completion is picked, with the
+// default as the value when there is one.
+func insertText(item *CompletionItem) string {
+	value := ""
+	switch {
+	case item.Default != nil:
+		if b, err := json.Marshal(item.Default); err == nil {
+			value = string(b)
+		}
+	case strings.HasPrefix(item.Type, "object"):
+		value = "{}"
+	case strings.HasPrefix(item.Type, "array"):
+		value = "[]"
+	case strings.HasPrefix(item.Type, "string"):
+		value = `""`
+	}
+	label, _ := json.Marshal(item.Label)
+	return string(label) + ": " + value
+}
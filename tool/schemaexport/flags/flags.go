@@ -0,0 +1,13 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package flags
+
+import "github.com/aws/amazon-cloudwatch-agent/tool/cmdwrapper"
+
+const Command = "config-schema"
+
+var SchemaFlags = map[string]cmdwrapper.Flag{
+	"output":            {DefaultValue: "", Description: "Path of the output JSON Schema. The schema is printed to stdout if not set."},
+	"completion-output": {DefaultValue: "", Description: "Path of the output editor completion data. Not written if not set."},
+}
@@ -0,0 +1,131 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package schemaexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/version"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+)
+
+const (
+	schemaTitle = "Amazon CloudWatch Agent configuration"
+	schemaKey   = "$schema"
+)
+
+func RunSchemaExportFromFlags(flags map[string]*string) error {
+	return RunSchemaExport(*flags["output"], *flags["completion-output"])
+}
+
+// RunSchemaExport writes the documented schema of the running agent version
+// to outputPath, or to stdout when outputPath is empty, and the completion
+// data to completionPath when it is set.
+func RunSchemaExport(outputPath, completionPath string) error {
+	schema, err := Export(version.Number())
+	if err != nil {
+		return err
+	}
+	if err = writeJSON(schema, outputPath, "JSON Schema"); err != nil {
+		return err
+	}
+	if completionPath == "" {
+		return nil
+	}
+	return writeJSON(Completions(schema), completionPath, "completion data")
+}
+
+func writeJSON(value interface{}, path, name string) error {
+	byteArray, err := json.MarshalIndent(value, "", "\t")
+	if err != nil {
+		return fmt.Errorf("failed to serialize the %s: %w", name, err)
+	}
+	if path == "" {
+		fmt.Println(string(byteArray))
+		return nil
+	}
+	if err = os.WriteFile(path, byteArray, 0644); err != nil {
+		return fmt.Errorf("failed to write the %s to %s: %w", name, path, err)
+	}
+	fmt.Printf("The %s was written to %s.\n", name, path)
+	return nil
+}
+
+// SchemaID is the id of the schema exported for an agent version. Configs
+// can point their "$schema" key at a local copy of the file with this name.
+func SchemaID(agentVersion string) string {
+	return fmt.Sprintf("amazon-cloudwatch-agent-%s.schema.json", agentVersion)
+}
+
+// Export returns the validation schema with its version, and with the
+// descriptions, defaults and examples of the annotations merged in. The
+// validation rules themselves are left untouched, so every config that
+// passes the translator validation also passes the exported schema.
+func Export(agentVersion string) (map[string]interface{}, error) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(config.GetJsonSchema()), &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse the embedded schema: %w", err)
+	}
+	schema["id"] = SchemaID(agentVersion)
+	schema["title"] = fmt.Sprintf("%s %s", schemaTitle, agentVersion)
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	if properties == nil {
+		return nil, fmt.Errorf("embedded schema has no properties")
+	}
+	properties[schemaKey] = map[string]interface{}{
+		"description": "The JSON Schema the config is written against. Only used by editors.",
+		"type":        "string",
+	}
+
+	pointers := make([]string, 0, len(annotations))
+	for pointer := range annotations {
+		pointers = append(pointers, pointer)
+	}
+	sort.Strings(pointers)
+	for _, pointer := range pointers {
+		node, ok := resolvePointer(schema, pointer).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("annotated schema node %s does not exist", pointer)
+		}
+		a := annotations[pointer]
+		if _, hasDescription := node["description"]; !hasDescription && a.description != "" {
+			node["description"] = a.description
+		}
+		if a.defaultVal != nil {
+			node["default"] = a.defaultVal
+		}
+		if len(a.examples) > 0 {
+			node["examples"] = a.examples
+		}
+	}
+	return schema, nil
+}
+
+// resolvePointer follows a JSON pointer ("/definitions/agentDefinition")
+// from the root of the schema.
+func resolvePointer(root interface{}, pointer string) interface{} {
+	node := root
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch current := node.(type) {
+		case map[string]interface{}:
+			node = current[token]
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil
+			}
+			node = current[index]
+		default:
+			return nil
+		}
+	}
+	return node
+}
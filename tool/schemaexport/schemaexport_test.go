@@ -0,0 +1,352 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package schemaexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/logger"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/connections"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/edac"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/exec"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/file_handles"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/kernel_events/kmsg"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/nfs"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/pressure"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/probes"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	collect_list "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/files/collect_list"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/windows_events/collect_list"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/metrics_collected/prometheus/ecsservicediscovery"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/metrics_collected/prometheus/ecsservicediscovery/dockerlabel"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics"
+	collectd "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/collectd"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/cpu"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/ethtool"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/exec"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/statsd"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/systemd_units"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/awsxray"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util/tagutil"
+)
+
+func TestExport(t *testing.T) {
+	schema, err := Export("1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, "amazon-cloudwatch-agent-1.2.3.schema.json", schema["id"])
+	assert.Equal(t, "Amazon CloudWatch Agent configuration 1.2.3", schema["title"])
+
+	interval := resolvePointer(schema, agentPath+"metrics_collection_interval").(map[string]interface{})
+	assert.Equal(t, 60, interval["default"])
+	// Existing descriptions are kept.
+	assert.Equal(t, "How often the metrics defined will be collected", interval["description"])
+	statsdAddress := resolvePointer(schema, metricDefsPath+"statsdDefinitions/properties/service_address").(map[string]interface{})
+	assert.Equal(t, ":8125", statsdAddress["default"])
+	assert.NotEmpty(t, statsdAddress["description"])
+}
+
+// TestExportValidatesSampleConfigs checks that the annotations do not change
+// what the schema accepts.
+func TestExportValidatesSampleConfigs(t *testing.T) {
+	schema, err := Export("1.2.3")
+	require.NoError(t, err)
+	schemaLoader := gojsonschema.NewGoLoader(schema)
+
+	samples, err := filepath.Glob(filepath.Join("..", "..", "translator", "tocwconfig", "sampleConfig", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, samples)
+	for _, sample := range samples {
+		content, err := os.ReadFile(sample)
+		require.NoError(t, err)
+		var config map[string]interface{}
+		require.NoError(t, json.Unmarshal(content, &config), sample)
+		config[schemaKey] = SchemaID("1.2.3")
+		result, err := gojsonschema.Validate(schemaLoader, gojsonschema.NewGoLoader(config))
+		require.NoError(t, err, sample)
+		assert.Empty(t, result.Errors(), sample)
+	}
+}
+
+// TestEveryPropertyHasDescription fails for a key added to the schema without
+// a description, either in the schema itself, in the node it refers to, or
+// in the annotations.
+func TestEveryPropertyHasDescription(t *testing.T) {
+	schema, err := Export("1.2.3")
+	require.NoError(t, err)
+	var missing []string
+	var walk func(node interface{}, pointer string)
+	walk = func(node interface{}, pointer string) {
+		switch current := node.(type) {
+		case map[string]interface{}:
+			if properties, ok := current["properties"].(map[string]interface{}); ok {
+				for key, property := range properties {
+					if !hasDescription(schema, property) {
+						missing = append(missing, pointer+"/properties/"+key)
+					}
+				}
+			}
+			for key, child := range current {
+				walk(child, pointer+"/"+key)
+			}
+		case []interface{}:
+			for i, child := range current {
+				walk(child, fmt.Sprintf("%s/%d", pointer, i))
+			}
+		}
+	}
+	walk(schema, "")
+	sort.Strings(missing)
+	assert.Empty(t, missing, "add a description to the schema or to the annotations")
+}
+
+func hasDescription(schema map[string]interface{}, property interface{}) bool {
+	node, ok := property.(map[string]interface{})
+	if !ok {
+		return true
+	}
+	if _, ok = node["description"]; ok {
+		return true
+	}
+	if ref, ok := node["$ref"].(string); ok {
+		target, _ := resolvePointer(schema, strings.TrimPrefix(ref, "#")).(map[string]interface{})
+		_, ok = target["description"]
+		return ok
+	}
+	return false
+}
+
+// TestDefaultsMatchTranslatorRules keeps the documented defaults in sync with
+// the values the translator rules, or the plugins for the keys the rules pass
+// through, fall back to. Every annotation with a default must be covered.
+func TestDefaultsMatchTranslatorRules(t *testing.T) {
+	testCases := map[string]func() interface{}{
+		agentPath + "metrics_collection_interval": func() interface{} { return seconds(ruleDefault(new(agent.Interval))) },
+		agentPath + "debug":                       func() interface{} { return ruleDefault(new(agent.Debug)) },
+		agentPath + "omit_hostname":               func() interface{} { return ruleDefault(new(agent.OmitHostname)) },
+		agentPath + "log_format":                  func() interface{} { return logger.LogFormatText },
+
+		metricsPath + "namespace": func() interface{} { return ruleDefaultOf(new(metrics.Namespace), "namespace") },
+		metricsPath + "force_flush_interval": func() interface{} {
+			return seconds(ruleDefaultOf(new(metrics.ForceFlushInterval), "force_flush_interval"))
+		},
+
+		metricDefsPath + "collectdDefinitions/properties/service_address":         func() interface{} { return ruleDefault(new(collectd.ServiceAddress)) },
+		metricDefsPath + "collectdDefinitions/properties/name_prefix":             func() interface{} { return ruleDefault(new(collectd.NamePrefix)) },
+		metricDefsPath + "collectdDefinitions/properties/collectd_auth_file":      func() interface{} { return ruleDefault(new(collectd.AuthFile)) },
+		metricDefsPath + "collectdDefinitions/properties/collectd_security_level": func() interface{} { return ruleDefault(new(collectd.SecurityLevel)) },
+		metricDefsPath + "collectdDefinitions/properties/collectd_typesdb":        func() interface{} { return ruleDefault(new(collectd.TypesDB)) },
+		metricDefsPath + "collectdDefinitions/properties/metrics_aggregation_interval": func() interface{} {
+			return seconds(ruleDefaultOf(new(collectd.MetricsAggregationInterval), tagutil.AggregationIntervalTagKey))
+		},
+		metricDefsPath + "cpuDefinitions/allOf/1/properties/totalcpu":   func() interface{} { return ruleDefault(new(cpu.TotalCpu)) },
+		metricDefsPath + "statsdDefinitions/properties/service_address": func() interface{} { return ruleDefault(new(statsd.ServiceAddress)) },
+		metricDefsPath + "statsdDefinitions/properties/metrics_collection_interval": func() interface{} {
+			return seconds(ruleDefault(new(statsd.MetricsCollectionInterval)))
+		},
+		metricDefsPath + "statsdDefinitions/properties/metrics_aggregation_interval": func() interface{} {
+			return seconds(ruleDefaultOf(new(statsd.MetricsAggregationInterval), tagutil.AggregationIntervalTagKey))
+		},
+		metricDefsPath + "statsdDefinitions/properties/parse_data_dog_tags": func() interface{} { return ruleDefault(new(statsd.ParseTags)) },
+
+		metricDefsPath + "nfsDefinitions/allOf/2/properties/procfs_root":         func() interface{} { return inputDefault(t, "nfs", "procfs_root") },
+		metricDefsPath + "connectionsDefinitions/allOf/1/properties/procfs_root": func() interface{} { return inputDefault(t, "connections", "procfs_root") },
+		metricDefsPath + "fileHandlesDefinitions/allOf/1/properties/procfs_root": func() interface{} { return inputDefault(t, "file_handles", "procfs_root") },
+		metricDefsPath + "pressureDefinitions/allOf/1/properties/procfs_root":    func() interface{} { return inputDefault(t, "pressure", "procfs_root") },
+		metricDefsPath + "pressureDefinitions/allOf/1/properties/sysfs_root":     func() interface{} { return inputDefault(t, "pressure", "sysfs_root") },
+		metricDefsPath + "edacDefinitions/allOf/1/properties/sysfs_root":         func() interface{} { return inputDefault(t, "edac", "sysfs_root") },
+		metricDefsPath + "kernelEventsDefinitions/allOf/1/properties/kmsg_path":  func() interface{} { return kmsg.DefaultPath },
+
+		metricDefsPath + "probesDefinitions/allOf/1/properties/timeout":                                    func() interface{} { return inputDefault(t, "probes", "timeout") },
+		metricDefsPath + "probesDefinitions/allOf/1/properties/http/items/properties/method":               func() interface{} { return http.MethodGet },
+		metricDefsPath + "probesDefinitions/allOf/1/properties/http/items/properties/insecure_skip_verify": func() interface{} { return fieldDefault(t, probes.HTTPProbe{}, "insecure_skip_verify") },
+		metricDefsPath + "probesDefinitions/allOf/1/properties/tcp/items/properties/tls":                   func() interface{} { return fieldDefault(t, probes.TCPProbe{}, "tls") },
+
+		metricDefsPath + "execDefinitions/items/properties/data_format":         func() interface{} { return ruleDefault(new(exec.DataFormat)) },
+		metricDefsPath + "execDefinitions/items/properties/timeout":             func() interface{} { return inputDefault(t, "exec", "timeout") },
+		metricDefsPath + "systemdUnitsDefinitions/allOf/1/properties/unit_type": func() interface{} { return ruleDefault(new(systemd_units.UnitType)) },
+		metricDefsPath + "ethtoolDefinitions/properties/interface_include":      func() interface{} { return ruleDefault(new(ethtool.InterfaceInclude)) },
+
+		logsPath + "force_flush_interval": func() interface{} {
+			return seconds(ruleDefaultOf(new(logs.ForceFlushInterval), "force_flush_interval"))
+		},
+		collectListPath + "retention_in_days": func() interface{} { return ruleDefault(new(collect_list.RetentionInDays)) },
+		eventsListPath + "retention_in_days":  func() interface{} { return ruleDefault(new(collectlist.RetentionInDays)) },
+		kernelEventsPath + "kmsg_path":        func() interface{} { return kmsg.DefaultPath },
+		otlpLogsPath + "body_format": func() interface{} {
+			return cloudwatchlogs.NewFactory().CreateDefaultConfig().(*cloudwatchlogs.Config).BodyFormat
+		},
+		otlpLogsPath + "severity_format": func() interface{} {
+			return cloudwatchlogs.NewFactory().CreateDefaultConfig().(*cloudwatchlogs.Config).SeverityFormat
+		},
+
+		"/definitions/tracesDefinition/definitions/xrayDefinition/properties/bind_address": func() interface{} { return xrayDefaults(t).Endpoint },
+		"/definitions/tcpProxyDefinition/properties/bind_address":                          func() interface{} { return xrayDefaults(t).ProxyServer.Endpoint },
+
+		ecsSDPath + "properties/sd_frequency":                                  func() interface{} { return ruleDefault(new(ecsservicediscovery.SDFrequency)) },
+		ecsSDPath + "definitions/dockerLabel/properties/sd_job_name_label":     func() interface{} { return ruleDefault(new(dockerlabel.SDJobNameLabel)) },
+		ecsSDPath + "definitions/dockerLabel/properties/sd_metrics_path_label": func() interface{} { return ruleDefault(new(dockerlabel.SDMetricsPathLabel)) },
+		ecsSDPath + "definitions/dockerLabel/properties/sd_port_label":         func() interface{} { return ruleDefault(new(dockerlabel.SDPortLabel)) },
+	}
+	for pointer, a := range annotations {
+		if a.defaultVal != nil {
+			assert.Contains(t, testCases, pointer, "the default of %s is not checked against the translator", pointer)
+		}
+	}
+	for pointer, defaultVal := range testCases {
+		t.Run(pointer, func(t *testing.T) {
+			// compare the JSON forms, e.g. []string and []interface{}
+			expected, err := json.Marshal(defaultVal())
+			require.NoError(t, err)
+			actual, err := json.Marshal(annotations[pointer].defaultVal)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+}
+
+// ruleDefault returns the value of the rule when its key is not set.
+func ruleDefault(rule translator.Rule) interface{} {
+	_, val := rule.ApplyRule(map[string]interface{}{})
+	return val
+}
+
+// ruleDefaultOf returns the value of the key in the section the rule
+// returns when its key is not set.
+func ruleDefaultOf(rule translator.Rule, key string) interface{} {
+	section, _ := ruleDefault(rule).(map[string]interface{})
+	return section[key]
+}
+
+// seconds converts the translated intervals back to the seconds of the JSON
+// config.
+func seconds(val interface{}) interface{} {
+	interval, ok := val.(string)
+	if !ok {
+		return val
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return val
+	}
+	return int(d.Seconds())
+}
+
+// inputDefault returns the value the plugin starts with for the TOML key.
+func inputDefault(t *testing.T, name, key string) interface{} {
+	creator, ok := inputs.Inputs[name]
+	require.True(t, ok, name)
+	return fieldDefault(t, creator(), key)
+}
+
+// fieldDefault returns the value of the field with the TOML key, with
+// durations in seconds.
+func fieldDefault(t *testing.T, v interface{}, key string) interface{} {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).Tag.Get("toml") != key {
+			continue
+		}
+		field := value.Field(i).Interface()
+		if d, ok := field.(config.Duration); ok {
+			return int(time.Duration(d).Seconds())
+		}
+		return field
+	}
+	require.Failf(t, "missing field", "%T has no field %s", v, key)
+	return nil
+}
+
+func xrayDefaults(t *testing.T) *awsxrayreceiver.Config {
+	conf := confmap.NewFromStringMap(map[string]interface{}{
+		"traces": map[string]interface{}{
+			"traces_collected": map[string]interface{}{
+				"xray": map[string]interface{}{},
+			},
+		},
+	})
+	cfg, err := awsxray.NewTranslator().Translate(conf)
+	require.NoError(t, err)
+	return cfg.(*awsxrayreceiver.Config)
+}
+
+func TestCompletions(t *testing.T) {
+	schema, err := Export("1.2.3")
+	require.NoError(t, err)
+	data := Completions(schema)
+	assert.Equal(t, "amazon-cloudwatch-agent-1.2.3.schema.json", data.SchemaID)
+
+	items := map[string]*CompletionItem{}
+	for _, item := range data.Items {
+		items[item.Path] = item
+	}
+	assert.Equal(t, &CompletionItem{
+		Path:        "/agent/metrics_collection_interval",
+		Label:       "metrics_collection_interval",
+		Type:        "integer",
+		Description: "How often the metrics defined will be collected",
+		Default:     60,
+		Examples:    []interface{}{10, 60},
+		InsertText:  `"metrics_collection_interval": 60`,
+	}, items["/agent/metrics_collection_interval"])
+
+	securityLevel := items["/metrics/metrics_collected/collectd/collectd_security_level"]
+	require.NotNil(t, securityLevel)
+	assert.Equal(t, []interface{}{"none", "sign", "encrypt"}, securityLevel.Enum)
+	assert.Equal(t, `"collectd_security_level": "encrypt"`, securityLevel.InsertText)
+
+	for _, path := range []string{
+		"/$schema",
+		"/metrics/metrics_collected/cpu/totalcpu",
+		"/metrics/metrics_collected/procstat/[]/exe",
+		"/metrics/metrics_collected/procstat/[]/measurement",
+		"/logs/logs_collected/files/collect_list/[]/file_path",
+		"/logs/logs_collected/files/collect_list/[]/filters/[]/type",
+		"/traces/traces_collected/xray/tcp_proxy/bind_address",
+	} {
+		assert.Contains(t, items, path)
+	}
+	assert.Equal(t, "array", items["/metrics/metrics_collected/procstat"].Type)
+	assert.Equal(t, "array", items["/metrics/metrics_collected/procstat/[]/measurement"].Type)
+	assert.Equal(t, "string", items["/metrics/metrics_collected/cpu/measurement/[]/rename"].Type)
+}
+
+func TestRunSchemaExport(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	completionPath := filepath.Join(dir, "completion.json")
+	require.NoError(t, RunSchemaExport(schemaPath, completionPath))
+
+	content, err := os.ReadFile(schemaPath)
+	require.NoError(t, err)
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &schema))
+	assert.Contains(t, schema["id"], "amazon-cloudwatch-agent-")
+
+	content, err = os.ReadFile(completionPath)
+	require.NoError(t, err)
+	var data CompletionData
+	require.NoError(t, json.Unmarshal(content, &data))
+	assert.Equal(t, schema["id"], data.SchemaID)
+	assert.NotEmpty(t, data.Items)
+}
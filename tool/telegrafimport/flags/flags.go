@@ -0,0 +1,16 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package flags
+
+import "github.com/aws/amazon-cloudwatch-agent/tool/cmdwrapper"
+
+const (
+	Command                       = "import-telegraf"
+	DefaultFilePathTelegrafConfig = "/etc/telegraf/telegraf.conf"
+)
+
+var ImportFlags = map[string]cmdwrapper.Flag{
+	"input":  {DefaultValue: DefaultFilePathTelegrafConfig, Description: "Path of the telegraf.conf to import."},
+	"output": {DefaultValue: "", Description: "Path of the output json config. The config is printed to stdout if not set."},
+}
@@ -0,0 +1,269 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package telegrafimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	keyInterval   = "interval"
+	keyFieldPass  = "fieldpass"
+	keyTagPass    = "tagpass"
+	keyResources  = "resources"
+	keyMeasure    = "measurement"
+	keyCollection = "metrics_collection_interval"
+)
+
+// commonPluginKeys are handled for every input before the plugin specific
+// keys are looked at.
+var commonPluginKeys = map[string]bool{
+	keyInterval:  true,
+	keyFieldPass: true,
+	keyTagPass:   true,
+}
+
+func RunImporterFromFlags(flags map[string]*string) error {
+	return RunImporter(*flags["input"], *flags["output"])
+}
+
+// RunImporter reads a telegraf.conf and writes the equivalent agent JSON
+// config to outputPath, or to stdout when outputPath is empty.
+func RunImporter(inputPath, outputPath string) error {
+	content, err := os.ReadFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read telegraf config %s: %w", inputPath, err)
+	}
+	result, err := Import(content)
+	if err != nil {
+		return fmt.Errorf("failed to import telegraf config %s: %w", inputPath, err)
+	}
+	for _, unsupported := range result.Unsupported {
+		fmt.Fprintf(os.Stderr, "Warning: %s in file %s is not supported and was skipped.\n", unsupported, inputPath)
+	}
+	byteArray, err := json.MarshalIndent(result.Config, "", "\t")
+	if err != nil {
+		return fmt.Errorf("failed to serialize the agent config: %w", err)
+	}
+	if outputPath == "" {
+		fmt.Println(string(byteArray))
+		return nil
+	}
+	if err = os.WriteFile(outputPath, byteArray, 0644); err != nil {
+		return fmt.Errorf("failed to write the agent config to %s: %w", outputPath, err)
+	}
+	fmt.Printf("The agent config was written to %s.\n", outputPath)
+	return nil
+}
+
+// Result is the agent JSON config built from a telegraf.conf, along with
+// every setting that could not be expressed in it.
+type Result struct {
+	Config      map[string]interface{}
+	Unsupported []string
+}
+
+type importer struct {
+	metricsCollected map[string]interface{}
+	unsupported      []string
+}
+
+func (im *importer) reportUnsupported(format string, args ...interface{}) {
+	im.unsupported = append(im.unsupported, fmt.Sprintf(format, args...))
+}
+
+// Import translates the supported inputs of a telegraf.conf into
+// metrics.metrics_collected.
+func Import(content []byte) (*Result, error) {
+	var telegrafConfig map[string]interface{}
+	if _, err := toml.Decode(string(content), &telegrafConfig); err != nil {
+		return nil, err
+	}
+	im := &importer{metricsCollected: map[string]interface{}{}}
+	config := map[string]interface{}{}
+	metrics := map[string]interface{}{}
+
+	for _, section := range sortedKeys(telegrafConfig) {
+		switch section {
+		case "agent":
+			if agent := im.importAgent(asMap(telegrafConfig[section])); len(agent) > 0 {
+				config["agent"] = agent
+			}
+		case "inputs":
+			im.importInputs(asMap(telegrafConfig[section]))
+		case "outputs":
+			im.importOutputs(asMap(telegrafConfig[section]), metrics)
+		default:
+			im.reportUnsupported("section [%s]", section)
+		}
+	}
+	if len(im.metricsCollected) > 0 {
+		metrics["metrics_collected"] = im.metricsCollected
+	}
+	if len(metrics) > 0 {
+		config["metrics"] = metrics
+	}
+	return &Result{Config: config, Unsupported: im.unsupported}, nil
+}
+
+func (im *importer) importAgent(agent map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, key := range sortedKeys(agent) {
+		switch key {
+		case keyInterval:
+			if seconds, ok := im.durationSeconds("[agent]", agent[key]); ok {
+				result[keyCollection] = seconds
+			}
+		case "debug":
+			result["debug"] = agent[key]
+		case "omit_hostname":
+			result["omit_hostname"] = agent[key]
+		case "logfile":
+			result["logfile"] = agent[key]
+		case "flush_interval", "flush_jitter", "collection_jitter", "metric_batch_size", "metric_buffer_limit", "round_interval", "precision", "hostname", "quiet", "logtarget":
+			// Controlled by the agent itself.
+		default:
+			im.reportUnsupported("[agent] option %q", key)
+		}
+	}
+	return result
+}
+
+func (im *importer) importOutputs(outputs map[string]interface{}, metrics map[string]interface{}) {
+	for _, name := range sortedKeys(outputs) {
+		instances := asMapSlice(outputs[name])
+		if name != "cloudwatch" {
+			im.reportUnsupported("output plugin %q", name)
+			continue
+		}
+		for i, output := range instances {
+			if i > 0 {
+				im.reportUnsupported("additional [[outputs.cloudwatch]] instance")
+				break
+			}
+			for _, key := range sortedKeys(output) {
+				switch key {
+				case "namespace":
+					metrics["namespace"] = output[key]
+				case "region", "access_key", "secret_key", "token", "profile", "shared_credential_file", "role_arn", "endpoint_url":
+					// Credentials and region come from the agent section
+					// and the common config.
+				default:
+					im.reportUnsupported("[[outputs.cloudwatch]] option %q", key)
+				}
+			}
+		}
+	}
+}
+
+func (im *importer) importInputs(inputs map[string]interface{}) {
+	for _, name := range sortedKeys(inputs) {
+		converter, ok := inputConverters[name]
+		if !ok {
+			im.reportUnsupported("input plugin %q", name)
+			continue
+		}
+		for i, plugin := range asMapSlice(inputs[name]) {
+			label := fmt.Sprintf("[[inputs.%s]]", name)
+			if i > 0 && !converter.repeated {
+				im.reportUnsupported("additional %s instance", label)
+				break
+			}
+			result := converter.convert(im, label, plugin)
+			if result == nil {
+				continue
+			}
+			if converter.repeated {
+				existing, _ := im.metricsCollected[converter.key].([]interface{})
+				im.metricsCollected[converter.key] = append(existing, result)
+			} else {
+				im.metricsCollected[converter.key] = result
+			}
+		}
+	}
+}
+
+// durationSeconds parses a telegraf duration ("10s", "1m") into the whole
+// seconds used by metrics_collection_interval.
+func (im *importer) durationSeconds(label string, value interface{}) (int, bool) {
+	var duration time.Duration
+	switch v := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			im.reportUnsupported("%s interval %q", label, v)
+			return 0, false
+		}
+		duration = parsed
+	case int64:
+		duration = time.Duration(v) * time.Second
+	default:
+		im.reportUnsupported("%s interval %v", label, value)
+		return 0, false
+	}
+	if duration < time.Second || duration%time.Second != 0 {
+		im.reportUnsupported("%s interval %v (must be whole seconds)", label, duration)
+		return 0, false
+	}
+	return int(duration / time.Second), true
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func asMap(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+// asMapSlice accepts both [[inputs.x]] (array of tables) and [inputs.x].
+func asMapSlice(value interface{}) []map[string]interface{} {
+	switch v := value.(type) {
+	case []map[string]interface{}:
+		return v
+	case map[string]interface{}:
+		return []map[string]interface{}{v}
+	}
+	return nil
+}
+
+func asStringSlice(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, true
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, s)
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+func hasGlob(values []string) bool {
+	for _, value := range values {
+		if strings.ContainsAny(value, "*?[") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package telegrafimport
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+)
+
+func TestImport(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "telegraf.conf"))
+	require.NoError(t, err)
+	result, err := Import(content)
+	require.NoError(t, err)
+
+	actual, err := json.MarshalIndent(result.Config, "", "\t")
+	require.NoError(t, err)
+	expected, err := os.ReadFile(filepath.Join("testdata", "expected.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+
+	assert.Equal(t, []string{
+		`section [global_tags]`,
+		`[[inputs.disk]] tagpass on "fstype"`,
+		`[[inputs.disk]] fieldpass with glob patterns [used_percent inodes_*]`,
+		`input plugin "nginx"`,
+		`[[inputs.procstat]] interval 500ms (must be whole seconds)`,
+		`[[inputs.procstat]] option "user"`,
+		`[[inputs.procstat]] without exe, pattern or pid_file`,
+		`[[inputs.statsd]] option "percentiles"`,
+		`[[outputs.cloudwatch]] option "high_resolution_metrics"`,
+		`output plugin "influxdb"`,
+	}, result.Unsupported)
+}
+
+// TestImportValidatesAgainstSchema checks that the imported config is
+// accepted by the agent config schema.
+func TestImportValidatesAgainstSchema(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "telegraf.conf"))
+	require.NoError(t, err)
+	result, err := Import(content)
+	require.NoError(t, err)
+
+	validation, err := gojsonschema.Validate(
+		gojsonschema.NewStringLoader(config.GetJsonSchema()),
+		gojsonschema.NewGoLoader(result.Config),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, validation.Errors())
+}
+
+func TestImportSocketListener(t *testing.T) {
+	result, err := Import([]byte(`
+[[inputs.socket_listener]]
+  service_address = "tcp://:8094"
+  data_format = "influx"
+`))
+	require.NoError(t, err)
+	assert.Empty(t, result.Config)
+	assert.Equal(t, []string{`[[inputs.socket_listener]] data_format influx (only collectd is supported)`}, result.Unsupported)
+}
+
+func TestImportRepeatedInput(t *testing.T) {
+	result, err := Import([]byte(`
+[[inputs.mem]]
+  interval = "10s"
+[[inputs.mem]]
+  interval = "60s"
+`))
+	require.NoError(t, err)
+	mem := result.Config["metrics"].(map[string]interface{})["metrics_collected"].(map[string]interface{})["mem"].(map[string]interface{})
+	assert.Equal(t, 10, mem[keyCollection])
+	assert.Equal(t, []string{`additional [[inputs.mem]] instance`}, result.Unsupported)
+}
+
+func TestImportInvalid(t *testing.T) {
+	_, err := Import([]byte(`[[inputs.cpu]`))
+	assert.Error(t, err)
+}
+
+func TestRunImporter(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, RunImporter(filepath.Join("testdata", "telegraf.conf"), outputPath))
+
+	actual, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	expected, err := os.ReadFile(filepath.Join("testdata", "expected.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+
+	assert.Error(t, RunImporter(filepath.Join("testdata", "missing.conf"), outputPath))
+}
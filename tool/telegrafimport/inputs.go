@@ -0,0 +1,244 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package telegrafimport
+
+// inputConverter describes how the options of one telegraf input map onto
+// its section in metrics.metrics_collected.
+type inputConverter struct {
+	// key is the metrics_collected section the input is written to.
+	key string
+	// repeated inputs (procstat) are written as a list of sections.
+	repeated bool
+	// options maps telegraf option names to agent keys. An empty agent key
+	// means the option is handled by postProcess.
+	options map[string]string
+	// ignored options do not change what is collected, so they are dropped
+	// without a warning.
+	ignored map[string]bool
+	// resourceTag is the tag whose tagpass values become "resources".
+	resourceTag string
+	// measurementKey receives the fieldpass list. Inputs without one do not
+	// support field filtering.
+	measurementKey string
+	// defaultMeasurement is used when there is no fieldpass, since telegraf
+	// collects every field while the agent only collects what is listed.
+	defaultMeasurement []string
+	// noInterval inputs have no metrics_collection_interval in the agent.
+	noInterval  bool
+	postProcess func(im *importer, label string, plugin, result map[string]interface{}) bool
+}
+
+var inputConverters = map[string]inputConverter{
+	"cpu": {
+		key: "cpu",
+		options: map[string]string{
+			"percpu":           "",
+			"totalcpu":         "totalcpu",
+			"collect_cpu_time": "collect_cpu_time",
+			"report_active":    "report_active",
+		},
+		ignored:        map[string]bool{"core_tags": true},
+		measurementKey: keyMeasure,
+		defaultMeasurement: []string{
+			"usage_user", "usage_system", "usage_idle", "usage_nice", "usage_iowait",
+			"usage_irq", "usage_softirq", "usage_steal", "usage_guest", "usage_guest_nice",
+		},
+		postProcess: func(_ *importer, _ string, plugin, result map[string]interface{}) bool {
+			if perCPU, _ := plugin["percpu"].(bool); perCPU {
+				result[keyResources] = []string{"*"}
+			}
+			return true
+		},
+	},
+	"mem": {
+		key:            "mem",
+		measurementKey: keyMeasure,
+		defaultMeasurement: []string{
+			"active", "available", "available_percent", "buffered", "cached",
+			"free", "inactive", "total", "used", "used_percent",
+		},
+	},
+	"disk": {
+		key: "disk",
+		options: map[string]string{
+			"mount_points": keyResources,
+			"ignore_fs":    "ignore_file_system_types",
+		},
+		ignored:        map[string]bool{"ignore_mount_opts": true},
+		resourceTag:    "path",
+		measurementKey: keyMeasure,
+		defaultMeasurement: []string{
+			"free", "inodes_free", "inodes_total", "inodes_used", "total", "used", "used_percent",
+		},
+	},
+	"diskio": {
+		key: "diskio",
+		options: map[string]string{
+			"devices": keyResources,
+		},
+		ignored:        map[string]bool{"skip_serial_number": true},
+		resourceTag:    "name",
+		measurementKey: keyMeasure,
+		defaultMeasurement: []string{
+			"reads", "writes", "read_bytes", "write_bytes", "read_time",
+			"write_time", "io_time", "iops_in_progress",
+		},
+	},
+	"net": {
+		key: "net",
+		options: map[string]string{
+			"interfaces": keyResources,
+		},
+		ignored:        map[string]bool{"ignore_protocol_stats": true},
+		resourceTag:    "interface",
+		measurementKey: keyMeasure,
+		defaultMeasurement: []string{
+			"bytes_sent", "bytes_recv", "packets_sent", "packets_recv",
+			"err_in", "err_out", "drop_in", "drop_out",
+		},
+	},
+	"procstat": {
+		key:      "procstat",
+		repeated: true,
+		options: map[string]string{
+			"exe":        "exe",
+			"pattern":    "pattern",
+			"pid_file":   "pid_file",
+			"pid_finder": "pid_finder",
+		},
+		ignored:            map[string]bool{"pid_tag": true},
+		measurementKey:     keyMeasure,
+		defaultMeasurement: []string{"cpu_usage", "memory_rss"},
+		postProcess: func(im *importer, label string, _, result map[string]interface{}) bool {
+			if result["exe"] == nil && result["pattern"] == nil && result["pid_file"] == nil {
+				im.reportUnsupported("%s without exe, pattern or pid_file", label)
+				return false
+			}
+			return true
+		},
+	},
+	"statsd": {
+		key: "statsd",
+		options: map[string]string{
+			"service_address":          "service_address",
+			"metric_separator":         "metric_separator",
+			"allowed_pending_messages": "allowed_pending_messages",
+			"parse_data_dog_tags":      "parse_data_dog_tags",
+			"datadog_extensions":       "parse_data_dog_tags",
+		},
+		ignored: map[string]bool{"protocol": true, "max_tcp_connections": true},
+	},
+	"socket_listener": {
+		key: "collectd",
+		options: map[string]string{
+			"service_address":         "service_address",
+			"name_prefix":             "name_prefix",
+			"collectd_auth_file":      "collectd_auth_file",
+			"collectd_security_level": "collectd_security_level",
+			"collectd_typesdb":        "collectd_typesdb",
+			"data_format":             "",
+		},
+		ignored:    map[string]bool{"collectd_parse_multivalue": true},
+		noInterval: true,
+		postProcess: func(im *importer, label string, plugin, _ map[string]interface{}) bool {
+			if plugin["data_format"] != "collectd" {
+				im.reportUnsupported("%s data_format %v (only collectd is supported)", label, plugin["data_format"])
+				return false
+			}
+			return true
+		},
+	},
+	"ethtool": {
+		key: "ethtool",
+		options: map[string]string{
+			"interface_include": "interface_include",
+			"interface_exclude": "interface_exclude",
+		},
+		resourceTag:    "interface",
+		measurementKey: "metrics_include",
+		noInterval:     true,
+	},
+}
+
+func (c inputConverter) convert(im *importer, label string, plugin map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, key := range sortedKeys(plugin) {
+		if commonPluginKeys[key] {
+			continue
+		}
+		agentKey, ok := c.options[key]
+		switch {
+		case ok && agentKey != "":
+			result[agentKey] = plugin[key]
+		case ok || c.ignored[key]:
+		default:
+			im.reportUnsupported("%s option %q", label, key)
+		}
+	}
+
+	if interval, ok := plugin[keyInterval]; ok {
+		if c.noInterval {
+			im.reportUnsupported("%s option %q", label, keyInterval)
+		} else if seconds, ok := im.durationSeconds(label, interval); ok {
+			result[keyCollection] = seconds
+		}
+	}
+
+	c.convertTagPass(im, label, plugin, result)
+	c.convertFieldPass(im, label, plugin, result)
+
+	if c.postProcess != nil && !c.postProcess(im, label, plugin, result) {
+		return nil
+	}
+	return result
+}
+
+func (c inputConverter) convertTagPass(im *importer, label string, plugin, result map[string]interface{}) {
+	tagPass := asMap(plugin[keyTagPass])
+	for _, tag := range sortedKeys(tagPass) {
+		if tag != c.resourceTag {
+			im.reportUnsupported("%s tagpass on %q", label, tag)
+			continue
+		}
+		values, ok := asStringSlice(tagPass[tag])
+		if !ok {
+			im.reportUnsupported("%s tagpass %v", label, tagPass[tag])
+			continue
+		}
+		resourceKey := keyResources
+		if c.key == "ethtool" {
+			resourceKey = "interface_include"
+		}
+		if _, exists := result[resourceKey]; exists {
+			im.reportUnsupported("%s tagpass on %q combined with an explicit list", label, tag)
+			continue
+		}
+		result[resourceKey] = values
+	}
+}
+
+func (c inputConverter) convertFieldPass(im *importer, label string, plugin, result map[string]interface{}) {
+	fieldPass, hasFieldPass := plugin[keyFieldPass]
+	if c.measurementKey == "" {
+		if hasFieldPass {
+			im.reportUnsupported("%s option %q", label, keyFieldPass)
+		}
+		return
+	}
+	if hasFieldPass {
+		fields, ok := asStringSlice(fieldPass)
+		switch {
+		case !ok:
+			im.reportUnsupported("%s fieldpass %v", label, fieldPass)
+		case hasGlob(fields):
+			im.reportUnsupported("%s fieldpass with glob patterns %v", label, fields)
+		default:
+			result[c.measurementKey] = fields
+			return
+		}
+	}
+	if c.defaultMeasurement != nil {
+		result[c.measurementKey] = c.defaultMeasurement
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Answers pre-answers the wizard questions so it can run without a terminal.
+// Answers are keyed by the question text exactly as the wizard prints it. A
+// question that is asked more than once (e.g. "Log file path:") takes a list,
+// which is consumed in order.
+type Answers struct {
+	answers map[string][]string
+	used    map[string]int
+}
+
+// MissingAnswerError is raised when the wizard asks a question that has no
+// answer left in the answers file.
+type MissingAnswerError struct {
+	Question string
+}
+
+func (e *MissingAnswerError) Error() string {
+	return fmt.Sprintf("no answer for question %q", e.Question)
+}
+
+// InvalidAnswerError is raised when an answer is not one of the options of
+// the question it answers.
+type InvalidAnswerError struct {
+	Question string
+	Answer   string
+}
+
+func (e *InvalidAnswerError) Error() string {
+	return fmt.Sprintf("answer %q is not valid for question %q", e.Answer, e.Question)
+}
+
+var answers *Answers
+
+// SetAnswers makes every question read from the answers instead of stdin.
+// Passing nil restores the interactive behavior.
+func SetAnswers(a *Answers) {
+	answers = a
+}
+
+func HasAnswers() bool {
+	return answers != nil
+}
+
+func LoadAnswers(path string) (*Answers, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAnswers(content)
+}
+
+func ParseAnswers(content []byte) (*Answers, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	a := &Answers{answers: make(map[string][]string, len(raw)), used: map[string]int{}}
+	for question, value := range raw {
+		if list, ok := value.([]interface{}); ok {
+			for _, item := range list {
+				answer, err := answerToString(item)
+				if err != nil {
+					return nil, fmt.Errorf("question %q: %w", question, err)
+				}
+				a.answers[question] = append(a.answers[question], answer)
+			}
+			continue
+		}
+		answer, err := answerToString(value)
+		if err != nil {
+			return nil, fmt.Errorf("question %q: %w", question, err)
+		}
+		a.answers[question] = []string{answer}
+	}
+	return a, nil
+}
+
+func answerToString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		if v {
+			return "yes", nil
+		}
+		return "no", nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("unsupported answer %v", value)
+}
+
+func (a *Answers) next(question string) string {
+	index := a.used[question]
+	if index >= len(a.answers[question]) {
+		panic(&MissingAnswerError{Question: question})
+	}
+	a.used[question] = index + 1
+	answer := a.answers[question][index]
+	fmt.Printf("%s\n%s\n", question, answer)
+	return answer
+}
+
+// choose resolves an answer to the index of one of the valid values, either
+// by value (case insensitive) or by its 1-based option number.
+func (a *Answers) choose(question string, defaultOption int, validValues []string) int {
+	answer := a.next(question)
+	if answer == "" {
+		return defaultOption - 1
+	}
+	for i, value := range validValues {
+		if strings.EqualFold(value, answer) {
+			return i
+		}
+	}
+	if option, err := strconv.Atoi(answer); err == nil && option > 0 && option <= len(validValues) {
+		return option - 1
+	}
+	panic(&InvalidAnswerError{Question: question, Answer: answer})
+}
+
+// Unused lists the questions with answers that were never asked, which
+// usually means the answers file is out of date.
+func (a *Answers) Unused() []string {
+	var unused []string
+	for question, list := range a.answers {
+		if a.used[question] < len(list) {
+			unused = append(unused, question)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnswers(t *testing.T) {
+	a, err := ParseAnswers([]byte(`
+"Do you want to turn on StatsD daemon?": yes
+"Which port?": 8125
+"Which OS?": 2
+"Log file path:":
+  - /var/log/messages
+  - /var/log/secure
+"Log group name:": ""
+"Never asked?": true
+`))
+	require.NoError(t, err)
+	SetAnswers(a)
+	defer SetAnswers(nil)
+
+	assert.True(t, Yes("Do you want to turn on StatsD daemon?"))
+	assert.Equal(t, "8125", AskWithDefault("Which port?", "2000"))
+	assert.Equal(t, "windows", Choice("Which OS?", 1, []string{"linux", "windows"}))
+	assert.Equal(t, "/var/log/messages", Ask("Log file path:"))
+	assert.Equal(t, "/var/log/secure", Ask("Log file path:"))
+	assert.Equal(t, "messages", AskWithDefault("Log group name:", "messages"))
+	assert.Equal(t, []string{"Never asked?"}, a.Unused())
+
+	assert.PanicsWithError(t, `no answer for question "Log file path:"`, func() { Ask("Log file path:") })
+	assert.PanicsWithError(t, `no answer for question "Unknown?"`, func() { Yes("Unknown?") })
+}
+
+func TestAnswersInvalidChoice(t *testing.T) {
+	a, err := ParseAnswers([]byte(`
+"Which OS?": solaris
+"Which index?": 3
+`))
+	require.NoError(t, err)
+	SetAnswers(a)
+	defer SetAnswers(nil)
+
+	assert.PanicsWithError(t, `answer "solaris" is not valid for question "Which OS?"`, func() {
+		Choice("Which OS?", 1, []string{"linux", "windows"})
+	})
+	assert.PanicsWithError(t, `answer "3" is not valid for question "Which index?"`, func() {
+		ChoiceIndex("Which index?", 1, []string{"a", "b"})
+	})
+}
+
+func TestParseAnswersInvalid(t *testing.T) {
+	_, err := ParseAnswers([]byte(`"Question?": {nested: map}`))
+	assert.Error(t, err)
+	_, err = ParseAnswers([]byte(`: : :`))
+	assert.Error(t, err)
+}
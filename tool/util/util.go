@@ -257,6 +257,12 @@ func No(question string) bool {
 }
 
 func AskWithDefault(question, defaultValue string) string {
+	if answers != nil {
+		if answer := answers.next(question); answer != "" {
+			return answer
+		}
+		return defaultValue
+	}
 	for {
 		var answer string
 		fmt.Printf("%s\ndefault choice: [%s]\n\r", question, defaultValue)
@@ -276,6 +282,12 @@ func Ask(question string) string {
 
 // defaultOption value starts from 1
 func Choice(question string, defaultOption int, validValues []string) string {
+	if answers != nil {
+		if validValues == nil {
+			return answers.next(question)
+		}
+		return validValues[answers.choose(question, defaultOption, validValues)]
+	}
 	for {
 		var answer string
 		options := ""
@@ -310,6 +322,9 @@ func Choice(question string, defaultOption int, validValues []string) string {
 
 // ChoiceIndex returns index of choice chosen
 func ChoiceIndex(question string, defaultOption int, validValues []string) int {
+	if answers != nil {
+		return answers.choose(question, defaultOption, validValues)
+	}
 	for {
 		var answer string
 		options := ""
@@ -334,6 +349,9 @@ func ChoiceIndex(question string, defaultOption int, validValues []string) int {
 	}
 }
 func EnterToExit() {
+	if answers != nil {
+		return
+	}
 	fmt.Println("Please press Enter to exit...")
 	stdin.Scanln()
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/basicInfo"
+	"github.com/aws/amazon-cloudwatch-agent/tool/processors/migration/fluent"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/migration/linux"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/serialization"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/tracesconfig"
@@ -32,6 +33,7 @@ var MainProcessorGlobal IMainProcessor = &MainProcessorStruct{}
 type Params struct {
 	IsNonInteractiveWindowsMigration bool
 	IsNonInteractiveLinuxMigration   bool
+	IsNonInteractiveFluentMigration  bool
 	TracesOnly                       bool
 	UseParameterStore                bool
 	IsNonInteractiveXrayMigration    bool
@@ -39,6 +41,7 @@ type Params struct {
 	ConfigOutputPath                 string
 	ParameterStoreName               string
 	ParameterStoreRegion             string
+	AnswersFilePath                  string
 }
 
 func init() {
@@ -59,6 +62,9 @@ func init() {
 }
 
 func RunWizard(params Params) error {
+	if params.AnswersFilePath != "" {
+		return runWizardWithAnswers(params)
+	}
 	if params.IsNonInteractiveWindowsMigration {
 		addWindowsMigrationInputs(
 			params.ConfigFilePath,
@@ -76,6 +82,17 @@ func RunWizard(params Params) error {
 		}
 		process(ctx, config, linux.Processor, serialization.Processor)
 		return nil
+	} else if params.IsNonInteractiveFluentMigration {
+		ctx := new(runtime.Context)
+		config := new(data.Config)
+		ctx.HasExistingFluentConfig = true
+		ctx.ConfigFilePath = params.ConfigFilePath
+		ctx.ConfigOutputPath = params.ConfigOutputPath
+		if ctx.ConfigFilePath == "" {
+			ctx.ConfigFilePath = wizardflags.DefaultFilePathFluentBitConfiguration
+		}
+		process(ctx, config, fluent.Processor, serialization.Processor)
+		return nil
 	} else if params.TracesOnly {
 		ctx := new(runtime.Context)
 		config := new(data.Config)
@@ -94,6 +111,7 @@ func RunWizardFromFlags(flags map[string]*string) error {
 	params := Params{
 		IsNonInteractiveWindowsMigration: *flags["isNonInteractiveWindowsMigration"] == "true",
 		IsNonInteractiveLinuxMigration:   *flags["isNonInteractiveLinuxMigration"] == "true",
+		IsNonInteractiveFluentMigration:  *flags["isNonInteractiveFluentMigration"] == "true",
 		TracesOnly:                       *flags["tracesOnly"] == "true",
 		UseParameterStore:                *flags["useParameterStore"] == "true",
 		IsNonInteractiveXrayMigration:    *flags["nonInteractiveXrayMigration"] == "true",
@@ -101,10 +119,40 @@ func RunWizardFromFlags(flags map[string]*string) error {
 		ConfigOutputPath:                 *flags["configOutputPath"],
 		ParameterStoreName:               *flags["parameterStoreName"],
 		ParameterStoreRegion:             *flags["parameterStoreRegion"],
+		AnswersFilePath:                  *flags["answers"],
 	}
 	return RunWizard(params)
 }
 
+// runWizardWithAnswers runs the interactive flow with every question answered
+// from the answers file, failing instead of prompting on a missing answer.
+func runWizardWithAnswers(params Params) (err error) {
+	answers, err := util.LoadAnswers(params.AnswersFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load answers from %s: %w", params.AnswersFilePath, err)
+	}
+	util.SetAnswers(answers)
+	defer util.SetAnswers(nil)
+	defer func() {
+		if r := recover(); r != nil {
+			switch answerErr := r.(type) {
+			case *util.MissingAnswerError:
+				err = answerErr
+			case *util.InvalidAnswerError:
+				err = answerErr
+			default:
+				panic(r)
+			}
+		}
+	}()
+
+	startProcessing(params.ConfigOutputPath, false, false)
+	for _, question := range answers.Unused() {
+		fmt.Printf("Warning: the answer to question %q was not used.\n", question)
+	}
+	return nil
+}
+
 func addWindowsMigrationInputs(configFilePath string, parameterStoreName string, parameterStoreRegion string, useParameterStore bool) {
 	inputChan := testutil.SetUpTestInputStream()
 	if useParameterStore {
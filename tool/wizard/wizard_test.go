@@ -83,3 +83,43 @@ func TestWindowsMigration(t *testing.T) {
 	assert.True(t, windows.AreTwoConfigurationsEqual(actualConfig, expectedConfig),
 		"The generated new config is incorrect, got: '%v', want: '%v'.", actualConfig, expectedConfig)
 }
+
+func TestRunWizardWithAnswers(t *testing.T) {
+	expected, err := os.ReadFile(filepath.Join("testdata", "expected.json"))
+	assert.NoError(t, err)
+
+	// Two runs from the same answers must produce byte-identical configs.
+	for i := 0; i < 2; i++ {
+		outputPath := filepath.Join(t.TempDir(), "config.json")
+		err = RunWizard(Params{
+			AnswersFilePath:  filepath.Join("testdata", "answers.yaml"),
+			ConfigOutputPath: outputPath,
+		})
+		assert.NoError(t, err)
+
+		actual, err := os.ReadFile(outputPath)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual))
+	}
+	assert.False(t, util.HasAnswers())
+}
+
+func TestRunWizardWithMissingAnswer(t *testing.T) {
+	answersPath := filepath.Join(t.TempDir(), "answers.yaml")
+	assert.NoError(t, os.WriteFile(answersPath, []byte(`"On which OS are you planning to use the agent?": linux`), 0600))
+
+	err := RunWizard(Params{AnswersFilePath: answersPath, ConfigOutputPath: filepath.Join(t.TempDir(), "config.json")})
+	assert.EqualError(t, err, `no answer for question "Are you using EC2 or On-Premises hosts?"`)
+	assert.False(t, util.HasAnswers())
+}
+
+func TestRunWizardWithInvalidAnswer(t *testing.T) {
+	answersPath := filepath.Join(t.TempDir(), "answers.yaml")
+	assert.NoError(t, os.WriteFile(answersPath, []byte(`"On which OS are you planning to use the agent?": solaris`), 0600))
+
+	err := RunWizard(Params{AnswersFilePath: answersPath})
+	assert.EqualError(t, err, `answer "solaris" is not valid for question "On which OS are you planning to use the agent?"`)
+
+	err = RunWizard(Params{AnswersFilePath: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.Error(t, err)
+}
@@ -6,6 +6,8 @@ package toenvconfig
 import (
 	"encoding/json"
 	"log"
+	"sort"
+	"strings"
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
@@ -19,8 +21,22 @@ const (
 	debugKey          = "debug"
 	awsSdkLogLevelKey = "aws_sdk_log_level"
 	usageDataKey      = "usage_data"
+	logFormatKey      = "log_format"
+	logLevelsKey      = "component_log_levels"
 )
 
+// formatComponentLevels writes the component log levels in the
+// "id=level,id=level" form the agent parses on startup.
+func formatComponentLevels(levels map[string]interface{}) string {
+	entries := make([]string, 0, len(levels))
+	for component, level := range levels {
+		name, _ := level.(string)
+		entries = append(entries, component+"="+strings.ToLower(name))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
 func ToEnvConfig(jsonConfigValue map[string]interface{}) []byte {
 	envVars := make(map[string]string)
 
@@ -33,6 +49,12 @@ func ToEnvConfig(jsonConfigValue map[string]interface{}) []byte {
 		if isDebug, ok := agentMap[debugKey].(bool); ok && isDebug {
 			envVars[envconfig.CWAGENT_LOG_LEVEL] = "DEBUG"
 		}
+		if logFormat, ok := agentMap[logFormatKey].(string); ok {
+			envVars[envconfig.CWAgentLogFormat] = logFormat
+		}
+		if logLevels, ok := agentMap[logLevelsKey].(map[string]interface{}); ok && len(logLevels) > 0 {
+			envVars[envconfig.CWAgentComponentLogLevels] = formatComponentLevels(logLevels)
+		}
 		if awsSdkLogLevel, ok := agentMap[awsSdkLogLevelKey].(string); ok {
 			envVars[envconfig.AWS_SDK_LOG_LEVEL] = awsSdkLogLevel
 		}
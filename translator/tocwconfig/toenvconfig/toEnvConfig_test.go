@@ -116,6 +116,27 @@ func TestToEnvConfig(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "agent section with log format and component log levels",
+			input: map[string]interface{}{
+				agent.SectionKey: map[string]interface{}{
+					logFormatKey: "json",
+					logLevelsKey: map[string]interface{}{
+						"inputs.cpu": "DEBUG",
+						"awsemf":     "warn",
+					},
+				},
+			},
+			envVars: map[string]string{},
+			expectedEnv: map[string]string{
+				envconfig.CWAgentLogFormat:          "json",
+				envconfig.CWAgentComponentLogLevels: "awsemf=warn,inputs.cpu=debug",
+			},
+			contextSetup: func() {
+				context.CurrentContext().SetProxy(map[string]string{})
+				context.CurrentContext().SetSSL(map[string]string{})
+			},
+		},
 		{
 			name: "invalid dual-stack type string",
 			input: map[string]interface{}{
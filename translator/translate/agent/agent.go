@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
 	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
@@ -36,6 +37,7 @@ type Agent struct {
 	ServiceName           string
 	DeploymentEnvironment string
 	UseDualStackEndpoint  bool
+	HostMetadata          *hostmetadata.Config
 }
 
 var (
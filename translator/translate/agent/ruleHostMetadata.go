@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const HostMetadataKey = "host_metadata"
+
+type HostMetadata struct {
+}
+
+// ApplyRule sets the global host metadata config. The host metadata is used in
+// place of the EC2 metadata, it is not part of the agent section of the TOML.
+func (h *HostMetadata) ApplyRule(input interface{}) (string, interface{}) {
+	Global_Config.HostMetadata = nil
+	agentMap, ok := input.(map[string]interface{})
+	if !ok {
+		return "", translator.ErrorMessages
+	}
+	value, exists := agentMap[HostMetadataKey]
+	if !exists {
+		return "", nil
+	}
+	hostMetadataMap, ok := value.(map[string]interface{})
+	if !ok {
+		return "", translator.ErrorMessages
+	}
+
+	cfg := &hostmetadata.Config{}
+	if file, ok := hostMetadataMap["file"].(string); ok {
+		cfg.File = file
+	}
+	if command, ok := hostMetadataMap["command"].([]interface{}); ok {
+		for _, arg := range command {
+			if s, ok := arg.(string); ok {
+				cfg.Command = append(cfg.Command, s)
+			}
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		translator.AddErrorMessages(GetCurPath()+HostMetadataKey, err.Error())
+		return "", nil
+	}
+	Global_Config.HostMetadata = cfg
+	return "", nil
+}
+
+func init() {
+	RegisterRule(HostMetadataKey, new(HostMetadata))
+}
@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestHostMetadata_ApplyRule(t *testing.T) {
+	defer func() {
+		Global_Config.HostMetadata = nil
+		translator.ResetMessages()
+	}()
+
+	testCases := map[string]struct {
+		input      interface{}
+		wantConfig *hostmetadata.Config
+		wantErrors bool
+	}{
+		"File": {
+			input:      map[string]interface{}{HostMetadataKey: map[string]interface{}{"file": "/etc/host-metadata.yaml"}},
+			wantConfig: &hostmetadata.Config{File: "/etc/host-metadata.yaml"},
+		},
+		"Command": {
+			input:      map[string]interface{}{HostMetadataKey: map[string]interface{}{"command": []interface{}{"/usr/local/bin/cmdb-lookup", "--json"}}},
+			wantConfig: &hostmetadata.Config{Command: []string{"/usr/local/bin/cmdb-lookup", "--json"}},
+		},
+		"Missing": {
+			input: map[string]interface{}{},
+		},
+		"NoSource": {
+			input:      map[string]interface{}{HostMetadataKey: map[string]interface{}{}},
+			wantErrors: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			Global_Config.HostMetadata = &hostmetadata.Config{File: "previous"}
+			key, _ := new(HostMetadata).ApplyRule(testCase.input)
+			assert.Empty(t, key)
+			assert.Equal(t, testCase.wantConfig, Global_Config.HostMetadata)
+			assert.Equal(t, testCase.wantErrors, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"kernel_events": {
+//	    "log_group_name": "kernel-events",
+//	    "log_stream_name": "{instance_id}",
+//	    "retention_in_days": 30
+//	}
+const (
+	SectionKey       = "kernel_events"
+	SectionMappedKey = "kernel_event_log"
+)
+
+func GetCurPath() string {
+	return parent.GetCurPath() + SectionKey + "/"
+}
+
+func RegisterRule(ruleName string, r translator.Rule) {
+	ChildRule[ruleName] = r
+}
+
+type KernelEvents struct {
+}
+
+func (k *KernelEvents) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	if _, ok := im[SectionKey]; !ok {
+		return "", ""
+	}
+	kernelEventConfig := map[string]interface{}{
+		"destination": "cloudwatchlogs",
+	}
+	for _, rule := range ChildRule {
+		key, val := rule.ApplyRule(im[SectionKey])
+		if key != "" {
+			kernelEventConfig[key] = val
+		}
+	}
+	if _, ok := kernelEventConfig[LogGroupNameKey]; !ok {
+		translator.AddErrorMessages(GetCurPath(), "log_group_name must be set")
+		return "", ""
+	}
+	return "inputs", map[string]interface{}{
+		SectionMappedKey: []interface{}{kernelEventConfig},
+	}
+}
+
+var MergeRuleMap = map[string]mergeJsonRule.MergeRule{}
+
+func (k *KernelEvents) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeMap(source, result, SectionKey, MergeRuleMap, GetCurPath())
+}
+
+func init() {
+	obj := new(KernelEvents)
+	parent.RegisterLinuxRule(SectionKey, obj)
+	parent.MergeRuleMap[SectionKey] = obj
+}
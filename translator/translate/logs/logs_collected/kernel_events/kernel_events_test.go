@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestApplyRule(t *testing.T) {
+	k := new(KernelEvents)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"kernel_events": {
+		"log_group_name": "kernel-events",
+		"log_stream_name": "host-1",
+		"retention_in_days": 30,
+		"log_group_class": "infrequent_access",
+		"kmsg_path": "/rootfs/dev/kmsg"
+	}}`), &input))
+	actualKey, actualVal := k.ApplyRule(input)
+	assert.Equal(t, "inputs", actualKey)
+	assert.Equal(t, map[string]interface{}{
+		"kernel_event_log": []interface{}{
+			map[string]interface{}{
+				"destination":       "cloudwatchlogs",
+				"log_group_name":    "kernel-events",
+				"log_stream_name":   "host-1",
+				"retention_in_days": 30,
+				"log_group_class":   "INFREQUENT_ACCESS",
+				"kmsg_path":         "/rootfs/dev/kmsg",
+			},
+		},
+	}, actualVal)
+}
+
+func TestApplyRuleDefaults(t *testing.T) {
+	k := new(KernelEvents)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"kernel_events": {"log_group_name": "kernel-events"}}`), &input))
+	_, actualVal := k.ApplyRule(input)
+	assert.Equal(t, map[string]interface{}{
+		"kernel_event_log": []interface{}{
+			map[string]interface{}{
+				"destination":       "cloudwatchlogs",
+				"log_group_name":    "kernel-events",
+				"retention_in_days": -1,
+				"log_group_class":   "",
+			},
+		},
+	}, actualVal)
+}
+
+func TestApplyRuleWithoutLogGroup(t *testing.T) {
+	translator.ResetMessages()
+	k := new(KernelEvents)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"kernel_events": {}}`), &input))
+	actualKey, _ := k.ApplyRule(input)
+	assert.Equal(t, "", actualKey)
+	assert.Len(t, translator.ErrorMessages, 1)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"files": {}}`), &input))
+	actualKey, _ = k.ApplyRule(input)
+	assert.Equal(t, "", actualKey)
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+type KmsgPath struct{}
+
+const KmsgPathKey = "kmsg_path"
+
+func (r *KmsgPath) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[KmsgPathKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		returnKey = KmsgPathKey
+		returnVal = m[KmsgPathKey]
+	}
+	return
+}
+
+func init() {
+	r := new(KmsgPath)
+	RegisterRule(KmsgPathKey, r)
+}
@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const LogGroupClassKey = "log_group_class"
+
+type LogGroupClass struct {
+}
+
+func (l *LogGroupClass) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, returnVal = translator.DefaultLogGroupClassCase(LogGroupClassKey, "", input)
+	returnKey = LogGroupClassKey
+	return
+}
+
+func init() {
+	l := new(LogGroupClass)
+	RegisterRule(LogGroupClassKey, l)
+}
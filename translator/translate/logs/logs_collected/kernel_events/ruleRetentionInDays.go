@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const RetentionInDaysKey = "retention_in_days"
+
+type RetentionInDays struct {
+}
+
+func (r *RetentionInDays) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, returnVal = translator.DefaultRetentionInDaysCase(RetentionInDaysKey, float64(-1), input)
+	returnKey = RetentionInDaysKey
+	return
+}
+
+func init() {
+	r := new(RetentionInDays)
+	RegisterRule(RetentionInDaysKey, r)
+}
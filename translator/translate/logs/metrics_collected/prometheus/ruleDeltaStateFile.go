@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+const (
+	SectionKeyDeltaStateFile = "delta_state_file"
+)
+
+type DeltaStateFile struct {
+}
+
+// ApplyRule sets the file the counter values are saved to. The values are
+// not saved if it is not set.
+func (d *DeltaStateFile) ApplyRule(input interface{}) (string, interface{}) {
+	if stateFile, ok := input.(map[string]interface{})[SectionKeyDeltaStateFile].(string); ok && stateFile != "" {
+		return SectionKeyDeltaStateFile, stateFile
+	}
+	return "", nil
+}
+
+func init() {
+	RegisterRule(SectionKeyDeltaStateFile, new(DeltaStateFile))
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDeltaStateFileRule(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+	}{
+		"WithStateFile": {
+			input:     `{"delta_state_file": "/opt/aws/amazon-cloudwatch-agent/var/prometheus-delta.json"}`,
+			wantKey:   SectionKeyDeltaStateFile,
+			wantValue: "/opt/aws/amazon-cloudwatch-agent/var/prometheus-delta.json",
+		},
+		"WithoutStateFile": {
+			input: `{"cluster_name": "TestCluster"}`,
+		},
+		"WithEmptyStateFile": {
+			input: `{"delta_state_file": ""}`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(DeltaStateFile).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+		})
+	}
+}
+
+func TestApplyRuleWithDeltaStateFile(t *testing.T) {
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"prometheus": {"delta_state_file": "/tmp/prometheus-delta.json"}}`), &input))
+	key, value := new(Prometheus).ApplyRule(input)
+	assert.Equal(t, SectionKey, key)
+	inputs := value.(map[string]map[string]interface{})["inputs"][SectionKey].([]interface{})
+	require.Len(t, inputs, 1)
+	assert.Equal(t, "/tmp/prometheus-delta.json", inputs[0].(map[string]interface{})[SectionKeyDeltaStateFile])
+}
@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+const (
+	SectionKeyUseCreatedTimestamps = "use_created_timestamps"
+)
+
+type UseCreatedTimestamps struct {
+}
+
+// ApplyRule enables the detection of the counter resets by the created
+// timestamps of the OpenMetrics targets. It is disabled if not set.
+func (u *UseCreatedTimestamps) ApplyRule(input interface{}) (string, interface{}) {
+	if enabled, ok := input.(map[string]interface{})[SectionKeyUseCreatedTimestamps].(bool); ok && enabled {
+		return SectionKeyUseCreatedTimestamps, true
+	}
+	return "", nil
+}
+
+func init() {
+	RegisterRule(SectionKeyUseCreatedTimestamps, new(UseCreatedTimestamps))
+}
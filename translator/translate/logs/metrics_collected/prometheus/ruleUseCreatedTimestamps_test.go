@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyUseCreatedTimestampsRule(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+	}{
+		"WithEnabled": {
+			input:     `{"use_created_timestamps": true}`,
+			wantKey:   SectionKeyUseCreatedTimestamps,
+			wantValue: true,
+		},
+		"WithDisabled": {
+			input: `{"use_created_timestamps": false}`,
+		},
+		"WithoutKey": {
+			input: `{"cluster_name": "TestCluster"}`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(UseCreatedTimestamps).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+		})
+	}
+}
+
+func TestApplyRuleWithUseCreatedTimestamps(t *testing.T) {
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"prometheus": {"use_created_timestamps": true}}`), &input))
+	key, value := new(Prometheus).ApplyRule(input)
+	assert.Equal(t, SectionKey, key)
+	inputs := value.(map[string]map[string]interface{})["inputs"][SectionKey].([]interface{})
+	require.Len(t, inputs, 1)
+	promInput := inputs[0].(map[string]interface{})
+	assert.Equal(t, true, promInput[SectionKeyUseCreatedTimestamps])
+	// the created timestamps do not need the delta state file
+	assert.NotContains(t, promInput, SectionKeyDeltaStateFile)
+}
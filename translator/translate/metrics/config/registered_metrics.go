@@ -8,7 +8,7 @@ package config
 var Registered_Metrics_Linux = map[string][]string{
 	"cpu": {"time_active", "time_guest", "time_guest_nice", "time_idle", "time_iowait", "time_irq", "time_nice", "time_softirq", "time_steal", "time_system", "time_user",
 		"usage_active", "usage_guest", "usage_guest_nice", "usage_idle", "usage_iowait", "usage_irq", "usage_nice", "usage_softirq", "usage_steal", "usage_system", "usage_user"},
-	"disk": {"free", "inodes_free", "inodes_total", "inodes_used", "total", "used", "used_percent"},
+	"disk": {"free", "inodes_free", "inodes_total", "inodes_used", "inodes_used_percent", "total", "used", "used_percent"},
 	"diskio": {"iops_in_progress", "io_time", "reads", "read_bytes", "read_time", "writes", "write_bytes", "write_time", "ebs_total_read_ops", "ebs_total_write_ops",
 		"ebs_total_read_bytes", "ebs_total_write_bytes", "ebs_total_read_time", "ebs_total_write_time", "ebs_volume_performance_exceeded_iops",
 		"ebs_volume_performance_exceeded_tp", "ebs_ec2_instance_performance_exceeded_iops", "ebs_ec2_instance_performance_exceeded_tp", "ebs_volume_queue_length",
@@ -25,6 +25,19 @@ var Registered_Metrics_Linux = map[string][]string{
 		"read_bytes", "read_count", "realtime_priority", "rlimit_cpu_time_hard", "rlimit_cpu_time_soft", "rlimit_file_locks_hard", "rlimit_file_locks_soft", "rlimit_memory_data_hard", "rlimit_memory_data_soft", "rlimit_memory_locked_hard", "rlimit_memory_locked_soft",
 		"rlimit_memory_rss_hard", "rlimit_memory_rss_soft", "rlimit_memory_stack_hard", "rlimit_memory_stack_soft", "rlimit_memory_vms_hard", "rlimit_memory_vms_soft", "rlimit_nice_priority_hard", "rlimit_nice_priority_soft", "rlimit_num_fds_hard", "rlimit_num_fds_soft",
 		"rlimit_realtime_priority_hard", "rlimit_realtime_priority_soft", "rlimit_signals_pending_hard", "rlimit_signals_pending_soft", "signals_pending", "voluntary_context_switches", "write_bytes", "write_count", "pid_count"},
+	"connections":   {"established", "syn_recv", "fin_wait1", "fin_wait2", "time_wait", "close_wait", "last_ack", "listen", "closing", "conntrack_count", "conntrack_max", "conntrack_used_percent"},
+	"file_handles":  {"allocated", "unused", "used", "max", "used_percent"},
+	"edac":          {"ce_count", "ue_count", "ce_noinfo_count", "ue_noinfo_count"},
+	"kernel_events": {"oom_kill", "hung_task", "mce"},
+	"nfs":           {"ops", "retransmits", "timeouts", "bytes_sent", "bytes_recv", "rtt", "execute_time", "queue_time", "read_ops", "read_rtt", "write_ops", "write_rtt"},
+	"pressure": {"cpu_some_avg10", "cpu_some_avg60", "cpu_some_avg300", "cpu_some_total", "cpu_full_avg10", "cpu_full_avg60", "cpu_full_avg300", "cpu_full_total",
+		"memory_some_avg10", "memory_some_avg60", "memory_some_avg300", "memory_some_total", "memory_full_avg10", "memory_full_avg60", "memory_full_avg300", "memory_full_total",
+		"io_some_avg10", "io_some_avg60", "io_some_avg300", "io_some_total", "io_full_avg10", "io_full_avg60", "io_full_avg300", "io_full_total",
+		"cpu_usage_usec", "cpu_user_usec", "cpu_system_usec", "cpu_nr_periods", "cpu_nr_throttled", "cpu_throttled_usec",
+		"memory_events_low", "memory_events_high", "memory_events_max", "memory_events_oom", "memory_events_oom_kill",
+		"io_rbytes", "io_wbytes", "io_rios", "io_wios", "io_dbytes", "io_dios"},
+	"probes":        {"up", "response_time", "status_code", "cert_expiry_days"},
+	"systemd_units": {"active", "load_state", "active_state", "sub_state", "restarts", "memory_current", "cpu_usage_nsec", "tasks_current"},
 	"nvidia_smi": {"utilization_gpu", "temperature_gpu", "power_draw", "utilization_memory", "fan_speed", "memory_total", "memory_used", "memory_free", "temperature_gpu", "pcie_link_gen_current", "pcie_link_width_current",
 		"encoder_stats_session_count", "encoder_stats_average_fps", "encoder_stats_average_latency", "clocks_current_graphics", "clocks_current_sm", "clocks_current_memory", "clocks_current_video"},
 }
@@ -34,7 +47,7 @@ var Registered_Metrics_Linux = map[string][]string{
 var Registered_Metrics_Darwin = map[string][]string{
 	"cpu": {"time_active", "time_guest", "time_guest_nice", "time_idle", "time_iowait", "time_irq", "time_nice", "time_softirq", "time_steal", "time_system", "time_user",
 		"usage_active", "usage_guest", "usage_guest_nice", "usage_idle", "usage_iowait", "usage_irq", "usage_nice", "usage_softirq", "usage_steal", "usage_system", "usage_user"},
-	"disk":      {"free", "inodes_free", "inodes_total", "inodes_used", "total", "used", "used_percent"},
+	"disk":      {"free", "inodes_free", "inodes_total", "inodes_used", "inodes_used_percent", "total", "used", "used_percent"},
 	"diskio":    {"iops_in_progress", "io_time", "reads", "read_bytes", "read_time", "writes", "write_bytes", "write_time"},
 	"swap":      {"free", "used", "used_percent"},
 	"mem":       {"active", "available", "available_percent", "buffered", "cached", "free", "inactive", "total", "used", "used_percent"},
@@ -44,6 +57,7 @@ var Registered_Metrics_Darwin = map[string][]string{
 	"procstat": {"cpu_time_system", "cpu_time_user", "cpu_usage",
 		"memory_data", "memory_locked", "memory_rss", "memory_stack", "memory_swap", "memory_vms", "pid",
 		"pid_count"},
+	"probes": {"up", "response_time", "status_code", "cert_expiry_days"},
 	"nvidia_smi": {"utilization_gpu", "temperature_gpu", "power_draw", "utilization_memory", "utilization_encoder", "utilization_decoder", "fan_speed", "memory_total", "memory_used", "memory_free", "temperature_gpu", "pcie_link_gen_current", "pcie_link_width_current",
 		"encoder_stats_session_count", "encoder_stats_average_fps", "encoder_stats_average_latency", "clocks_current_graphics", "clocks_current_sm", "clocks_current_memory", "clocks_current_video"},
 }
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package connections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithoutMeasurement(t *testing.T) {
+	c := new(Connections)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"connections":{"ports": [80]}}`), &input))
+	actualReturnKey, _ := c.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+}
+
+func TestConntrackOnly(t *testing.T) {
+	c := new(Connections)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"connections":{"measurement": ["connections_conntrack_count", "conntrack_used_percent"]}}`), &input))
+	actualReturnKey, actualVal := c.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"fieldpass": []string{"conntrack_count", "conntrack_used_percent"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
+
+func TestFullConfig(t *testing.T) {
+	c := new(Connections)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"connections":{
+		"ports": [80, 443],
+		"procfs_root": "/rootfs/proc",
+		"measurement": ["established", "time_wait", "conntrack_used_percent"],
+		"metrics_collection_interval": 300
+	}}`), &input))
+	_, actualVal := c.ApplyRule(input)
+	expectedVal := []interface{}{map[string]interface{}{
+		"ports":       []int{80, 443},
+		"procfs_root": "/rootfs/proc",
+		"fieldpass":   []string{"established", "time_wait", "conntrack_used_percent"},
+		"interval":    "300s",
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package connections
+
+type Ports struct{}
+
+const PortsKey = "ports"
+
+func (r *Ports) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	values, ok := m[PortsKey].([]interface{})
+	if !ok {
+		return "", ""
+	}
+	// By default json unmarshal will store number as float64
+	ports := make([]int, 0, len(values))
+	for _, value := range values {
+		if port, ok := value.(float64); ok {
+			ports = append(ports, int(port))
+		}
+	}
+	return PortsKey, ports
+}
+
+func init() {
+	r := new(Ports)
+	RegisterRule(PortsKey, r)
+}
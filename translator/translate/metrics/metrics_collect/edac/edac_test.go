@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package edac
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEDAC(t *testing.T) {
+	e := new(EDAC)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"edac":{"sysfs_root": "/rootfs/sys", "measurement": ["edac_ce_count", "ue_count"]}}`), &input))
+	actualReturnKey, actualVal := e.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"sysfs_root": "/rootfs/sys",
+		"fieldpass":  []string{"ce_count", "ue_count"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"edac":{"measurement": ["unknown"]}}`), &input))
+	actualReturnKey, _ = e.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package edac
+
+type SysfsRoot struct{}
+
+const SysfsRootKey = "sysfs_root"
+
+func (r *SysfsRoot) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[SysfsRootKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		returnKey = SysfsRootKey
+		returnVal = m[SysfsRootKey]
+	}
+	return
+}
+
+func init() {
+	r := new(SysfsRoot)
+	RegisterRule(SysfsRootKey, r)
+}
@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"exec": [
+//	    {
+//	        "command": ["/usr/local/bin/queue-depth.sh"],
+//	        "data_format": "influx",
+//	        "timeout": 30,
+//	        "run_as_user": "nobody",
+//	        "metrics_collection_interval": 60
+//	    }
+//	]
+//
+// Every command becomes its own input, so that it can have its own interval.
+const SectionKey = "exec"
+
+func GetCurPath() string {
+	curPath := parent.GetCurPath() + SectionKey + "/"
+	return curPath
+}
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+// CommandName returns the name identifying a command, which is hashed into
+// the alias of its input.
+func CommandName(command []interface{}) string {
+	args := make([]string, 0, len(command))
+	for _, arg := range command {
+		args = append(args, fmt.Sprint(arg))
+	}
+	return strings.Join(args, " ")
+}
+
+type Exec struct {
+}
+
+func (e *Exec) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	returnKey = ""
+	returnVal = ""
+	if _, ok := im[SectionKey]; !ok {
+		return
+	}
+
+	resArray := []interface{}{}
+	aliases := map[string]bool{}
+	configArray := im[SectionKey].([]interface{})
+	for i, commandConfig := range configArray {
+		commandMap := commandConfig.(map[string]interface{})
+		command, ok := commandMap[CommandKey].([]interface{})
+		if !ok || len(command) == 0 {
+			translator.AddErrorMessages(fmt.Sprintf("%s%d", GetCurPath(), i), "command must be set")
+			continue
+		}
+		// Each command has its own input, identified by the alias.
+		alias := hash.HashName(CommandName(command))
+		if aliases[alias] {
+			translator.AddErrorMessages(fmt.Sprintf("%s%d", GetCurPath(), i), fmt.Sprintf("command %q is configured more than once", CommandName(command)))
+			continue
+		}
+		aliases[alias] = true
+
+		result := map[string]interface{}{}
+		result = translator.ProcessRuleToApply(commandMap, ChildRule, result)
+		util.ProcessIntervalAndDimensions(commandMap, SectionKey, result)
+		result[util.Alias_Key] = alias
+		resArray = append(resArray, result)
+	}
+	if len(resArray) == 0 {
+		return
+	}
+
+	returnKey = SectionKey
+	returnVal = resArray
+	return
+}
+
+var MergeRuleMap = map[string]mergeJsonRule.MergeRule{}
+
+func (e *Exec) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeList(source, result, SectionKey)
+}
+
+func init() {
+	e := new(Exec)
+	parent.RegisterLinuxRule(SectionKey, e)
+	parent.RegisterDarwinRule(SectionKey, e)
+	parent.MergeRuleMap[SectionKey] = e
+}
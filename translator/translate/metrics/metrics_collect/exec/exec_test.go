@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestWithoutCommand(t *testing.T) {
+	translator.ResetMessages()
+	e := new(Exec)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"exec":[{"data_format": "json"}]}`), &input))
+	actualReturnKey, _ := e.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey)
+	assert.False(t, translator.IsTranslateSuccess())
+}
+
+func TestDuplicateCommand(t *testing.T) {
+	translator.ResetMessages()
+	e := new(Exec)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"exec":[
+		{"command": ["/bin/check.sh", "--all"]},
+		{"command": ["/bin/check.sh", "--all"], "data_format": "json"}
+	]}`), &input))
+	actualReturnKey, actualVal := e.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	assert.Len(t, actualVal, 1)
+	assert.False(t, translator.IsTranslateSuccess())
+}
+
+func TestCommands(t *testing.T) {
+	translator.ResetMessages()
+	e := new(Exec)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"exec":[
+		{"command": ["/usr/local/bin/queue-depth.sh"]},
+		{
+			"command": ["/usr/local/bin/check.sh", "--json"],
+			"data_format": "json",
+			"tag_keys": ["queue"],
+			"timeout": 10,
+			"run_as_user": "nobody",
+			"metrics_collection_interval": 300,
+			"append_dimensions": {"team": "batch"}
+		}
+	]}`), &input))
+	actualReturnKey, actualVal := e.ApplyRule(input)
+	assert.True(t, translator.IsTranslateSuccess())
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{
+		map[string]interface{}{
+			"command":     []interface{}{"/usr/local/bin/queue-depth.sh"},
+			"data_format": "influx",
+			"alias":       hash.HashName("/usr/local/bin/queue-depth.sh"),
+		},
+		map[string]interface{}{
+			"command":     []interface{}{"/usr/local/bin/check.sh", "--json"},
+			"data_format": "json",
+			"tag_keys":    []interface{}{"queue"},
+			"timeout":     "10s",
+			"run_as_user": "nobody",
+			"interval":    "300s",
+			"tags":        map[string]interface{}{"team": "batch"},
+			"alias":       hash.HashName("/usr/local/bin/check.sh --json"),
+		},
+	}
+	assert.Equal(t, expectedVal, actualVal)
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+type Command struct{}
+
+const CommandKey = "command"
+
+func (c *Command) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[CommandKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		returnKey = CommandKey
+		returnVal = m[CommandKey]
+	}
+	return
+}
+
+func init() {
+	c := new(Command)
+	RegisterRule(CommandKey, c)
+}
@@ -0,0 +1,22 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type DataFormat struct{}
+
+const DataFormatKey = "data_format"
+
+func (d *DataFormat) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(DataFormatKey, "influx", input)
+	return
+}
+
+func init() {
+	d := new(DataFormat)
+	RegisterRule(DataFormatKey, d)
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+type RunAsUser struct{}
+
+const RunAsUserKey = "run_as_user"
+
+func (r *RunAsUser) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[RunAsUserKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		returnKey = RunAsUserKey
+		returnVal = m[RunAsUserKey]
+	}
+	return
+}
+
+func init() {
+	r := new(RunAsUser)
+	RegisterRule(RunAsUserKey, r)
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+type TagKeys struct{}
+
+const TagKeysKey = "tag_keys"
+
+func (t *TagKeys) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[TagKeysKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		returnKey = TagKeysKey
+		returnVal = m[TagKeysKey]
+	}
+	return
+}
+
+func init() {
+	t := new(TagKeys)
+	RegisterRule(TagKeysKey, t)
+}
@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"fmt"
+)
+
+type Timeout struct{}
+
+const TimeoutKey = "timeout"
+
+func (t *Timeout) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	// By default json unmarshal will store number as float64
+	if seconds, ok := m[TimeoutKey].(float64); ok && seconds > 0 {
+		return TimeoutKey, fmt.Sprintf("%ds", int(seconds))
+	}
+	return "", ""
+}
+
+func init() {
+	t := new(Timeout)
+	RegisterRule(TimeoutKey, t)
+}
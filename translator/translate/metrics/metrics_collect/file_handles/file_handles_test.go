@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package file_handles
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileHandles(t *testing.T) {
+	f := new(FileHandles)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"file_handles":{"procfs_root": "/rootfs/proc", "measurement": ["file_handles_used_percent", "max"]}}`), &input))
+	actualReturnKey, actualVal := f.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"procfs_root": "/rootfs/proc",
+		"fieldpass":   []string{"used_percent", "max"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"file_handles":{"measurement": ["unknown"]}}`), &input))
+	actualReturnKey, _ = f.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+}
@@ -0,0 +1,34 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKernelEvents(t *testing.T) {
+	k := new(KernelEvents)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"kernel_events":{
+		"kmsg_path": "/rootfs/dev/kmsg",
+		"measurement": ["kernel_events_oom_kill", "hung_task", "mce"],
+		"metrics_collection_interval": 60
+	}}`), &input))
+	actualReturnKey, actualVal := k.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"kmsg_path": "/rootfs/dev/kmsg",
+		"fieldpass": []string{"oom_kill", "hung_task", "mce"},
+		"interval":  "60s",
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"kernel_events":{"kmsg_path": "/dev/kmsg"}}`), &input))
+	actualReturnKey, _ = k.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+}
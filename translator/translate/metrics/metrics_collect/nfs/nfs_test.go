@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nfs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithoutMeasurement(t *testing.T) {
+	n := new(NFS)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"nfs":{"resources": ["*"]}}`), &input))
+	actualReturnKey, _ := n.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+}
+
+func TestAllMountPoints(t *testing.T) {
+	n := new(NFS)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"nfs":{"resources": ["*"], "measurement": ["nfs_rtt", "retransmits"]}}`), &input))
+	actualReturnKey, actualVal := n.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"fieldpass": []string{"rtt", "retransmits"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
+
+func TestFullConfig(t *testing.T) {
+	n := new(NFS)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"nfs":{
+		"resources": ["/mnt/efs"],
+		"procfs_root": "/rootfs/proc",
+		"measurement": ["ops", "read_rtt", "write_rtt"],
+		"metrics_collection_interval": 300
+	}}`), &input))
+	_, actualVal := n.ApplyRule(input)
+	expectedVal := []interface{}{map[string]interface{}{
+		"mount_points": []interface{}{"/mnt/efs"},
+		"procfs_root":  "/rootfs/proc",
+		"fieldpass":    []string{"ops", "read_rtt", "write_rtt"},
+		"interval":     "300s",
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithoutMeasurement(t *testing.T) {
+	p := new(Pressure)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"pressure":{"cgroups": ["system.slice/*"]}}`), &input))
+	actualReturnKey, _ := p.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+}
+
+func TestDefaultConfig(t *testing.T) {
+	p := new(Pressure)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"pressure":{"measurement": ["pressure_cpu_some_avg10", "io_full_avg60"]}}`), &input))
+	actualReturnKey, actualVal := p.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"fieldpass": []string{"cpu_some_avg10", "io_full_avg60"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
+
+func TestFullConfig(t *testing.T) {
+	p := new(Pressure)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"pressure":{
+		"cgroups": ["system.slice/*.service"],
+		"procfs_root": "/rootfs/proc",
+		"sysfs_root": "/rootfs/sys",
+		"measurement": ["memory_events_oom_kill", "cpu_throttled_usec", "io_rbytes"],
+		"metrics_collection_interval": 120
+	}}`), &input))
+	_, actualVal := p.ApplyRule(input)
+	expectedVal := []interface{}{map[string]interface{}{
+		"cgroups":     []interface{}{"system.slice/*.service"},
+		"procfs_root": "/rootfs/proc",
+		"sysfs_root":  "/rootfs/sys",
+		"fieldpass":   []string{"memory_events_oom_kill", "cpu_throttled_usec", "io_rbytes"},
+		"interval":    "120s",
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+type Cgroups struct{}
+
+const CgroupsKey = "cgroups"
+
+func (r *Cgroups) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[CgroupsKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		returnKey = CgroupsKey
+		returnVal = m[CgroupsKey]
+	}
+	return
+}
+
+func init() {
+	r := new(Cgroups)
+	RegisterRule(CgroupsKey, r)
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+type ProcfsRoot struct{}
+
+const ProcfsRootKey = "procfs_root"
+
+func (r *ProcfsRoot) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[ProcfsRootKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		returnKey = ProcfsRootKey
+		returnVal = m[ProcfsRootKey]
+	}
+	return
+}
+
+func init() {
+	r := new(ProcfsRoot)
+	RegisterRule(ProcfsRootKey, r)
+}
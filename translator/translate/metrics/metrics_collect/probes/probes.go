@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package probes
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"probes": {
+//	    "timeout": 5,
+//	    "http": [
+//	        {
+//	            "url": "https://localhost:8443/health",
+//	            "expected_status_codes": [200]
+//	        }
+//	    ],
+//	    "tcp": [
+//	        {
+//	            "address": "localhost:5432"
+//	        }
+//	    ],
+//	    "measurement": [
+//	        "up",
+//	        "response_time",
+//	        "cert_expiry_days"
+//	    ]
+//	}
+const SectionKey = "probes"
+
+func GetCurPath() string {
+	curPath := parent.GetCurPath() + SectionKey + "/"
+	return curPath
+}
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type Probes struct {
+}
+
+func (p *Probes) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	res := []interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+		return
+	}
+	inputMap := m[SectionKey].(map[string]interface{})
+	_, hasHTTP := inputMap[HTTPKey]
+	_, hasTCP := inputMap[TCPKey]
+	if !hasHTTP && !hasTCP {
+		translator.AddErrorMessages(GetCurPath(), "at least one http or tcp probe must be set")
+		returnKey = ""
+		return
+	}
+
+	//Check if there are some config entry with rules applied
+	result = translator.ProcessRuleToApply(inputMap, ChildRule, result)
+
+	//Process common config, like measurement
+	hasValidMetric := util.ProcessLinuxCommonConfig(inputMap, SectionKey, GetCurPath(), result)
+	if hasValidMetric {
+		res = append(res, result)
+		returnKey = SectionKey
+		returnVal = res
+	} else {
+		returnKey = ""
+	}
+	return
+}
+
+// toDuration converts a number of seconds, which json unmarshal stores as
+// float64, to a duration string.
+func toDuration(value interface{}) (string, bool) {
+	seconds, ok := value.(float64)
+	if !ok || seconds <= 0 {
+		return "", false
+	}
+	return fmt.Sprintf("%ds", int(seconds)), true
+}
+
+func init() {
+	p := new(Probes)
+	parent.RegisterLinuxRule(SectionKey, p)
+	parent.RegisterDarwinRule(SectionKey, p)
+	parent.RegisterWindowsRule(SectionKey, p)
+}
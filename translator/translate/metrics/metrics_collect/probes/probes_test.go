@@ -0,0 +1,98 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package probes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestWithoutProbes(t *testing.T) {
+	translator.ResetMessages()
+	p := new(Probes)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"probes":{"measurement": ["up"]}}`), &input))
+	actualReturnKey, _ := p.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey)
+	assert.False(t, translator.IsTranslateSuccess())
+}
+
+func TestWithoutMeasurement(t *testing.T) {
+	p := new(Probes)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"probes":{"tcp": [{"address": "localhost:5432"}]}}`), &input))
+	actualReturnKey, _ := p.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+}
+
+func TestMissingEndpoint(t *testing.T) {
+	translator.ResetMessages()
+	p := new(Probes)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"probes":{
+		"http": [{"method": "GET"}],
+		"tcp": [{"address": "localhost:5432"}],
+		"measurement": ["up"]
+	}}`), &input))
+	_, actualVal := p.ApplyRule(input)
+	assert.False(t, translator.IsTranslateSuccess())
+	expectedVal := []interface{}{map[string]interface{}{
+		"tcp":       []interface{}{map[string]interface{}{"address": "localhost:5432"}},
+		"fieldpass": []string{"up"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
+
+func TestFullConfig(t *testing.T) {
+	translator.ResetMessages()
+	p := new(Probes)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"probes":{
+		"timeout": 10,
+		"http": [
+			{
+				"url": "https://localhost:8443/health",
+				"method": "HEAD",
+				"headers": {"Host": "example.com"},
+				"expected_status_codes": [200, 204],
+				"timeout": 3,
+				"insecure_skip_verify": true
+			},
+			{"url": "http://localhost:8080/"}
+		],
+		"tcp": [{"address": "localhost:636", "timeout": 2, "tls": true, "insecure_skip_verify": false}],
+		"measurement": ["probes_up", "response_time", "cert_expiry_days"],
+		"metrics_collection_interval": 300
+	}}`), &input))
+	_, actualVal := p.ApplyRule(input)
+	assert.True(t, translator.IsTranslateSuccess())
+	expectedVal := []interface{}{map[string]interface{}{
+		"timeout": "10s",
+		"http": []interface{}{
+			map[string]interface{}{
+				"url":                   "https://localhost:8443/health",
+				"method":                "HEAD",
+				"headers":               map[string]interface{}{"Host": "example.com"},
+				"expected_status_codes": []int{200, 204},
+				"timeout":               "3s",
+				"insecure_skip_verify":  true,
+			},
+			map[string]interface{}{"url": "http://localhost:8080/"},
+		},
+		"tcp": []interface{}{map[string]interface{}{
+			"address":              "localhost:636",
+			"timeout":              "2s",
+			"tls":                  true,
+			"insecure_skip_verify": false,
+		}},
+		"fieldpass": []string{"up", "response_time", "cert_expiry_days"},
+		"interval":  "300s",
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
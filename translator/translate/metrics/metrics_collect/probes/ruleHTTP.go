@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package probes
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type HTTP struct{}
+
+const (
+	HTTPKey                = "http"
+	URLKey                 = "url"
+	MethodKey              = "method"
+	HeadersKey             = "headers"
+	ExpectedStatusCodesKey = "expected_status_codes"
+	InsecureSkipVerifyKey  = "insecure_skip_verify"
+)
+
+func (h *HTTP) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	items, ok := m[HTTPKey].([]interface{})
+	if !ok {
+		return "", ""
+	}
+	var probes []interface{}
+	for i, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		url, ok := itemMap[URLKey].(string)
+		if !ok || url == "" {
+			translator.AddErrorMessages(fmt.Sprintf("%s%s/%d", GetCurPath(), HTTPKey, i), "url must be set")
+			continue
+		}
+		probe := map[string]interface{}{URLKey: url}
+		if method, ok := itemMap[MethodKey].(string); ok {
+			probe[MethodKey] = method
+		}
+		if headers, ok := itemMap[HeadersKey].(map[string]interface{}); ok {
+			probe[HeadersKey] = headers
+		}
+		if codes, ok := itemMap[ExpectedStatusCodesKey].([]interface{}); ok {
+			// By default json unmarshal will store number as float64
+			statusCodes := make([]int, 0, len(codes))
+			for _, code := range codes {
+				if statusCode, ok := code.(float64); ok {
+					statusCodes = append(statusCodes, int(statusCode))
+				}
+			}
+			probe[ExpectedStatusCodesKey] = statusCodes
+		}
+		if timeout, ok := toDuration(itemMap[TimeoutKey]); ok {
+			probe[TimeoutKey] = timeout
+		}
+		if insecure, ok := itemMap[InsecureSkipVerifyKey].(bool); ok {
+			probe[InsecureSkipVerifyKey] = insecure
+		}
+		probes = append(probes, probe)
+	}
+	if len(probes) == 0 {
+		return "", ""
+	}
+	return HTTPKey, probes
+}
+
+func init() {
+	h := new(HTTP)
+	RegisterRule(HTTPKey, h)
+}
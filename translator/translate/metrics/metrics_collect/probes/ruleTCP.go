@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package probes
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type TCP struct{}
+
+const (
+	TCPKey     = "tcp"
+	AddressKey = "address"
+	TLSKey     = "tls"
+)
+
+func (t *TCP) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	items, ok := m[TCPKey].([]interface{})
+	if !ok {
+		return "", ""
+	}
+	var probes []interface{}
+	for i, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		address, ok := itemMap[AddressKey].(string)
+		if !ok || address == "" {
+			translator.AddErrorMessages(fmt.Sprintf("%s%s/%d", GetCurPath(), TCPKey, i), "address must be set")
+			continue
+		}
+		probe := map[string]interface{}{AddressKey: address}
+		if timeout, ok := toDuration(itemMap[TimeoutKey]); ok {
+			probe[TimeoutKey] = timeout
+		}
+		if useTLS, ok := itemMap[TLSKey].(bool); ok {
+			probe[TLSKey] = useTLS
+		}
+		if insecure, ok := itemMap[InsecureSkipVerifyKey].(bool); ok {
+			probe[InsecureSkipVerifyKey] = insecure
+		}
+		probes = append(probes, probe)
+	}
+	if len(probes) == 0 {
+		return "", ""
+	}
+	return TCPKey, probes
+}
+
+func init() {
+	t := new(TCP)
+	RegisterRule(TCPKey, t)
+}
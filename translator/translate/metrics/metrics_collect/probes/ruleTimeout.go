@@ -0,0 +1,21 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package probes
+
+type Timeout struct{}
+
+const TimeoutKey = "timeout"
+
+func (t *Timeout) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if timeout, ok := toDuration(m[TimeoutKey]); ok {
+		return TimeoutKey, timeout
+	}
+	return "", ""
+}
+
+func init() {
+	t := new(Timeout)
+	RegisterRule(TimeoutKey, t)
+}
@@ -0,0 +1,22 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type UnitType struct{}
+
+const UnitTypeKey = "unit_type"
+
+func (u *UnitType) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(UnitTypeKey, "service", input)
+	return
+}
+
+func init() {
+	u := new(UnitType)
+	RegisterRule(UnitTypeKey, u)
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+type Units struct{}
+
+const UnitsKey = "units"
+
+func (u *Units) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[UnitsKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		returnKey = UnitsKey
+		returnVal = m[UnitsKey]
+	}
+	return
+}
+
+func init() {
+	u := new(Units)
+	RegisterRule(UnitsKey, u)
+}
@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"systemd_units": {
+//	    "units": ["nginx.service", "sshd"],
+//	    "pattern": "^app-.*\\.service$",
+//	    "unit_type": "service",
+//	    "measurement": [
+//	        "active",
+//	        "restarts"
+//	    ]
+//	}
+const SectionKey = "systemd_units"
+
+func GetCurPath() string {
+	curPath := parent.GetCurPath() + SectionKey + "/"
+	return curPath
+}
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type SystemdUnits struct {
+}
+
+func (s *SystemdUnits) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	res := []interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+		return
+	}
+	inputMap := m[SectionKey].(map[string]interface{})
+	_, hasUnits := inputMap[UnitsKey]
+	_, hasPattern := inputMap[PatternKey]
+	if !hasUnits && !hasPattern {
+		translator.AddErrorMessages(GetCurPath(), "either units or pattern must be set")
+		returnKey = ""
+		return
+	}
+
+	//Check if there are some config entry with rules applied
+	result = translator.ProcessRuleToApply(inputMap, ChildRule, result)
+
+	//Process common config, like measurement
+	hasValidMetric := util.ProcessLinuxCommonConfig(inputMap, SectionKey, GetCurPath(), result)
+	if hasValidMetric {
+		res = append(res, result)
+		returnKey = SectionKey
+		returnVal = res
+	} else {
+		returnKey = ""
+	}
+	return
+}
+
+func init() {
+	s := new(SystemdUnits)
+	parent.RegisterLinuxRule(SectionKey, s)
+}
@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestWithoutUnitsOrPattern(t *testing.T) {
+	translator.ResetMessages()
+	s := new(SystemdUnits)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"systemd_units":{"measurement": ["active"]}}`), &input))
+	actualReturnKey, _ := s.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+	assert.False(t, translator.IsTranslateSuccess())
+}
+
+func TestWithoutMeasurement(t *testing.T) {
+	s := new(SystemdUnits)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"systemd_units":{"units": ["sshd"]}}`), &input))
+	actualReturnKey, _ := s.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+}
+
+func TestFullConfig(t *testing.T) {
+	s := new(SystemdUnits)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"systemd_units":{
+		"units": ["sshd", "chronyd.service"],
+		"pattern": "^app-",
+		"unit_type": "service",
+		"measurement": ["systemd_units_active", "restarts", "memory_current"],
+		"metrics_collection_interval": 120,
+		"append_dimensions": {"team": "payments"}
+	}}`), &input))
+	actualReturnKey, actualVal := s.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+
+	expectedVal := []interface{}{map[string]interface{}{
+		"units":     []interface{}{"sshd", "chronyd.service"},
+		"pattern":   "^app-",
+		"unit_type": "service",
+		"fieldpass": []string{"active", "restarts", "memory_current"},
+		"interval":  "120s",
+		"tags":      map[string]interface{}{"team": "payments"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
+
+func TestDefaultUnitType(t *testing.T) {
+	s := new(SystemdUnits)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"systemd_units":{"pattern": "^app-", "measurement": ["active"]}}`), &input))
+	_, actualVal := s.ApplyRule(input)
+	expectedVal := []interface{}{map[string]interface{}{
+		"pattern":   "^app-",
+		"unit_type": "service",
+		"fieldpass": []string{"active"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
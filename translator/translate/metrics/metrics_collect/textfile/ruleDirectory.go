@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+type Directory struct{}
+
+const DirectoryKey = "directory"
+
+func (r *Directory) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[DirectoryKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		returnKey = DirectoryKey
+		returnVal = m[DirectoryKey]
+	}
+	return
+}
+
+func init() {
+	r := new(Directory)
+	RegisterRule(DirectoryKey, r)
+}
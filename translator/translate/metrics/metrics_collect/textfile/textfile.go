@@ -0,0 +1,68 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"textfile": {
+//	    "directory": "/var/lib/amazon-cloudwatch-agent/textfile",
+//	    "metrics_collection_interval": 60,
+//	    "append_dimensions": {
+//	        "team": "batch"
+//	    }
+//	}
+//
+// The metrics are named by the files, so unlike the other plugins there is no
+// measurement to select them.
+const SectionKey = "textfile"
+
+func GetCurPath() string {
+	curPath := parent.GetCurPath() + SectionKey + "/"
+	return curPath
+}
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type Textfile struct {
+}
+
+func (t *Textfile) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+		return
+	}
+	inputMap := m[SectionKey].(map[string]interface{})
+	if _, ok := inputMap[DirectoryKey]; !ok {
+		translator.AddErrorMessages(GetCurPath(), "directory must be set")
+		returnKey = ""
+		return
+	}
+
+	//Check if there are some config entry with rules applied
+	result = translator.ProcessRuleToApply(inputMap, ChildRule, result)
+	util.ProcessIntervalAndDimensions(inputMap, SectionKey, result)
+	returnKey = SectionKey
+	returnVal = []interface{}{result}
+	return
+}
+
+func init() {
+	t := new(Textfile)
+	parent.RegisterLinuxRule(SectionKey, t)
+	parent.RegisterDarwinRule(SectionKey, t)
+	parent.RegisterWindowsRule(SectionKey, t)
+}
@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestWithoutDirectory(t *testing.T) {
+	translator.ResetMessages()
+	tf := new(Textfile)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"textfile":{"metrics_collection_interval": 60}}`), &input))
+	actualReturnKey, _ := tf.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey)
+	assert.False(t, translator.IsTranslateSuccess())
+}
+
+func TestDirectoryOnly(t *testing.T) {
+	translator.ResetMessages()
+	tf := new(Textfile)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"textfile":{"directory": "/var/lib/textfile"}}`), &input))
+	actualReturnKey, actualVal := tf.ApplyRule(input)
+	assert.True(t, translator.IsTranslateSuccess())
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"directory": "/var/lib/textfile",
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
+
+func TestFullConfig(t *testing.T) {
+	translator.ResetMessages()
+	tf := new(Textfile)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"textfile":{
+		"directory": "/var/lib/textfile",
+		"metrics_collection_interval": 10,
+		"append_dimensions": {"team": "batch"}
+	}}`), &input))
+	_, actualVal := tf.ApplyRule(input)
+	assert.True(t, translator.IsTranslateSuccess())
+	expectedVal := []interface{}{map[string]interface{}{
+		"directory": "/var/lib/textfile",
+		"interval":  "10s",
+		"tags":      map[string]interface{}{"team": "batch", "aws:StorageResolution": "true"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
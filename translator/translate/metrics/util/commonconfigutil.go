@@ -49,6 +49,14 @@ func ProcessLinuxCommonConfig(input interface{}, pluginName string, path string,
 		return false
 	}
 
+	ProcessIntervalAndDimensions(inputMap, pluginName, result)
+	return true
+}
+
+// ProcessIntervalAndDimensions sets the interval and the append_dimensions
+// tags, along with the high resolution tag for sub-minute intervals. It is
+// the part of the common config that does not depend on the measurement.
+func ProcessIntervalAndDimensions(inputMap map[string]interface{}, pluginName string, result map[string]interface{}) {
 	ProcessAppendDimensions(inputMap, pluginName, result)
 
 	isHighResolution := IsHighResolution(agent.Global_Config.Interval)
@@ -61,7 +69,6 @@ func ProcessLinuxCommonConfig(input interface{}, pluginName string, path string,
 			result[Append_Dimensions_Mapped_Key] = map[string]interface{}{tagutil.HighResolutionTagKey: "true"}
 		}
 	}
-	return true
 }
 func ProcessAppendDimensions(inputMap map[string]interface{}, pluginName string, result map[string]interface{}) {
 	// Set append_dimensions as tags
@@ -55,6 +55,7 @@ const (
 	SigV4Auth                                      = "sigv4auth"
 	MetricsCollectionIntervalKey                   = "metrics_collection_interval"
 	AggregationDimensionsKey                       = "aggregation_dimensions"
+	RollupRulesKey                                 = "rollup_rules"
 	MeasurementKey                                 = "measurement"
 	DropOriginalMetricsKey                         = "drop_original_metrics"
 	ForceFlushIntervalKey                          = "force_flush_interval"
@@ -67,6 +68,7 @@ const (
 	HighFrequencyGpuMetrics                        = "high_frequency_gpu_metrics"
 	EnableKueueContainerInsights                   = "kueue_container_insights"
 	AppendDimensionsKey                            = "append_dimensions"
+	EC2InstanceTagsKey                             = "ec2_instance_tags"
 	Console                                        = "console"
 	DiskKey                                        = "disk"
 	DiskIOKey                                      = "diskio"
@@ -84,6 +86,9 @@ const (
 	NameKey                                        = "name"
 	RenameKey                                      = "rename"
 	UnitKey                                        = "unit"
+	StatusEndpointKey                              = "status_endpoint"
+	EntityRulesKey                                 = "entity_rules"
+	HostMetadataKey                                = "host_metadata"
 )
 
 const (
@@ -92,6 +97,7 @@ const (
 	CPUMetricKey      = "cpu"
 	DiskMetricKey     = "disk"
 	DiskIoMetricKey   = "diskio"
+	ExecMetricKey     = "exec"
 	StatsDMetricKey   = "statsd"
 	SwapMetricKey     = "swap"
 	MemMetricKey      = "mem"
@@ -117,6 +123,7 @@ const (
 	PipelineNameHostCustomMetrics    = "hostCustomMetrics"
 	PipelineNameHostDeltaMetrics     = "hostDeltaMetrics"
 	PipelineNameHostOtlpMetrics      = "hostOtlpMetrics"
+	PipelineNameHostOtlpLogs         = "hostOtlpLogs"
 	PipelineNameContainerInsights    = "containerinsights"
 	PipelineNameJmx                  = "jmx"
 	PipelineNameContainerInsightsJmx = "containerinsightsjmx"
@@ -148,9 +155,14 @@ var (
 	JmxTargets = []string{"activemq", "cassandra", "hbase", "hadoop", "jetty", "jvm", "kafka", "kafka-consumer", "kafka-producer", "solr", "tomcat", "wildfly"}
 
 	AgentDebugConfigKey             = ConfigKey(AgentKey, DebugKey)
+	AgentStatusEndpointConfigKey    = ConfigKey(AgentKey, StatusEndpointKey)
+	AgentEntityRulesConfigKey       = ConfigKey(AgentKey, EntityRulesKey)
+	AgentHostMetadataConfigKey      = ConfigKey(AgentKey, HostMetadataKey)
 	MetricsAggregationDimensionsKey = ConfigKey(MetricsKey, AggregationDimensionsKey)
+	MetricsRollupRulesKey           = ConfigKey(MetricsKey, RollupRulesKey)
 	OTLPLogsKey                     = ConfigKey(LogsKey, MetricsCollectedKey, OtlpKey)
 	OTLPMetricsKey                  = ConfigKey(MetricsKey, MetricsCollectedKey, OtlpKey)
+	OTLPLogsCollectedKey            = ConfigKey(LogsKey, LogsCollectedKey, OtlpKey)
 )
 
 type TranslatorID interface {
@@ -0,0 +1,27 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/entity"
+)
+
+// GetEntityRules returns the entity rules of the agent section, which are
+// shared by the awsentity processors and the entitystore extension.
+func GetEntityRules(conf *confmap.Conf) ([]entity.Rule, error) {
+	if conf == nil || !conf.IsSet(AgentEntityRulesConfigKey) {
+		return nil, nil
+	}
+	var rules struct {
+		Rules []entity.Rule `mapstructure:"entity_rules"`
+	}
+	if err := confmap.NewFromStringMap(map[string]any{EntityRulesKey: conf.Get(AgentEntityRulesConfigKey)}).Unmarshal(&rules); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", AgentEntityRulesConfigKey, err)
+	}
+	return rules.Rules, nil
+}
@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/entity"
+)
+
+func TestGetEntityRules(t *testing.T) {
+	rules, err := GetEntityRules(confmap.NewFromStringMap(map[string]any{"agent": map[string]any{}}))
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"agent": map[string]any{
+			"entity_rules": []any{
+				map[string]any{
+					"source":       "log_group",
+					"pattern":      "/app/(?P<service>[^/]+)",
+					"service_name": "{{service}}",
+					"priority":     float64(10),
+				},
+				map[string]any{
+					"name":        "payments",
+					"source":      "container_label",
+					"key":         "team",
+					"pattern":     "payments",
+					"environment": "prod",
+				},
+			},
+		},
+	})
+	rules, err = GetEntityRules(conf)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.Rule{
+		{Source: "log_group", Pattern: "/app/(?P<service>[^/]+)", ServiceName: "{{service}}", Priority: 10},
+		{Name: "payments", Source: "container_label", Key: "team", Pattern: "payments", Environment: "prod"},
+	}, rules)
+
+	_, err = GetEntityRules(confmap.NewFromStringMap(map[string]any{"agent": map[string]any{"entity_rules": "invalid"}}))
+	assert.Error(t, err)
+}
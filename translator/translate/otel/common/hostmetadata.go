@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
+)
+
+// GetHostMetadata returns the host metadata source of the agent section, which
+// is used in place of IMDS by the ec2tagger processor and the entitystore
+// extension.
+func GetHostMetadata(conf *confmap.Conf) (*hostmetadata.Config, error) {
+	if conf == nil || !conf.IsSet(AgentHostMetadataConfigKey) {
+		return nil, nil
+	}
+	var hostMetadata struct {
+		Config hostmetadata.Config `mapstructure:"host_metadata"`
+	}
+	if err := confmap.NewFromStringMap(map[string]any{HostMetadataKey: conf.Get(AgentHostMetadataConfigKey)}).Unmarshal(&hostMetadata); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", AgentHostMetadataConfigKey, err)
+	}
+	if err := hostMetadata.Config.Validate(); err != nil {
+		return nil, err
+	}
+	return &hostMetadata.Config, nil
+}
@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
+)
+
+func TestGetHostMetadata(t *testing.T) {
+	cfg, err := GetHostMetadata(confmap.NewFromStringMap(map[string]any{"agent": map[string]any{}}))
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	cfg, err = GetHostMetadata(confmap.NewFromStringMap(map[string]any{
+		"agent": map[string]any{"host_metadata": map[string]any{"file": "/etc/host-metadata.yaml"}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, &hostmetadata.Config{File: "/etc/host-metadata.yaml"}, cfg)
+
+	cfg, err = GetHostMetadata(confmap.NewFromStringMap(map[string]any{
+		"agent": map[string]any{"host_metadata": map[string]any{"command": []any{"cmdb-lookup", "--json"}}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, &hostmetadata.Config{Command: []string{"cmdb-lookup", "--json"}}, cfg)
+
+	_, err = GetHostMetadata(confmap.NewFromStringMap(map[string]any{"agent": map[string]any{"host_metadata": map[string]any{}}}))
+	assert.Error(t, err)
+	_, err = GetHostMetadata(confmap.NewFromStringMap(map[string]any{"agent": map[string]any{"host_metadata": "invalid"}}))
+	assert.Error(t, err)
+}
@@ -4,10 +4,13 @@
 package awscloudwatch
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/exporter"
 
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/exph"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatch"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
@@ -19,6 +22,8 @@ const (
 	forceFlushIntervalKey = "force_flush_interval"
 	dropOriginalWildcard  = "*"
 
+	exponentialHistogramCompressionKey = "exponential_histogram_compression"
+
 	internalMaxValuesPerDatum = 5000
 )
 
@@ -71,6 +76,18 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if dropOriginalMetrics := common.GetDropOriginalMetrics(conf); len(dropOriginalMetrics) != 0 {
 		cfg.DropOriginalConfigs = dropOriginalMetrics
 	}
+	compressionKey := common.ConfigKey(common.MetricsKey, exponentialHistogramCompressionKey)
+	if conf.IsSet(compressionKey) {
+		compressionConf, err := conf.Sub(compressionKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", compressionKey, err)
+		}
+		var compression exph.Compression
+		if err = compressionConf.Unmarshal(&compression); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", compressionKey, err)
+		}
+		cfg.ExponentialHistogramCompression = &compression
+	}
 	cfg.MiddlewareID = &agenthealth.MetricsID
 	return cfg, nil
 }
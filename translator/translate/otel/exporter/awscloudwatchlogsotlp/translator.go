@@ -0,0 +1,103 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awscloudwatchlogsotlp
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs"
+	legacytranslator "github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+const (
+	retentionInDaysKey = "retention_in_days"
+	logGroupClassKey   = "log_group_class"
+	bodyFormatKey      = "body_format"
+	severityFormatKey  = "severity_format"
+	concurrencyKey     = "concurrency"
+)
+
+var (
+	roleARNPathKey        = common.ConfigKey(common.LogsKey, common.CredentialsKey, common.RoleARNKey)
+	endpointOverrideKey   = common.ConfigKey(common.LogsKey, common.EndpointOverrideKey)
+	forceFlushIntervalKey = common.ConfigKey(common.LogsKey, common.ForceFlushIntervalKey)
+)
+
+type translator struct {
+	name    string
+	factory exporter.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslatorWithName(name string) common.ComponentTranslator {
+	return &translator{name, cloudwatchlogs.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// Translate creates an exporter config based on the fields in the
+// logs.logs_collected.otlp section of the JSON config. The agent placeholders
+// in the log group and stream names are resolved here, while the resource
+// attribute placeholders are left for the exporter.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(common.OTLPLogsCollectedKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.OTLPLogsCollectedKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*cloudwatchlogs.Config)
+	credentials := confmap.NewFromStringMap(agent.Global_Config.Credentials)
+	_ = credentials.Unmarshal(cfg)
+	cfg.Region = agent.Global_Config.Region
+	cfg.RoleARN = agent.Global_Config.Role_arn
+	if roleARN, ok := common.GetString(conf, roleARNPathKey); ok {
+		cfg.RoleARN = roleARN
+	}
+	if endpointOverride, ok := common.GetString(conf, endpointOverrideKey); ok {
+		cfg.EndpointOverride = endpointOverride
+	}
+	if forceFlushInterval, ok := common.GetDuration(conf, forceFlushIntervalKey); ok {
+		cfg.ForceFlushInterval = forceFlushInterval
+	}
+	if concurrency, ok := common.GetNumber(conf, common.ConfigKey(common.LogsKey, concurrencyKey)); ok {
+		cfg.Concurrency = int(concurrency)
+	}
+
+	if logGroupName, ok := common.GetString(conf, common.ConfigKey(common.OTLPLogsCollectedKey, common.LogGroupName)); ok {
+		cfg.LogGroupName = logGroupName
+	}
+	cfg.LogGroupName = util.ResolvePlaceholder(cfg.LogGroupName, logs.GlobalLogConfig.MetadataInfo)
+	if logStreamName, ok := common.GetString(conf, common.ConfigKey(common.OTLPLogsCollectedKey, common.LogStreamName)); ok {
+		cfg.LogStreamName = util.ResolvePlaceholder(logStreamName, logs.GlobalLogConfig.MetadataInfo)
+	} else {
+		// falls back to the log stream name of the logs section
+		rule := logs.LogStreamName{}
+		_, val := rule.ApplyRule(conf.Get(common.LogsKey))
+		if logStreamName, ok := val.(map[string]any)[common.LogStreamName].(string); ok {
+			cfg.LogStreamName = logStreamName
+		}
+	}
+	if retention, ok := common.GetNumber(conf, common.ConfigKey(common.OTLPLogsCollectedKey, retentionInDaysKey)); ok {
+		cfg.RetentionInDays = int(retention)
+	}
+	// CreateLogGroup only accepts upper case log group classes
+	_, logGroupClass := legacytranslator.DefaultLogGroupClassCase(logGroupClassKey, "", conf.Get(common.OTLPLogsCollectedKey))
+	cfg.LogGroupClass, _ = logGroupClass.(string)
+	if bodyFormat, ok := common.GetString(conf, common.ConfigKey(common.OTLPLogsCollectedKey, bodyFormatKey)); ok {
+		cfg.BodyFormat = bodyFormat
+	}
+	if severityFormat, ok := common.GetString(conf, common.ConfigKey(common.OTLPLogsCollectedKey, severityFormatKey)); ok {
+		cfg.SeverityFormat = severityFormat
+	}
+	cfg.MiddlewareID = &agenthealth.LogsID
+	return cfg, nil
+}
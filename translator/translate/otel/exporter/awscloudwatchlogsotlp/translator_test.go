@@ -0,0 +1,137 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awscloudwatchlogsotlp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	globallogs "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
+	logsutil "github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+func testMetadata() *logsutil.Metadata {
+	return &logsutil.Metadata{
+		InstanceID: "some_instance_id",
+		Hostname:   "some_hostname",
+		PrivateIP:  "some_private_ip",
+		AccountID:  "some_account_id",
+	}
+}
+
+func TestTranslator(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.Role_arn = "global_arn"
+	agent.Global_Config.Credentials = map[string]any{
+		"profile":                "some_profile",
+		"shared_credential_file": "/some/credentials",
+	}
+	globallogs.GlobalLogConfig.MetadataInfo = logsutil.GetMetadataInfo(testMetadata)
+	tt := NewTranslatorWithName(common.PipelineNameHostOtlpLogs)
+	require.EqualValues(t, "awscloudwatchlogs_otlp/hostOtlpLogs", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		want    func(cfg *cloudwatchlogs.Config)
+		wantErr error
+	}{
+		"WithoutOtlpKey": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"metrics_collected": map[string]any{
+						"otlp": map[string]any{},
+					},
+				},
+			},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: common.OTLPLogsCollectedKey},
+		},
+		"WithDefaults": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{},
+					},
+				},
+			},
+			want: func(cfg *cloudwatchlogs.Config) {
+				cfg.LogStreamName = "some_instance_id"
+			},
+		},
+		"WithLogsStreamName": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{},
+					},
+					"log_stream_name": "{hostname}/stream",
+				},
+			},
+			want: func(cfg *cloudwatchlogs.Config) {
+				cfg.LogStreamName = "some_hostname/stream"
+			},
+		},
+		"WithCompleteConfig": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{
+							"log_group_name":    "/otlp/{instance_id}/{service.name}",
+							"log_stream_name":   "{ip_address}/{service.instance.id}",
+							"log_group_class":   "infrequent_access",
+							"retention_in_days": 7,
+							"body_format":       "json",
+							"severity_format":   "number",
+						},
+					},
+					"credentials": map[string]any{
+						"role_arn": "logs_arn",
+					},
+					"endpoint_override":    "https://cloudwatchlogs-endpoint",
+					"force_flush_interval": 10,
+					"concurrency":          4,
+				},
+			},
+			want: func(cfg *cloudwatchlogs.Config) {
+				cfg.RoleARN = "logs_arn"
+				cfg.EndpointOverride = "https://cloudwatchlogs-endpoint"
+				cfg.ForceFlushInterval = 10 * time.Second
+				cfg.Concurrency = 4
+				cfg.LogGroupName = "/otlp/some_instance_id/{service.name}"
+				cfg.LogStreamName = "some_private_ip/{service.instance.id}"
+				cfg.LogGroupClass = "INFREQUENT_ACCESS"
+				cfg.RetentionInDays = 7
+				cfg.BodyFormat = "json"
+				cfg.SeverityFormat = "number"
+			},
+		},
+	}
+	factory := cloudwatchlogs.NewFactory()
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			require.Equal(t, testCase.wantErr, err)
+			if err == nil {
+				require.NotNil(t, got)
+				gotCfg, ok := got.(*cloudwatchlogs.Config)
+				require.True(t, ok)
+				wantCfg := factory.CreateDefaultConfig().(*cloudwatchlogs.Config)
+				wantCfg.Region = "us-east-1"
+				wantCfg.RoleARN = "global_arn"
+				wantCfg.Profile = "some_profile"
+				wantCfg.SharedCredentialFilename = "/some/credentials"
+				wantCfg.MiddlewareID = &agenthealth.LogsID
+				testCase.want(wantCfg)
+				assert.Equal(t, wantCfg, gotCfg)
+			}
+		})
+	}
+}
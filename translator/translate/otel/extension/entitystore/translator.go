@@ -39,6 +39,16 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	cfg.Region = agent.Global_Config.Region
 	credentials := confmap.NewFromStringMap(agent.Global_Config.Credentials)
 	_ = credentials.Unmarshal(cfg)
+	rules, err := common.GetEntityRules(conf)
+	if err != nil {
+		return nil, err
+	}
+	cfg.EntityRules = rules
+	hostMetadata, err := common.GetHostMetadata(conf)
+	if err != nil {
+		return nil, err
+	}
+	cfg.HostMetadata = hostMetadata
 
 	return cfg, nil
 }
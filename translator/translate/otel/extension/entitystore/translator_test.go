@@ -10,6 +10,8 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/aws/amazon-cloudwatch-agent/extension/entitystore"
+	"github.com/aws/amazon-cloudwatch-agent/internal/entity"
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 	translateagent "github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
@@ -62,6 +64,46 @@ func TestTranslate(t *testing.T) {
 				Filename:       "test_file",
 			},
 		},
+		"WithEntityRules": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"entity_rules": []interface{}{
+						map[string]interface{}{
+							"source":       "log_group",
+							"pattern":      "/aws/(?P<app>.+)",
+							"service_name": "{{app}}",
+						},
+					},
+				},
+			},
+			inputMode:      config.ModeEC2,
+			profile_exists: true,
+			want: &entitystore.Config{
+				Mode:    config.ModeEC2,
+				Region:  "us-east-1",
+				Profile: "test_profile",
+				EntityRules: []entity.Rule{
+					{Source: entity.RuleSourceLogGroup, Pattern: "/aws/(?P<app>.+)", ServiceName: "{{app}}"},
+				},
+			},
+		},
+		"WithHostMetadata": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"host_metadata": map[string]interface{}{
+						"command": []interface{}{"cmdb-lookup", "--json"},
+					},
+				},
+			},
+			inputMode:      config.ModeOnPremise,
+			profile_exists: true,
+			want: &entitystore.Config{
+				Mode:         config.ModeOnPremise,
+				Region:       "us-east-1",
+				Profile:      "test_profile",
+				HostMetadata: &hostmetadata.Config{Command: []string{"cmdb-lookup", "--json"}},
+			},
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -46,5 +46,5 @@ func (t *translator) isAppSignals(conf *confmap.Conf) bool {
 }
 
 func (t *translator) isOTLP(conf *confmap.Conf) bool {
-	return conf.IsSet(common.OTLPLogsKey) || conf.IsSet(common.OTLPMetricsKey)
+	return conf.IsSet(common.OTLPLogsKey) || conf.IsSet(common.OTLPMetricsKey) || conf.IsSet(common.OTLPLogsCollectedKey)
 }
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/extension"
 
 	"github.com/aws/amazon-cloudwatch-agent/extension/server"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 )
 
@@ -36,12 +37,18 @@ func (t *translator) ID() component.ID {
 	return component.NewIDWithName(t.factory.Type(), t.name)
 }
 
-// Translate creates an extension configuration.
+// Translate creates an extension configuration. The HTTPS server is only
+// configured in Kubernetes, so the status endpoint alone does not start it.
 func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	cfg := t.factory.CreateDefaultConfig().(*server.Config)
-	cfg.ListenAddress = defaultListenAddr
-	cfg.TLSCAPath = caFilePath
-	cfg.TLSCertPath = tlsServerCertFilePath
-	cfg.TLSKeyPath = tlsServerKeyFilePath
+	if context.CurrentContext().KubernetesMode() != "" {
+		cfg.ListenAddress = defaultListenAddr
+		cfg.TLSCAPath = caFilePath
+		cfg.TLSCertPath = tlsServerCertFilePath
+		cfg.TLSKeyPath = tlsServerKeyFilePath
+	}
+	if statusEndpoint, ok := common.GetString(conf, common.AgentStatusEndpointConfigKey); ok {
+		cfg.StatusListenAddress = statusEndpoint
+	}
 	return cfg, nil
 }
@@ -10,20 +10,49 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/aws/amazon-cloudwatch-agent/extension/server"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 )
 
 func TestTranslate(t *testing.T) {
 	testCases := map[string]struct {
-		input map[string]interface{}
-		want  *server.Config
+		input          map[string]interface{}
+		kubernetesMode string
+		want           *server.Config
 	}{
 		"DefaultConfig": {
-			input: map[string]interface{}{},
-			want:  &server.Config{ListenAddress: defaultListenAddr, TLSCAPath: caFilePath, TLSCertPath: tlsServerCertFilePath, TLSKeyPath: tlsServerKeyFilePath},
+			input:          map[string]interface{}{},
+			kubernetesMode: config.ModeEKS,
+			want:           &server.Config{ListenAddress: defaultListenAddr, TLSCAPath: caFilePath, TLSCertPath: tlsServerCertFilePath, TLSKeyPath: tlsServerKeyFilePath},
+		},
+		"WithStatusEndpoint": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"status_endpoint": "127.0.0.1:4312",
+				},
+			},
+			kubernetesMode: config.ModeEKS,
+			want: &server.Config{
+				ListenAddress:       defaultListenAddr,
+				TLSCAPath:           caFilePath,
+				TLSCertPath:         tlsServerCertFilePath,
+				TLSKeyPath:          tlsServerKeyFilePath,
+				StatusListenAddress: "127.0.0.1:4312",
+			},
+		},
+		"WithStatusEndpointOutsideKubernetes": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"status_endpoint": "127.0.0.1:4312",
+				},
+			},
+			want: &server.Config{StatusListenAddress: "127.0.0.1:4312"},
 		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
+			context.CurrentContext().SetKubernetesMode(testCase.kubernetesMode)
+			t.Cleanup(func() { context.CurrentContext().SetKubernetesMode("") })
 			tt := NewTranslator().(*translator)
 			assert.Equal(t, "server", tt.ID().String())
 			conf := confmap.NewFromStringMap(testCase.input)
@@ -105,13 +105,19 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 	}
 
 	currentContext := context.CurrentContext()
+	hostMetadata, err := common.GetHostMetadata(conf)
+	if err != nil {
+		return nil, err
+	}
+	// the host metadata provides the same entity information as IMDS
+	entityEnabled := currentContext.Mode() == config.ModeEC2 || hostMetadata != nil
 
 	switch determinePipeline(t.name) {
 	case common.PipelineNameHostOtlpMetrics:
 		if currentContext.KubernetesMode() != "" {
 			entityProcessor = awsentity.NewTranslatorWithEntityType(awsentity.Service, common.OtlpKey, false)
 			translators.Extensions.Set(k8smetadata.NewTranslator())
-		} else if currentContext.Mode() == config.ModeEC2 {
+		} else if entityEnabled {
 			switch t.Destination() {
 			case common.DefaultDestination, common.CloudWatchKey:
 				entityProcessor = util.CreateEntityProcessorFromConfig(common.OtlpKey+"/"+common.CloudWatchKey, common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.OtlpKey), conf)
@@ -124,7 +130,8 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 			entityProcessor = awsentity.NewTranslatorWithEntityType(awsentity.Service, "telegraf", true)
 		}
 	case common.PipelineNameHost, common.PipelineNameHostDeltaMetrics:
-		if !currentContext.RunInContainer() {
+		// the resource entity is the EC2 instance, which a host with host metadata is not
+		if !currentContext.RunInContainer() && currentContext.Mode() == config.ModeEC2 {
 			entityProcessor = awsentity.NewTranslatorWithEntityType(awsentity.Resource, "", ec2TaggerEnabled)
 		}
 	}
@@ -132,17 +139,21 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 	validDestination := slices.Contains(supportedEntityProcessorDestinations[:], t.Destination())
 	// ECS is not in scope for entity association, so we only add the entity processor in non-ECS platforms
 	isECS := ecsutil.GetECSUtilSingleton().IsECS()
-	if entityProcessor != nil && currentContext.Mode() == config.ModeEC2 && !isECS && validDestination {
+	if entityProcessor != nil && entityEnabled && !isECS && validDestination {
 		translators.Processors.Set(entityProcessor)
 	}
 
 	switch t.Destination() {
 	case common.DefaultDestination, common.CloudWatchKey:
+		// the exporter rolls up the aggregation_dimensions itself
+		if conf.IsSet(common.MetricsRollupRulesKey) {
+			translators.Processors.Set(rollupprocessor.NewRulesTranslator())
+		}
 		translators.Exporters.Set(awscloudwatch.NewTranslator())
 		translators.Extensions.Set(agenthealth.NewTranslator(agenthealth.MetricsName, []string{agenthealth.OperationPutMetricData}))
 		translators.Extensions.Set(agenthealth.NewTranslatorWithStatusCode(agenthealth.StatusCodeName, nil, true))
 	case common.AMPKey:
-		if conf.IsSet(common.MetricsAggregationDimensionsKey) {
+		if conf.IsSet(common.MetricsAggregationDimensionsKey) || conf.IsSet(common.MetricsRollupRulesKey) {
 			translators.Processors.Set(rollupprocessor.NewTranslator())
 		}
 		translators.Processors.Set(batchprocessor.NewTranslatorWithNameAndSection(t.name, common.MetricsKey))
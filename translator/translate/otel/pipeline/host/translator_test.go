@@ -232,6 +232,83 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
 			},
 		},
+		"WithMetricsKeyStatsDOnPremise": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"statsd": map[string]interface{}{},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHostCustomMetrics,
+			mode:         config.ModeOnPremise,
+			want: &want{
+				pipelineID: "metrics/hostCustomMetrics",
+				receivers:  []string{"nop", "other"},
+				processors: []string{},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
+		"WithMetricsKeyStatsDOnPremiseHostMetadata": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"host_metadata": map[string]interface{}{"file": "/etc/host-metadata.yaml"},
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"statsd": map[string]interface{}{},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHostCustomMetrics,
+			mode:         config.ModeOnPremise,
+			want: &want{
+				pipelineID: "metrics/hostCustomMetrics",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"awsentity/service/telegraf"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
+		"WithMetricsSectionOnPremiseHostMetadata": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"host_metadata": map[string]interface{}{"file": "/etc/host-metadata.yaml"},
+				},
+				"metrics": map[string]interface{}{},
+			},
+			pipelineName: common.PipelineNameHost,
+			mode:         config.ModeOnPremise,
+			want: &want{
+				pipelineID: "metrics/host",
+				receivers:  []string{"nop", "other"},
+				processors: []string{},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
+		"WithOtlpMetricsOnPremiseHostMetadata": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"host_metadata": map[string]interface{}{"file": "/etc/host-metadata.yaml"},
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"otlp": map[string]interface{}{},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHostOtlpMetrics,
+			mode:         config.ModeOnPremise,
+			want: &want{
+				pipelineID: "metrics/hostOtlpMetrics",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"cumulativetodelta/hostOtlpMetrics", "awsentity/service/otlp"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
 		"WithMetricDecoration": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
@@ -312,6 +389,50 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"sigv4auth"},
 			},
 		},
+		"WithRollupRules": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"aggregation_dimensions": []interface{}{[]interface{}{"d1", "d2"}},
+					"rollup_rules": []interface{}{
+						map[string]interface{}{
+							"exclude_attribute_groups": []interface{}{[]interface{}{"path"}},
+							"aggregation":              "max",
+						},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"awsentity/resource", "rollup/cloudwatch"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
+		"WithPRWExporter/RollupRules": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"rollup_rules": []interface{}{
+						map[string]interface{}{
+							"exclude_attribute_groups": []interface{}{[]interface{}{"path"}},
+							"aggregation":              "max",
+						},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			destination:  common.AMPKey,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host/amp",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"rollup", "batch/host/amp", "deltatocumulative/host/amp"},
+				exporters:  []string{"prometheusremotewrite/amp"},
+				extensions: []string{"sigv4auth"},
+			},
+		},
 		"WithPRWExporter/NoAggregation": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{},
@@ -38,7 +38,8 @@ func NewTranslators(conf *confmap.Conf, configSection, os string) (common.Transl
 		adapterReceivers.Range(func(translator common.ComponentTranslator) {
 			if translator.ID().Type() == adapter.Type(common.DiskIOKey) || translator.ID().Type() == adapter.Type(common.NetKey) {
 				deltaReceivers.Set(translator)
-			} else if translator.ID().Type() == adapter.Type(common.StatsDMetricKey) || translator.ID().Type() == adapter.Type(common.CollectDPluginKey) {
+			} else if translator.ID().Type() == adapter.Type(common.StatsDMetricKey) || translator.ID().Type() == adapter.Type(common.CollectDPluginKey) ||
+				translator.ID().Type() == adapter.Type(common.ExecMetricKey) {
 				hostCustomReceivers.Set(translator)
 			} else {
 				hostReceivers.Set(translator)
@@ -254,6 +254,31 @@ func TestTranslators(t *testing.T) {
 				},
 			},
 		},
+		"WithExec": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"cpu": map[string]interface{}{},
+						"exec": []interface{}{
+							map[string]interface{}{
+								"command": []interface{}{"/usr/local/bin/queue-depth.sh"},
+							},
+						},
+					},
+				},
+			},
+			configSection: MetricsKey,
+			want: map[string]want{
+				"metrics/host": {
+					receivers: []string{"telegraf_cpu"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/hostCustomMetrics": {
+					receivers: []string{"telegraf_exec/2381630691"},
+					exporters: []string{"awscloudwatch"},
+				},
+			},
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -114,11 +114,15 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 	switch t.Destination() {
 	case common.DefaultDestination, common.CloudWatchKey:
 		translators.Processors.Set(cumulativetodeltaprocessor.NewTranslator(common.WithName(common.PipelineNameJmx), cumulativetodeltaprocessor.WithConfigKeys(common.JmxConfigKey)))
+		// the exporter rolls up the aggregation_dimensions itself
+		if conf.IsSet(common.MetricsRollupRulesKey) {
+			translators.Processors.Set(rollupprocessor.NewRulesTranslator())
+		}
 		translators.Exporters.Set(awscloudwatch.NewTranslator())
 		translators.Extensions.Set(agenthealth.NewTranslatorWithStatusCode(agenthealth.MetricsName, []string{agenthealth.OperationPutMetricData}, true))
 	case common.AMPKey:
 		translators.Processors.Set(batchprocessor.NewTranslatorWithNameAndSection(t.name, common.MetricsKey))
-		if conf.IsSet(common.MetricsAggregationDimensionsKey) {
+		if conf.IsSet(common.MetricsAggregationDimensionsKey) || conf.IsSet(common.MetricsRollupRulesKey) {
 			translators.Processors.Set(rollupprocessor.NewTranslator())
 		}
 		// prometheusremotewrite doesn't support delta metrics so convert them to cumulative metrics
@@ -0,0 +1,68 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlp_logs
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awscloudwatchlogsotlp"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/k8smetadata"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/awsentity"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/batchprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/otlp"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util/ecsutil"
+)
+
+type translator struct{}
+
+var _ common.PipelineTranslator = (*translator)(nil)
+
+func NewTranslator() common.PipelineTranslator {
+	return &translator{}
+}
+
+func (t *translator) ID() pipeline.ID {
+	return pipeline.NewIDWithName(pipeline.SignalLogs, common.PipelineNameHostOtlpLogs)
+}
+
+// Translate creates a pipeline that sends the log records received over OTLP
+// to CloudWatch Logs if the logs.logs_collected.otlp section is present.
+func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators, error) {
+	if conf == nil || !conf.IsSet(common.OTLPLogsCollectedKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.OTLPLogsCollectedKey}
+	}
+	translators := common.ComponentTranslators{
+		Receivers:  otlp.NewTranslators(conf, common.PipelineNameHostOtlpLogs, common.OTLPLogsCollectedKey),
+		Processors: common.NewTranslatorMap[component.Config, component.ID](),
+		Exporters:  common.NewTranslatorMap(awscloudwatchlogsotlp.NewTranslatorWithName(common.PipelineNameHostOtlpLogs)),
+		Extensions: common.NewTranslatorMap(agenthealth.NewTranslator(agenthealth.LogsName, []string{agenthealth.OperationPutLogEvents}),
+			agenthealth.NewTranslatorWithStatusCode(agenthealth.StatusCodeName, nil, true),
+		),
+	}
+
+	currentContext := context.CurrentContext()
+	hostMetadata, err := common.GetHostMetadata(conf)
+	if err != nil {
+		return nil, err
+	}
+	// ECS is not in scope for entity association. The host metadata provides the
+	// same entity information as IMDS.
+	if (currentContext.Mode() == config.ModeEC2 || hostMetadata != nil) && !ecsutil.GetECSUtilSingleton().IsECS() {
+		if currentContext.KubernetesMode() != "" {
+			translators.Processors.Set(awsentity.NewTranslatorWithEntityType(awsentity.Service, common.OtlpKey, false))
+			translators.Extensions.Set(k8smetadata.NewTranslator())
+		} else {
+			translators.Processors.Set(util.CreateEntityProcessorFromConfig(common.OtlpKey+"/"+common.PipelineNameHostOtlpLogs, common.OTLPLogsCollectedKey, conf))
+		}
+	}
+	translators.Processors.Set(batchprocessor.NewTranslatorWithNameAndSection(common.PipelineNameHostOtlpLogs, common.LogsKey))
+	return &translators, nil
+}
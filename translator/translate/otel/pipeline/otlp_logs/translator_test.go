@@ -0,0 +1,146 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlp_logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/otlp"
+)
+
+func TestTranslator(t *testing.T) {
+	type want struct {
+		receivers  []string
+		processors []string
+		exporters  []string
+		extensions []string
+	}
+	tt := NewTranslator()
+	require.EqualValues(t, "logs/hostOtlpLogs", tt.ID().String())
+	testCases := map[string]struct {
+		input          map[string]any
+		mode           string
+		kubernetesMode string
+		want           *want
+		wantErr        error
+	}{
+		"WithoutOtlpKey": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"metrics_collected": map[string]any{
+						"otlp": map[string]any{},
+					},
+				},
+			},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: common.OTLPLogsCollectedKey},
+		},
+		"WithDefaultEndpoints": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{},
+					},
+				},
+			},
+			mode: config.ModeOnPrem,
+			want: &want{
+				receivers:  []string{"otlp/grpc_127_0_0_1_4317", "otlp/http_127_0_0_1_4318"},
+				processors: []string{"batch/hostOtlpLogs"},
+				exporters:  []string{"awscloudwatchlogs_otlp/hostOtlpLogs"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+		"WithEntityOnEC2": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{
+							"http_endpoint": "127.0.0.1:4320",
+							"service.name":  "checkout",
+						},
+					},
+				},
+			},
+			mode: config.ModeEC2,
+			want: &want{
+				receivers:  []string{"otlp/http_127_0_0_1_4320"},
+				processors: []string{"awsentity/service/otlp/hostOtlpLogs", "batch/hostOtlpLogs"},
+				exporters:  []string{"awscloudwatchlogs_otlp/hostOtlpLogs"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+		"WithEntityOnPremiseHostMetadata": {
+			input: map[string]any{
+				"agent": map[string]any{
+					"host_metadata": map[string]any{"file": "/etc/host-metadata.yaml"},
+				},
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{
+							"http_endpoint": "127.0.0.1:4320",
+							"service.name":  "checkout",
+						},
+					},
+				},
+			},
+			mode: config.ModeOnPrem,
+			want: &want{
+				receivers:  []string{"otlp/http_127_0_0_1_4320"},
+				processors: []string{"awsentity/service/otlp/hostOtlpLogs", "batch/hostOtlpLogs"},
+				exporters:  []string{"awscloudwatchlogs_otlp/hostOtlpLogs"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+		"WithEntityOnKubernetes": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{},
+					},
+				},
+			},
+			mode:           config.ModeEC2,
+			kubernetesMode: config.ModeEKS,
+			want: &want{
+				receivers:  []string{"otlp/grpc_127_0_0_1_4317", "otlp/http_127_0_0_1_4318"},
+				processors: []string{"awsentity/service/otlp", "batch/hostOtlpLogs"},
+				exporters:  []string{"awscloudwatchlogs_otlp/hostOtlpLogs"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode", "k8smetadata"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			otlp.ClearConfigCache()
+			if testCase.mode != "" {
+				context.CurrentContext().SetMode(testCase.mode)
+			}
+			context.CurrentContext().SetKubernetesMode(testCase.kubernetesMode)
+			t.Cleanup(func() {
+				context.ResetContext()
+			})
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			require.Equal(t, testCase.wantErr, err)
+			if testCase.want == nil {
+				require.Nil(t, got)
+			} else {
+				require.NotNil(t, got)
+				assert.Equal(t, testCase.want.receivers, collections.MapSlice(got.Receivers.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.processors, collections.MapSlice(got.Processors.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.exporters, collections.MapSlice(got.Exporters.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.extensions, collections.MapSlice(got.Extensions.Keys(), component.ID.String))
+			}
+		})
+	}
+}
@@ -89,7 +89,7 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 			Exporters:  common.NewTranslatorMap(prometheusremotewrite.NewTranslatorWithName(common.AMPKey)),
 			Extensions: common.NewTranslatorMap(sigv4auth.NewTranslator()),
 		}
-		if conf.IsSet(common.MetricsAggregationDimensionsKey) {
+		if conf.IsSet(common.MetricsAggregationDimensionsKey) || conf.IsSet(common.MetricsRollupRulesKey) {
 			translators.Processors.Set(rollupprocessor.NewTranslator())
 		}
 		return translators, nil
@@ -103,12 +103,45 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		}
 	}
 
+	// the platform of the workloads on the host overrides the resolver of the mode
+	switch platform, _ := getMetricsString(conf, "platform"); platform {
+	case appsignalsconfig.PlatformDocker:
+		endpoint, _ := getMetricsString(conf, "platform_endpoint")
+		cfg.Resolvers = []appsignalsconfig.Resolver{
+			appsignalsconfig.NewDockerResolver(hostedIn, endpoint),
+		}
+	case appsignalsconfig.PlatformNomad:
+		endpoint, _ := getMetricsString(conf, "platform_endpoint")
+		cfg.Resolvers = []appsignalsconfig.Resolver{
+			appsignalsconfig.NewNomadResolver(hostedIn, endpoint),
+		}
+	}
+
 	limiterConfig, _ := t.translateMetricLimiterConfig(conf, configKey)
 	cfg.Limiter = limiterConfig
 
+	// the traces and the metrics pipelines share the processor, so the option is read from the traces section for both
+	for _, tracesConfigKey := range common.AppSignalsConfigKeys[pipeline.SignalTraces] {
+		if generate, ok := common.GetBool(conf, common.ConfigKey(tracesConfigKey, "generate_span_metrics")); ok {
+			cfg.GenerateSpanMetrics = generate
+			break
+		}
+	}
+
 	return t.translateCustomRules(conf, configKey, cfg)
 }
 
+// getMetricsString returns the option of the metrics section, which is shared
+// by both signals like hosted_in.
+func getMetricsString(conf *confmap.Conf, key string) (string, bool) {
+	for _, metricsConfigKey := range common.AppSignalsConfigKeys[pipeline.SignalMetrics] {
+		if value, ok := common.GetString(conf, common.ConfigKey(metricsConfigKey, key)); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
 func (t *translator) translateMetricLimiterConfig(conf *confmap.Conf, configKey []string) (*appsignalsconfig.LimiterConfig, error) {
 	limiterConfigKey := common.ConfigKey(configKey[0], "limiter")
 	if !conf.IsSet(limiterConfigKey) {
@@ -167,6 +200,20 @@ func (t *translator) translateMetricLimiterConfig(conf *confmap.Conf, configKey
 			}
 		}
 	}
+	if rawVal, exists := configJson["state_file"]; exists {
+		if val, ok := rawVal.(string); !ok {
+			return nil, errors.New("type conversion error: state_file is not a string")
+		} else {
+			limiterConfig.StateFile = val
+		}
+	}
+	if rawVal, exists := configJson["debug_listen_addr"]; exists {
+		if val, ok := rawVal.(string); !ok {
+			return nil, errors.New("type conversion error: debug_listen_addr is not a string")
+		} else {
+			limiterConfig.DebugListenAddress = val
+		}
+	}
 	return limiterConfig, nil
 
 }
@@ -201,6 +248,13 @@ func (t *translator) translateCustomRules(conf *confmap.Conf, configKey []string
 				}
 				ruleConfig.Replacements = getServiceReplacements(replacements)
 			}
+			if ruleConfig.Action == rules.AllowListActionRename {
+				renames, ok := ruleMap["renames"]
+				if !ok {
+					return nil, errors.New("rename action set, but no renames defined for service rule")
+				}
+				ruleConfig.Renames = getServiceRenames(renames)
+			}
 
 			rulesList = append(rulesList, ruleConfig)
 		}
@@ -217,7 +271,12 @@ func getServiceSelectors(selectorsList []interface{}) []rules.Selector {
 		selectorsMap := selector.(map[string]interface{})
 
 		selectorConfig.Dimension = selectorsMap["dimension"].(string)
-		selectorConfig.Match = selectorsMap["match"].(string)
+		if match, ok := selectorsMap["match"]; ok {
+			selectorConfig.Match = match.(string)
+		}
+		if matchRegex, ok := selectorsMap["match_regex"]; ok {
+			selectorConfig.MatchRegex = matchRegex.(string)
+		}
 		selectors = append(selectors, selectorConfig)
 	}
 	return selectors
@@ -235,3 +294,16 @@ func getServiceReplacements(replacementsList interface{}) []rules.Replacement {
 	}
 	return replacements
 }
+
+func getServiceRenames(renamesList interface{}) []rules.Rename {
+	var renames []rules.Rename
+	for _, rename := range renamesList.([]interface{}) {
+		renameConfig := rules.Rename{}
+		renameMap := rename.(map[string]interface{})
+
+		renameConfig.SourceDimension = renameMap["source_dimension"].(string)
+		renameConfig.TargetDimension = renameMap["target_dimension"].(string)
+		renames = append(renames, renameConfig)
+	}
+	return renames
+}
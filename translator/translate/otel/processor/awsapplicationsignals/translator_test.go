@@ -30,6 +30,12 @@ var (
 	validAppSignalsYamlEC2 string
 	//go:embed testdata/config_generic.yaml
 	validAppSignalsYamlGeneric string
+	//go:embed testdata/config_generic_span_metrics.yaml
+	validAppSignalsSpanMetricsYamlGeneric string
+	//go:embed testdata/config_docker.yaml
+	validAppSignalsYamlDocker string
+	//go:embed testdata/config_nomad.yaml
+	validAppSignalsYamlNomad string
 	//go:embed testdata/validRulesConfig.json
 	validAppSignalsRulesConfig string
 	//go:embed testdata/validRulesConfigEKS.yaml
@@ -164,6 +170,24 @@ func TestTranslate(t *testing.T) {
 			kubernetesMode: translatorConfig.ModeEKS,
 			mode:           translatorConfig.ModeEC2,
 		},
+		"WithAppSignalsSpanMetricsGeneric": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{},
+					},
+				},
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"generate_span_metrics": true,
+						},
+					},
+				}},
+			want:         validAppSignalsSpanMetricsYamlGeneric,
+			isKubernetes: false,
+			mode:         translatorConfig.ModeOnPrem,
+		},
 		"WithAppSignalsFallbackEnabledEC2": {
 			input: map[string]interface{}{
 				"logs": map[string]interface{}{
@@ -176,6 +200,32 @@ func TestTranslate(t *testing.T) {
 			want: validAppSignalsYamlEC2,
 			mode: translatorConfig.ModeEC2,
 		},
+		"WithAppSignalsDockerEC2": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"hosted_in": "test",
+							"platform":  "docker",
+						},
+					},
+				}},
+			want: validAppSignalsYamlDocker,
+			mode: translatorConfig.ModeEC2,
+		},
+		"WithAppSignalsFallbackNomadOnPrem": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"app_signals": map[string]interface{}{
+							"platform":          "nomad",
+							"platform_endpoint": "http://10.0.0.1:4646",
+						},
+					},
+				}},
+			want: validAppSignalsYamlNomad,
+			mode: translatorConfig.ModeOnPrem,
+		},
 	}
 	factory := awsapplicationsignals.NewFactory()
 	for name, testCase := range testCases {
@@ -99,6 +99,20 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		cfg.TransformEntity = t.transform
 	}
 
+	hostMetadata, err := common.GetHostMetadata(conf)
+	if err != nil {
+		return nil, err
+	}
+	cfg.HostMetadata = hostMetadata != nil
+
+	if cfg.EntityType == Service {
+		rules, err := common.GetEntityRules(conf)
+		if err != nil {
+			return nil, err
+		}
+		cfg.EntityRules = rules
+	}
+
 	if cfg.KubernetesMode != "" {
 		clusterName, clusterNameConfigured := common.GetHostedIn(conf)
 
@@ -232,3 +232,68 @@ func TestTranslate(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateEntityRules(t *testing.T) {
+	ecsutil.GetECSUtilSingleton().Region = ""
+	context.CurrentContext().SetMode(config.ModeEC2)
+	context.CurrentContext().SetKubernetesMode("")
+	conf := confmap.NewFromStringMap(map[string]interface{}{
+		"agent": map[string]interface{}{
+			"entity_rules": []interface{}{
+				map[string]interface{}{
+					"source":      "resource_attribute",
+					"key":         "k8s.namespace.name",
+					"pattern":     "(?P<env>.+)-ns",
+					"environment": "{{env}}",
+					"priority":    2,
+				},
+			},
+		},
+	})
+
+	got, err := NewTranslatorWithEntityType(Service, "", false).Translate(conf)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Rule{
+		{Source: entity.RuleSourceResourceAttribute, Key: "k8s.namespace.name", Pattern: "(?P<env>.+)-ns", Environment: "{{env}}", Priority: 2},
+	}, got.(*awsentity.Config).EntityRules)
+
+	// the rules only apply to Service entities
+	got, err = NewTranslatorWithEntityType(Resource, "", false).Translate(conf)
+	assert.NoError(t, err)
+	assert.Nil(t, got.(*awsentity.Config).EntityRules)
+
+	conf = confmap.NewFromStringMap(map[string]interface{}{
+		"agent": map[string]interface{}{
+			"entity_rules": "invalid",
+		},
+	})
+	_, err = NewTranslatorWithEntityType(Service, "", false).Translate(conf)
+	assert.Error(t, err)
+}
+
+func TestTranslateHostMetadata(t *testing.T) {
+	ecsutil.GetECSUtilSingleton().Region = ""
+	context.CurrentContext().SetMode(config.ModeOnPremise)
+	context.CurrentContext().SetKubernetesMode("")
+	defer context.CurrentContext().SetMode(config.ModeEC2)
+
+	got, err := NewTranslatorWithEntityType(Service, "", false).Translate(confmap.New())
+	assert.NoError(t, err)
+	assert.False(t, got.(*awsentity.Config).HostMetadata)
+
+	got, err = NewTranslatorWithEntityType(Service, "", false).Translate(confmap.NewFromStringMap(map[string]interface{}{
+		"agent": map[string]interface{}{
+			"host_metadata": map[string]interface{}{"command": []interface{}{"/usr/local/bin/host-metadata"}},
+		},
+	}))
+	assert.NoError(t, err)
+	assert.True(t, got.(*awsentity.Config).HostMetadata)
+	assert.Equal(t, config.ModeOnPremise, got.(*awsentity.Config).Platform)
+
+	_, err = NewTranslatorWithEntityType(Service, "", false).Translate(confmap.NewFromStringMap(map[string]interface{}{
+		"agent": map[string]interface{}{
+			"host_metadata": map[string]interface{}{},
+		},
+	}))
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@
 package ec2taggerprocessor
 
 import (
+	"slices"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -17,7 +18,16 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
 )
 
-var Ec2taggerKey = common.ConfigKey(common.MetricsKey, common.AppendDimensionsKey)
+var (
+	Ec2taggerKey       = common.ConfigKey(common.MetricsKey, common.AppendDimensionsKey)
+	ec2InstanceTagsKey = common.ConfigKey(common.MetricsKey, common.EC2InstanceTagsKey)
+)
+
+const (
+	tagKeysKey           = "keys"
+	refreshIntervalKey   = "refresh_interval"
+	tagChangeLogGroupKey = "tag_change_log_group"
+)
 
 type translator struct {
 	name    string
@@ -59,7 +69,18 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		}
 	}
 
+	for _, key := range common.GetArray[string](conf, common.ConfigKey(ec2InstanceTagsKey, tagKeysKey)) {
+		if !slices.Contains(cfg.EC2InstanceTagKeys, key) {
+			cfg.EC2InstanceTagKeys = append(cfg.EC2InstanceTagKeys, key)
+		}
+	}
 	cfg.RefreshTagsInterval = time.Duration(0)
+	if interval, ok := common.GetDuration(conf, common.ConfigKey(ec2InstanceTagsKey, refreshIntervalKey)); ok {
+		cfg.RefreshTagsInterval = interval
+	}
+	if logGroup, ok := common.GetString(conf, common.ConfigKey(ec2InstanceTagsKey, tagChangeLogGroupKey)); ok {
+		cfg.TagChangeLogGroup = logGroup
+	}
 	cfg.RefreshVolumesInterval = time.Duration(0)
 	if value, ok := common.GetString(conf, common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.DiskKey, common.AppendDimensionsKey, ec2tagger.AttributeVolumeId)); ok && value == ec2tagger.ValueAppendDimensionVolumeId {
 		cfg.RefreshVolumesInterval = 5 * time.Minute
@@ -67,6 +88,12 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		cfg.DiskDeviceTagKey = "device"
 	}
 
+	hostMetadata, err := common.GetHostMetadata(conf)
+	if err != nil {
+		return nil, err
+	}
+	cfg.HostMetadata = hostMetadata
+
 	cfg.MiddlewareID = &agenthealth.StatusCodeID
 	cfg.IMDSRetries = retryer.GetDefaultRetryNumber()
 
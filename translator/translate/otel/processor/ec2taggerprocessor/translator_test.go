@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/confmap"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 )
@@ -74,6 +75,64 @@ func TestTranslator(t *testing.T) {
 				EBSDeviceKeys:          []string{"*"},
 			},
 		},
+		"WithInstanceTags": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"append_dimensions": map[string]interface{}{
+						"AutoScalingGroupName": "${aws:AutoScalingGroupName}",
+					},
+					"ec2_instance_tags": map[string]interface{}{
+						"keys":                 []interface{}{"AutoScalingGroupName", "Environment", "team:*"},
+						"refresh_interval":     float64(300),
+						"tag_change_log_group": "/aws/ec2/tag-changes",
+					},
+				},
+			},
+			want: &ec2tagger.Config{
+				RefreshTagsInterval:    5 * time.Minute,
+				RefreshVolumesInterval: 0 * time.Minute,
+				EC2InstanceTagKeys:     []string{"AutoScalingGroupName", "Environment", "team:*"},
+				TagChangeLogGroup:      "/aws/ec2/tag-changes",
+			},
+		},
+		"WithInstanceTagsWithoutRefresh": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"append_dimensions": map[string]interface{}{
+						"InstanceId": "${aws:InstanceId}",
+					},
+					"ec2_instance_tags": map[string]interface{}{
+						"keys": []interface{}{"Name"},
+					},
+				},
+			},
+			want: &ec2tagger.Config{
+				RefreshTagsInterval:    0 * time.Second,
+				RefreshVolumesInterval: 0 * time.Minute,
+				EC2MetadataTags:        []string{"InstanceId"},
+				EC2InstanceTagKeys:     []string{"Name"},
+			},
+		},
+		"WithHostMetadata": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"host_metadata": map[string]interface{}{
+						"file": "/etc/host-metadata.yaml",
+					},
+				},
+				"metrics": map[string]interface{}{
+					"append_dimensions": map[string]interface{}{
+						"InstanceId": "${aws:InstanceId}",
+					},
+				},
+			},
+			want: &ec2tagger.Config{
+				RefreshTagsInterval:    0 * time.Second,
+				RefreshVolumesInterval: 0 * time.Minute,
+				EC2MetadataTags:        []string{"InstanceId"},
+				HostMetadata:           &hostmetadata.Config{File: "/etc/host-metadata.yaml"},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -91,6 +150,8 @@ func TestTranslator(t *testing.T) {
 				require.Equal(t, tc.want.EC2InstanceTagKeys, gotCfg.EC2InstanceTagKeys)
 				require.Equal(t, tc.want.DiskDeviceTagKey, gotCfg.DiskDeviceTagKey)
 				require.Equal(t, tc.want.EBSDeviceKeys, gotCfg.EBSDeviceKeys)
+				require.Equal(t, tc.want.TagChangeLogGroup, gotCfg.TagChangeLogGroup)
+				require.Equal(t, tc.want.HostMetadata, gotCfg.HostMetadata)
 			}
 		})
 	}
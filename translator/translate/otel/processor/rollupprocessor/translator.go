@@ -4,6 +4,7 @@
 package rollupprocessor
 
 import (
+	"fmt"
 	"sort"
 
 	"go.opentelemetry.io/collector/component"
@@ -18,6 +19,9 @@ import (
 type translator struct {
 	name    string
 	factory processor.Factory
+	// rulesOnly leaves the aggregation_dimensions and drop_original_metrics to
+	// the CloudWatch exporter, which rolls them up itself.
+	rulesOnly bool
 }
 
 var _ common.ComponentTranslator = (*translator)(nil)
@@ -30,21 +34,56 @@ func NewTranslatorWithName(name string) common.ComponentTranslator {
 	return &translator{name: name, factory: rollupprocessor.NewFactory()}
 }
 
+// NewRulesTranslator creates the rollup processor of the pipelines that send
+// to CloudWatch, which only has the metrics.rollup_rules.
+func NewRulesTranslator() common.ComponentTranslator {
+	return &translator{name: common.CloudWatchKey, factory: rollupprocessor.NewFactory(), rulesOnly: true}
+}
+
 func (t *translator) ID() component.ID {
 	return component.NewIDWithName(t.factory.Type(), t.name)
 }
 
 func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
-	if conf == nil || !conf.IsSet(common.MetricsAggregationDimensionsKey) {
+	if t.rulesOnly {
+		if conf == nil || !conf.IsSet(common.MetricsRollupRulesKey) {
+			return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.MetricsRollupRulesKey}
+		}
+	} else if conf == nil || (!conf.IsSet(common.MetricsAggregationDimensionsKey) && !conf.IsSet(common.MetricsRollupRulesKey)) {
 		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.MetricsAggregationDimensionsKey}
 	}
 	cfg := t.factory.CreateDefaultConfig().(*rollupprocessor.Config)
-	if rollupDimensions := common.GetRollupDimensions(conf); len(rollupDimensions) != 0 {
-		cfg.AttributeGroups = rollupDimensions
+	if !t.rulesOnly {
+		if rollupDimensions := common.GetRollupDimensions(conf); len(rollupDimensions) != 0 {
+			cfg.AttributeGroups = rollupDimensions
+		}
+		if dropOriginalMetrics := common.GetDropOriginalMetrics(conf); len(dropOriginalMetrics) != 0 {
+			cfg.DropOriginal = maps.Keys(dropOriginalMetrics)
+			sort.Strings(cfg.DropOriginal)
+		}
 	}
-	if dropOriginalMetrics := common.GetDropOriginalMetrics(conf); len(dropOriginalMetrics) != 0 {
-		cfg.DropOriginal = maps.Keys(dropOriginalMetrics)
-		sort.Strings(cfg.DropOriginal)
+	rules, err := getRollupRules(conf)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Rules = rules
+	if err = cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", t.ID(), err)
 	}
 	return cfg, nil
 }
+
+// getRollupRules returns the metrics.rollup_rules, which have the same keys as
+// the rollup processor rules.
+func getRollupRules(conf *confmap.Conf) ([]rollupprocessor.Rule, error) {
+	if !conf.IsSet(common.MetricsRollupRulesKey) {
+		return nil, nil
+	}
+	var rules struct {
+		Rules []rollupprocessor.Rule `mapstructure:"rollup_rules"`
+	}
+	if err := confmap.NewFromStringMap(map[string]any{common.RollupRulesKey: conf.Get(common.MetricsRollupRulesKey)}).Unmarshal(&rules); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", common.MetricsRollupRulesKey, err)
+	}
+	return rules.Rules, nil
+}
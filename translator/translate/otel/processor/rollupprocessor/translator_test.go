@@ -43,6 +43,37 @@ func TestTranslator(t *testing.T) {
 				CacheSize:       1000,
 			},
 		},
+		"WithOnlyRollupRules": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"rollup_rules": []interface{}{
+						map[string]interface{}{
+							"metric_names":             []interface{}{"disk_*"},
+							"exclude_attribute_groups": []interface{}{[]interface{}{"path"}},
+							"aggregation":              "max",
+							"drop_original":            true,
+						},
+						map[string]interface{}{
+							"attribute_groups": []interface{}{[]interface{}{"InstanceId"}},
+						},
+					},
+				},
+			},
+			want: &rollupprocessor.Config{
+				Rules: []rollupprocessor.Rule{
+					{
+						MetricNames:            []string{"disk_*"},
+						ExcludeAttributeGroups: [][]string{{"path"}},
+						Aggregation:            rollupprocessor.AggregationMax,
+						DropOriginal:           true,
+					},
+					{
+						AttributeGroups: [][]string{{"InstanceId"}},
+					},
+				},
+				CacheSize: 1000,
+			},
+		},
 		"WithFull": {
 			input: testutil.GetJson(t, filepath.Join("testdata", "config.json")),
 			want: &rollupprocessor.Config{
@@ -63,7 +94,57 @@ func TestTranslator(t *testing.T) {
 				require.True(t, ok)
 				assert.Equal(t, testCase.want.AttributeGroups, gotCfg.AttributeGroups)
 				assert.Equal(t, testCase.want.DropOriginal, gotCfg.DropOriginal)
+				assert.Equal(t, testCase.want.Rules, gotCfg.Rules)
 			}
 		})
 	}
 }
+
+func TestTranslatorWithInvalidRollupRule(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"rollup_rules": []interface{}{
+				map[string]interface{}{
+					"metric_names": []interface{}{"disk_*"},
+					"aggregation":  "max",
+				},
+			},
+		},
+	})
+	_, err := NewTranslator().Translate(conf)
+	assert.EqualError(t, err, "invalid rollup config: invalid rule 0: attribute_groups, exclude_attribute_groups or drop_original must be set")
+}
+
+func TestRulesTranslator(t *testing.T) {
+	rpt := NewRulesTranslator()
+	require.EqualValues(t, "rollup/cloudwatch", rpt.ID().String())
+	_, err := rpt.Translate(confmap.NewFromStringMap(map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"aggregation_dimensions": []interface{}{[]interface{}{"d1"}},
+		},
+	}))
+	assert.Equal(t, &common.MissingKeyError{ID: rpt.ID(), JsonKey: common.MetricsRollupRulesKey}, err)
+
+	input := testutil.GetJson(t, filepath.Join("testdata", "config.json"))
+	input["metrics"].(map[string]interface{})["rollup_rules"] = []interface{}{
+		map[string]interface{}{
+			"metric_names":             []interface{}{"disk_used_percent"},
+			"exclude_attribute_groups": []interface{}{[]interface{}{"path"}},
+			"aggregation":              "max",
+			"drop_original":            true,
+		},
+	}
+	got, err := rpt.Translate(confmap.NewFromStringMap(input))
+	require.NoError(t, err)
+	gotCfg, ok := got.(*rollupprocessor.Config)
+	require.True(t, ok)
+	// the exporter rolls up the aggregation_dimensions and drops the original metrics
+	assert.Empty(t, gotCfg.AttributeGroups)
+	assert.Empty(t, gotCfg.DropOriginal)
+	assert.Equal(t, []rollupprocessor.Rule{{
+		MetricNames:            []string{"disk_used_percent"},
+		ExcludeAttributeGroups: [][]string{{"path"}},
+		Aggregation:            rollupprocessor.AggregationMax,
+		DropOriginal:           true,
+	}}, gotCfg.Rules)
+}
@@ -14,10 +14,12 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	translatorconfig "github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/files"
+	kernelevents "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/kernel_events"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/windows_events"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
 	collectd "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/collectd"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/customizedmetrics"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/exec"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/gpu"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/procstat"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/statsd"
@@ -30,10 +32,13 @@ const (
 )
 
 var (
-	logKey           = common.ConfigKey(common.LogsKey, common.LogsCollectedKey)
-	metricKey        = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey)
-	skipInputSet     = collections.NewSet[string](files.SectionKey, windows_events.SectionKey)
-	multipleInputSet = collections.NewSet[string](procstat.SectionKey)
+	logKey    = common.ConfigKey(common.LogsKey, common.LogsCollectedKey)
+	metricKey = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey)
+	// skipInputSet contains the logs_collected inputs that are not collected
+	// through an adapter receiver. The same keys in metrics_collected, e.g.
+	// kernel_events, are metric inputs.
+	skipInputSet     = collections.NewSet[string](files.SectionKey, windows_events.SectionKey, kernelevents.SectionKey, common.OtlpKey)
+	multipleInputSet = collections.NewSet[string](procstat.SectionKey, exec.SectionKey)
 	// Order by PidFile, ExeKey, Pattern Key according to the public documents
 	// if multiple configuration is specified
 	// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch-Agent-procstat-process-metrics.html#CloudWatch-Agent-procstat-configuration
@@ -148,8 +153,8 @@ func fromInputs(conf *confmap.Conf, validInputs map[string]bool, baseKey string)
 	translators := common.NewTranslatorMap[component.Config, component.ID]()
 	if inputs, ok := conf.Get(baseKey).(map[string]interface{}); ok {
 		for inputName := range inputs {
-			if skipInputSet.Contains(inputName) {
-				// logs agent is separate from otel agent
+			if baseKey == logKey && skipInputSet.Contains(inputName) {
+				// logs agent is separate from otel agent and OTLP logs have their own pipeline
 				continue
 			}
 			if validInputs != nil {
@@ -240,6 +245,23 @@ func fromMultipleInput(conf *confmap.Conf, inputName, os string) common.Translat
 				}
 			}
 		}
+	} else if inputName == exec.SectionKey {
+		// Each command has its own input, identified by the command, so
+		// that every command can have its own interval.
+		for _, execKey := range common.GetArray[any](conf, cfgKey) {
+			commandConfig := execKey.(map[string]interface{})
+			command, ok := commandConfig[exec.CommandKey].([]interface{})
+			if !ok || len(command) == 0 {
+				continue
+			}
+			collectionInterval, _ := common.ParseDuration(commandConfig[common.MetricsCollectionIntervalKey])
+			translators.Set(NewTranslatorWithName(
+				exec.CommandName(command),
+				exec.SectionKey,
+				cfgKey,
+				collectionInterval,
+				defaultMetricsCollectionInterval))
+		}
 	} else if os == translatorconfig.OS_TYPE_WINDOWS && !windowsInputSet.Contains(inputName) && !skipWindowsInputSet.Contains(inputName) {
 		/* For customized metrics from Windows and  window performance counters metrics
 		   	[[inputs.win_perf_counters.object]]
@@ -26,6 +26,8 @@ func TestFindReceiversInConfig(t *testing.T) {
 	telegrafNvidiaSmiType, _ := component.NewType("telegraf_nvidia_smi")
 	telegrafStatsdType, _ := component.NewType("telegraf_statsd")
 	telegrafProcstatType, _ := component.NewType("telegraf_procstat")
+	telegrafExecType, _ := component.NewType("telegraf_exec")
+	telegrafKernelEventsType, _ := component.NewType("telegraf_kernel_events")
 	telegrafWinPerfCountersType, _ := component.NewType("telegraf_win_perf_counters")
 	type wantResult struct {
 		cfgKey   string
@@ -60,6 +62,15 @@ func TestFindReceiversInConfig(t *testing.T) {
 								"exe": "amazon-ssm-agent",
 							},
 						},
+						"exec": []interface{}{
+							map[string]interface{}{
+								"command": []interface{}{"/usr/local/bin/queue-depth.sh"},
+							},
+							map[string]interface{}{
+								"command":                     []interface{}{"/usr/local/bin/check.sh", "--json"},
+								"metrics_collection_interval": 300,
+							},
+						},
 					},
 				},
 			},
@@ -73,6 +84,8 @@ func TestFindReceiversInConfig(t *testing.T) {
 				component.NewID(telegrafStatsdType):                         {"metrics::metrics_collected::statsd", 10 * time.Second},
 				component.NewIDWithName(telegrafProcstatType, "793254176"):  {"metrics::metrics_collected::procstat", time.Minute},
 				component.NewIDWithName(telegrafProcstatType, "3599690165"): {"metrics::metrics_collected::procstat", time.Minute},
+				component.NewIDWithName(telegrafExecType, "2381630691"):     {"metrics::metrics_collected::exec", time.Minute},
+				component.NewIDWithName(telegrafExecType, "3713506858"):     {"metrics::metrics_collected::exec", time.Minute},
 			},
 		},
 		"WithWindowsMetrics": {
@@ -121,12 +134,35 @@ func TestFindReceiversInConfig(t *testing.T) {
 					"logs_collected": map[string]interface{}{
 						"files":          map[string]interface{}{},
 						"windows_events": map[string]interface{}{},
+						"otlp":           map[string]interface{}{},
 					},
 				},
 			},
 			os:   translatorconfig.OS_TYPE_WINDOWS,
 			want: map[component.ID]wantResult{},
 		},
+		"WithKernelEvents": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"kernel_events": map[string]interface{}{
+							"measurement": []interface{}{"oom_kill"},
+						},
+					},
+				},
+				"logs": map[string]interface{}{
+					"logs_collected": map[string]interface{}{
+						"kernel_events": map[string]interface{}{
+							"log_group_name": "kernel-events",
+						},
+					},
+				},
+			},
+			os: translatorconfig.OS_TYPE_LINUX,
+			want: map[component.ID]wantResult{
+				component.NewID(telegrafKernelEventsType): {"metrics::metrics_collected::kernel_events", time.Minute},
+			},
+		},
 		"WithNoSocketListener": {
 			input: map[string]interface{}{
 				"logs": map[string]interface{}{
@@ -32,6 +32,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/host"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/jmx"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/nop"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/otlp_logs"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/prometheus"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/xray"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util/ecsutil"
@@ -74,6 +75,7 @@ func Translate(jsonConfig interface{}, os string) (*otelcol.Config, error) {
 	translators.Set(applicationsignals.NewTranslator(pipeline.SignalMetrics))
 	translators.Merge(prometheus.NewTranslators(conf))
 	translators.Set(emf_logs.NewTranslator())
+	translators.Set(otlp_logs.NewTranslator())
 	translators.Set(xray.NewTranslator())
 	translators.Set(containerinsightsjmx.NewTranslator())
 	translators.Merge(jmx.NewTranslators(conf))
@@ -90,7 +92,7 @@ func Translate(jsonConfig interface{}, os string) (*otelcol.Config, error) {
 	if !ecsutil.GetECSUtilSingleton().IsECS() {
 		pipelines.Translators.Extensions.Set(entitystore.NewTranslator())
 	}
-	if context.CurrentContext().KubernetesMode() != "" {
+	if context.CurrentContext().KubernetesMode() != "" || conf.IsSet(common.AgentStatusEndpointConfigKey) {
 		pipelines.Translators.Extensions.Set(server.NewTranslator())
 	}
 
@@ -4,12 +4,16 @@
 package util
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util/ec2util"
@@ -24,6 +28,9 @@ const (
 	awsRegionPlaceholder     = "{aws_region}"
 	datePlaceholder          = "{date}"
 	accountIdPlaceholder     = "{account_id}"
+	// ec2TagPlaceholderPrefix starts the placeholder of an instance tag, e.g.
+	// {ec2_tag:Environment}
+	ec2TagPlaceholderPrefix = "{ec2_tag:"
 
 	unknownInstanceID   = "i-UNKNOWN"
 	unknownHostname     = "UNKNOWN-HOST"
@@ -32,10 +39,13 @@ const (
 	unknownAccountID    = "UNKNOWN-ACCOUNT"
 	unknownInstanceType = "UNKNOWN-TYPE"
 	unknownImageID      = "UNKNOWN-AMI"
+	unknownTagValue     = "UNKNOWN-TAG"
 
 	awsPlaceholderPrefix = "${aws:"
 )
 
+var ec2TagPlaceholderPattern = regexp.MustCompile(`\{ec2_tag:([^{}]+)\}`)
+
 type Metadata struct {
 	InstanceID   string
 	Hostname     string
@@ -54,6 +64,15 @@ var Ec2MetadataInfoProvider = func() *Metadata {
 }
 
 func ec2MetadataInfoProvider() *Metadata {
+	if md := getHostMetadata(); md != nil {
+		return &Metadata{
+			InstanceID:   md.HostID,
+			Hostname:     md.Hostname,
+			AccountID:    md.AccountID,
+			InstanceType: md.InstanceType,
+			ImageID:      md.ImageID,
+		}
+	}
 	ec2 := ec2util.GetEC2UtilSingleton()
 	return &Metadata{
 		InstanceID:   ec2.InstanceID,
@@ -65,6 +84,36 @@ func ec2MetadataInfoProvider() *Metadata {
 	}
 }
 
+// hostMetadataCache keeps the host metadata of the agent section, so that the
+// command is not run for every placeholder.
+var hostMetadataCache struct {
+	sync.Mutex
+	cfg      *hostmetadata.Config
+	provider *hostmetadata.Provider
+}
+
+// getHostMetadata returns the host metadata if it is configured in the agent
+// section, or nil to use the EC2 metadata.
+func getHostMetadata() *hostmetadata.Metadata {
+	cfg := agent.Global_Config.HostMetadata
+	if cfg == nil {
+		return nil
+	}
+	hostMetadataCache.Lock()
+	if hostMetadataCache.cfg != cfg {
+		hostMetadataCache.cfg = cfg
+		hostMetadataCache.provider = hostmetadata.NewProvider(*cfg)
+	}
+	provider := hostMetadataCache.provider
+	hostMetadataCache.Unlock()
+	md, err := provider.Metadata(context.Background())
+	if err != nil {
+		log.Printf("E! Unable to load the host metadata, using the EC2 metadata instead: %v", err)
+		return nil
+	}
+	return md
+}
+
 func ResolvePlaceholder(placeholder string, metadata map[string]string) string {
 	tmpString := placeholder
 	if tmpString == "" {
@@ -74,9 +123,36 @@ func ResolvePlaceholder(placeholder string, metadata map[string]string) string {
 		tmpString = strings.Replace(tmpString, k, v, -1)
 	}
 	tmpString = strings.Replace(tmpString, datePlaceholder, time.Now().Format("2006-01-02"), -1)
+	if strings.Contains(tmpString, ec2TagPlaceholderPrefix) {
+		tmpString = resolveEC2TagPlaceholders(tmpString, metadata[instanceIdPlaceholder])
+	}
 	return tmpString
 }
 
+var ec2TagValueProvider = tagutil.GetTagValue
+
+// resolveEC2TagPlaceholders replaces the {ec2_tag:<key>} placeholders with the
+// values of the instance tags. The tags are described when the configuration
+// is translated, later changes of the tags do not rename the log groups.
+func resolveEC2TagPlaceholders(input string, instanceID string) string {
+	return ec2TagPlaceholderPattern.ReplaceAllStringFunc(input, func(placeholder string) string {
+		if instanceID == "" || instanceID == unknownInstanceID {
+			return unknownTagValue
+		}
+		key := ec2TagPlaceholderPattern.FindStringSubmatch(placeholder)[1]
+		return defaultIfEmpty(getTagValue(instanceID, key), unknownTagValue)
+	})
+}
+
+// getTagValue returns the value of the tag of the host metadata, or of the
+// instance tag.
+func getTagValue(instanceID string, key string) string {
+	if md := getHostMetadata(); md != nil {
+		return md.Tags[key]
+	}
+	return ec2TagValueProvider(instanceID, key)
+}
+
 func defaultIfEmpty(value, defaultValue string) string {
 	if value == "" {
 		return defaultValue
@@ -133,7 +209,12 @@ func getTagMetadata() map[string]string {
 	}
 
 	result := make(map[string]string)
-	asgName := tagutil.GetAutoScalingGroupName(instanceID)
+	var asgName string
+	if hostMD := getHostMetadata(); hostMD != nil {
+		asgName = defaultIfEmpty(hostMD.Tags[ec2tagger.Ec2InstanceTagKeyASG], hostMD.Tags[ec2tagger.CWDimensionASG])
+	} else {
+		asgName = tagutil.GetAutoScalingGroupName(instanceID)
+	}
 	if asgName != "" {
 		result[ec2tagger.SupportedAppendDimensions["AutoScalingGroupName"]] = asgName
 	}
@@ -4,11 +4,15 @@
 package util
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/hostmetadata"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util/tagutil"
 )
 
@@ -258,3 +262,42 @@ func TestAWSMetadataFunctionality(t *testing.T) {
 	assert.Equal(t, "t3.micro", resultMap2["InstanceType"])
 	assert.Equal(t, "ami-test123", resultMap2["ImageId"])
 }
+
+func TestResolvePlaceholderWithEC2Tags(t *testing.T) {
+	defer func() { ec2TagValueProvider = tagutil.GetTagValue }()
+	ec2TagValueProvider = func(instanceID string, key string) string {
+		assert.Equal(t, dummyInstanceId, instanceID)
+		return map[string]string{"Environment": "blue", "team:name": "payments"}[key]
+	}
+	metadata := GetMetadataInfo(mockMetadataProvider(dummyInstanceId, dummyHostName, dummyPrivateIp, dummyAccountId))
+
+	assert.Equal(t, "/app/blue/payments", ResolvePlaceholder("/app/{ec2_tag:Environment}/{ec2_tag:team:name}", metadata))
+	assert.Equal(t, "some_instance_id-UNKNOWN-TAG", ResolvePlaceholder("{instance_id}-{ec2_tag:Missing}", metadata))
+
+	metadata = GetMetadataInfo(mockMetadataProvider("", dummyHostName, dummyPrivateIp, dummyAccountId))
+	assert.Equal(t, "/app/UNKNOWN-TAG", ResolvePlaceholder("/app/{ec2_tag:Environment}", metadata))
+}
+
+func TestPlaceholdersWithHostMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "host-metadata.yaml")
+	content := "host_id: mi-0123456789abcdef0\nhostname: rack1-node7\ninstance_type: r740\ntags:\n  Environment: blue\n  AutoScalingGroupName: storage-fleet\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	defer func() { agent.Global_Config.HostMetadata = nil }()
+	agent.Global_Config.HostMetadata = &hostmetadata.Config{File: path}
+
+	metadata := GetMetadataInfo(Ec2MetadataInfoProvider)
+	assert.Equal(t, "mi-0123456789abcdef0", metadata[instanceIdPlaceholder])
+	assert.Equal(t, "rack1-node7", metadata[hostnamePlaceholder])
+	assert.Equal(t, "/app/blue/mi-0123456789abcdef0", ResolvePlaceholder("/app/{ec2_tag:Environment}/{instance_id}", metadata))
+
+	result := ResolveAWSMetadataPlaceholders(map[string]interface{}{
+		"InstanceId":           "${aws:InstanceId}",
+		"InstanceType":         "${aws:InstanceType}",
+		"AutoScalingGroupName": "${aws:AutoScalingGroupName}",
+	})
+	assert.Equal(t, map[string]interface{}{
+		"InstanceId":           "mi-0123456789abcdef0",
+		"InstanceType":         "r740",
+		"AutoScalingGroupName": "storage-fleet",
+	}, result)
+}
@@ -168,6 +168,19 @@ func GetAutoScalingGroupName(instanceID string) string {
 	return tc.tags[autoScalingGroupNameTag]
 }
 
+// GetTagValue gets the value of an instance tag, or "" if the instance has no
+// tag with the key
+func GetTagValue(instanceID string, key string) string {
+	if instanceID == "" {
+		return ""
+	}
+
+	tc := getTagsCache(instanceID)
+	tc.loadAllTags()
+
+	return tc.tags[key]
+}
+
 // GetEKSClusterName gets the EKS cluster name for an instance
 func GetEKSClusterName(instanceID string) string {
 	if instanceID == "" {
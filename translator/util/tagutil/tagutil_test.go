@@ -184,3 +184,34 @@ func (m *MockEC2TagsClientWithPagination) DescribeTags(_ *ec2.DescribeTagsInput)
 		NextToken: nil, // No more pages
 	}, nil
 }
+
+func TestGetTagValue(t *testing.T) {
+	ResetTagsCache()
+
+	mockClient := &MockEC2TagsClient{}
+	mockOutput := &ec2.DescribeTagsOutput{
+		Tags: []*ec2.TagDescription{
+			{
+				Key:   aws.String("Environment"),
+				Value: aws.String("blue"),
+			},
+		},
+	}
+	mockClient.On("DescribeTags", mock.Anything).Return(mockOutput, nil).Once()
+
+	SetEC2APIProviderForTesting(func() interface {
+		DescribeTags(input *ec2.DescribeTagsInput) (*ec2.DescribeTagsOutput, error)
+	} {
+		return mockClient
+	})
+
+	assert.Equal(t, "", GetTagValue("", "Environment"))
+	assert.Equal(t, "blue", GetTagValue("i-1234567890abcdef0", "Environment"))
+	// the tags are only described once
+	assert.Equal(t, "", GetTagValue("i-1234567890abcdef0", "Name"))
+	mockClient.AssertExpectations(t)
+
+	// Clean up
+	ResetEC2APIProvider()
+	ResetTagsCache()
+}